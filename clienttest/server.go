@@ -0,0 +1,520 @@
+// Package clienttest provides an in-memory fake of the Frontegg endpoints used by the
+// AgentLink provider, for running fast provider and module tests without a real vendor.
+package clienttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default credentials accepted by the fake server
+const (
+	DefaultClientID = "test-client-id"
+	DefaultSecret   = "test-secret"
+	DefaultVendorID = "test-vendor-id"
+)
+
+// Collections stored by the fake server
+const (
+	Applications      = "applications"
+	Sources           = "sources"
+	McpConfigurations = "mcp-configurations"
+	Tools             = "tools"
+	Policies          = "policies"
+	// TenantApplications stores the applications assigned to each tenant, keyed by tenant ID
+	TenantApplications = "tenant-applications"
+)
+
+// Object is a JSON object as stored and returned by the fake server
+type Object = map[string]interface{}
+
+// Request is a request received by the fake server
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   []byte
+}
+
+// Server is an in-memory fake of the Frontegg API
+type Server struct {
+	// URL is the base URL to configure the provider or client with
+	URL string
+
+	server   *httptest.Server
+	clientID string
+	secret   string
+	token    string
+
+	mu            sync.Mutex
+	nextID        int
+	collections   map[string]map[string]Object
+	importedTools []Object
+	failures      map[string]int
+	requests      []Request
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithCredentials sets the client ID and secret accepted by /auth/vendor
+func WithCredentials(clientID, secret string) Option {
+	return func(s *Server) {
+		s.clientID = clientID
+		s.secret = secret
+	}
+}
+
+// InMemoryURL is the base URL of servers created by NewInMemoryServer. It does not resolve;
+// requests only reach the fake through Transport.
+const InMemoryURL = "https://agentlink-mock.invalid"
+
+// NewServer starts a fake Frontegg server. Callers must call Close when done.
+func NewServer(opts ...Option) *Server {
+	s := newServer(opts...)
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	s.URL = s.server.URL
+	return s
+}
+
+// NewInMemoryServer creates a fake Frontegg server that does not listen on a port. Route a
+// client to it with Transport.
+func NewInMemoryServer(opts ...Option) *Server {
+	s := newServer(opts...)
+	s.URL = InMemoryURL
+	return s
+}
+
+func newServer(opts ...Option) *Server {
+	s := &Server{
+		clientID:    DefaultClientID,
+		secret:      DefaultSecret,
+		token:       "test-token",
+		collections: map[string]map[string]Object{},
+		failures:    map[string]int{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	if s.server != nil {
+		s.server.Close()
+	}
+}
+
+// Transport returns an http.RoundTripper that serves requests directly with the fake, whatever
+// their host
+func (s *Server) Transport() http.RoundTripper {
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		recorder := httptest.NewRecorder()
+		s.handle(recorder, r)
+
+		resp := recorder.Result()
+		resp.Request = r
+		return resp, nil
+	})
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// Put stores an object in a collection, assigning an ID if it has none, and returns the ID
+func (s *Server) Put(collection string, object Object) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.put(collection, object)
+}
+
+// Get returns a copy of an object from a collection, or nil if it does not exist
+func (s *Server) Get(collection, id string) Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.collections[collection][id]
+	if !ok {
+		return nil
+	}
+	return copyObject(object)
+}
+
+// List returns copies of all objects in a collection
+func (s *Server) List(collection string) []Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list(collection, nil)
+}
+
+// SetImportedTools sets the tools returned by the OpenAPI and GraphQL import endpoints
+func (s *Server) SetImportedTools(tools []Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.importedTools = tools
+}
+
+// FailNext makes the next request matching method and path (without query) fail with status
+func (s *Server) FailNext(method, path string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[method+" "+path] = status
+}
+
+// Requests returns the requests received so far, excluding authentication
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	var body []byte
+	if r.Body != nil && !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+		var raw json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&raw); err == nil {
+			body = raw
+		}
+	}
+
+	if r.URL.Path == "/auth/vendor" {
+		s.authenticate(w, body)
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		writeError(w, http.StatusUnauthorized, "missing or invalid token")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: body})
+
+	key := r.Method + " " + r.URL.Path
+	if status, ok := s.failures[key]; ok {
+		delete(s.failures, key)
+		writeError(w, status, "injected failure")
+		return
+	}
+
+	var object Object
+	if len(body) > 0 {
+		_ = json.Unmarshal(body, &object)
+	}
+
+	s.route(w, r, object)
+}
+
+func (s *Server) authenticate(w http.ResponseWriter, body []byte) {
+	var credentials struct {
+		ClientID string `json:"clientId"`
+		Secret   string `json:"secret"`
+	}
+	_ = json.Unmarshal(body, &credentials)
+
+	if credentials.ClientID != s.clientID || credentials.Secret != s.secret {
+		writeError(w, http.StatusUnauthorized, "invalid credentials")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Object{"token": s.token, "expiresIn": 3600})
+}
+
+// API paths served by the fake
+const (
+	applicationsPath = "/applications/resources/applications/v1"
+	sourcesPath      = "/app-integrations/resources/app-mcp-configuration-sources/v1"
+	mcpConfigPath    = "/app-integrations/resources/app-mcp-configurations/v1"
+	toolsPath        = "/app-integrations/resources/internal-tools/v1"
+	policiesPath     = "/app-integrations/resources/policies/v1"
+	tenantAppsPath   = "/applications/resources/applications/tenant-assignments/v1"
+)
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request, body Object) {
+	path := r.URL.Path
+	query := r.URL.Query()
+
+	switch {
+	// Applications
+	case path == applicationsPath && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.list(Applications, nil))
+	case path == applicationsPath && r.Method == http.MethodPost:
+		id := s.put(Applications, body)
+		writeJSON(w, http.StatusCreated, s.collections[Applications][id])
+	case strings.HasPrefix(path, applicationsPath+"/"):
+		s.item(w, r, Applications, strings.TrimPrefix(path, applicationsPath+"/"), body)
+
+	// Sources
+	case path == sourcesPath && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.list(Sources, Object{"appId": query.Get("appId")}))
+	case path == sourcesPath && r.Method == http.MethodPost:
+		id := s.put(Sources, body)
+		writeJSON(w, http.StatusCreated, s.collections[Sources][id])
+	case strings.HasPrefix(path, sourcesPath+"/") && r.Method != http.MethodGet:
+		// The API has no GET for a single source; sources are read from the list of their application
+		s.item(w, r, Sources, strings.TrimPrefix(path, sourcesPath+"/"), body)
+
+	// MCP configurations are upserted per application
+	case path == mcpConfigPath && r.Method == http.MethodGet:
+		configs := s.list(McpConfigurations, Object{"appId": query.Get("appId")})
+		if len(configs) == 0 {
+			writeError(w, http.StatusNotFound, "MCP configuration not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, configs[0])
+	case path == mcpConfigPath && r.Method == http.MethodPost:
+		for _, config := range s.list(McpConfigurations, Object{"appId": body["appId"]}) {
+			body["id"] = config["id"]
+		}
+		id := s.put(McpConfigurations, body)
+		writeJSON(w, http.StatusOK, s.collections[McpConfigurations][id])
+
+	// Tools
+	case path == toolsPath+"/openapi/import" || path == toolsPath+"/graphql/import":
+		tools := s.importedTools
+		if tools == nil {
+			tools = []Object{}
+		}
+		writeJSON(w, http.StatusOK, tools)
+	case path == toolsPath+"/upsert" && r.Method == http.MethodPost:
+		s.upsertTools(w, body)
+	case path == toolsPath && r.Method == http.MethodGet:
+		filter := Object{"appId": query.Get("appId")}
+		if sourceID := query.Get("sourceId"); sourceID != "" {
+			filter["sourceId"] = sourceID
+		}
+		writeJSON(w, http.StatusOK, Object{"items": s.list(Tools, filter)})
+	case strings.HasPrefix(path, toolsPath+"/") && r.Method == http.MethodDelete:
+		s.item(w, r, Tools, strings.TrimPrefix(path, toolsPath+"/"), body)
+
+	// Policies share one collection; RBAC and masking policies have their own create, get and update paths
+	case path == policiesPath && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.listPolicies("CONDITIONAL"))
+	case path == policiesPath+"/rbac" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, append(s.listPolicies("RBAC_ROLES"), s.listPolicies("RBAC_PERMISSIONS")...))
+	case path == policiesPath+"/masking" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.listPolicies("MASKING"))
+	case policyType(path) != "" && r.Method == http.MethodPost:
+		if t, _ := body["type"].(string); t == "" {
+			body["type"] = policyType(path)
+		}
+		id := s.put(Policies, body)
+		writeJSON(w, http.StatusCreated, Object{"id": id})
+	case strings.HasPrefix(path, policiesPath+"/rbac/"):
+		s.policyItem(w, r, strings.TrimPrefix(path, policiesPath+"/rbac/"), body, "RBAC_ROLES", "RBAC_PERMISSIONS")
+	case strings.HasPrefix(path, policiesPath+"/masking/"):
+		s.policyItem(w, r, strings.TrimPrefix(path, policiesPath+"/masking/"), body, "MASKING")
+	case strings.HasPrefix(path, policiesPath+"/") && r.Method != http.MethodPatch:
+		// Policies of every type are read and deleted at the conditional policy path
+		s.item(w, r, Policies, strings.TrimPrefix(path, policiesPath+"/"), body)
+	case strings.HasPrefix(path, policiesPath+"/"):
+		s.policyItem(w, r, strings.TrimPrefix(path, policiesPath+"/"), body, "CONDITIONAL")
+
+	// Tenant application assignments
+	case path == tenantAppsPath && r.Method == http.MethodGet:
+		s.listTenantAssignments(w, query.Get("tenantIds"))
+	case strings.HasPrefix(path, tenantAppsPath+"/"):
+		s.tenantAssignment(w, r, strings.TrimPrefix(path, tenantAppsPath+"/"), body)
+
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no fake handler for %s %s", r.Method, path))
+	}
+}
+
+// item handles GET, PATCH and DELETE on a single object
+func (s *Server) item(w http.ResponseWriter, r *http.Request, collection, id string, body Object) {
+	object, ok := s.collections[collection][id]
+	if !ok {
+		writeError(w, http.StatusNotFound, collection+" "+id+" not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, object)
+	case http.MethodPatch, http.MethodPut:
+		for k, v := range body {
+			object[k] = v
+		}
+		object["updatedAt"] = now()
+		writeJSON(w, http.StatusOK, object)
+	case http.MethodDelete:
+		delete(s.collections[collection], id)
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// policyItem handles a single policy at the path of its type; policies of other types are not found
+func (s *Server) policyItem(w http.ResponseWriter, r *http.Request, id string, body Object, types ...string) {
+	if object, ok := s.collections[Policies][id]; ok {
+		if t, _ := object["type"].(string); !slices.Contains(types, t) {
+			writeError(w, http.StatusNotFound, Policies+" "+id+" not found")
+			return
+		}
+	}
+	s.item(w, r, Policies, id, body)
+}
+
+// listTenantAssignments lists the applications assigned to the tenants of a tenantIds query
+func (s *Server) listTenantAssignments(w http.ResponseWriter, tenantIDs string) {
+	assignments := []Object{}
+	for _, tenantID := range strings.Split(tenantIDs, ",") {
+		if object, ok := s.collections[TenantApplications][tenantID]; ok {
+			assignments = append(assignments, Object{"tenantId": tenantID, "appIds": object["appIds"]})
+		}
+	}
+	writeJSON(w, http.StatusOK, assignments)
+}
+
+// tenantAssignment handles POST on {appId}, assigning the application to the tenant of the body,
+// and DELETE on {appId}/{tenantId}
+func (s *Server) tenantAssignment(w http.ResponseWriter, r *http.Request, rest string, body Object) {
+	appID, tenantID, hasTenant := strings.Cut(rest, "/")
+	if r.Method == http.MethodPost && !hasTenant {
+		tenantID, _ = body["tenantId"].(string)
+	} else if r.Method != http.MethodDelete || !hasTenant {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	assigned := []interface{}{}
+	if object, ok := s.collections[TenantApplications][tenantID]; ok {
+		assigned, _ = object["appIds"].([]interface{})
+	}
+
+	if r.Method == http.MethodPost {
+		if !slices.Contains(assigned, interface{}(appID)) {
+			assigned = append(assigned, appID)
+		}
+		s.put(TenantApplications, Object{"id": tenantID, "appIds": assigned})
+		writeJSON(w, http.StatusCreated, Object{"tenantId": tenantID, "appIds": assigned})
+		return
+	}
+
+	if !slices.Contains(assigned, interface{}(appID)) {
+		writeError(w, http.StatusNotFound, "application "+appID+" is not assigned to tenant "+tenantID)
+		return
+	}
+	assigned = slices.DeleteFunc(assigned, func(id interface{}) bool { return id == appID })
+	s.put(TenantApplications, Object{"id": tenantID, "appIds": assigned})
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) upsertTools(w http.ResponseWriter, body Object) {
+	items, _ := body["tools"].([]interface{})
+	result := make([]Object, 0, len(items))
+
+	for _, item := range items {
+		tool, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		tool["appId"] = body["appId"]
+
+		// Tools are matched by name within an application
+		for _, existing := range s.list(Tools, Object{"appId": body["appId"], "name": tool["name"]}) {
+			tool["id"] = existing["id"]
+		}
+		id := s.put(Tools, tool)
+		result = append(result, s.collections[Tools][id])
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) put(collection string, object Object) string {
+	if object == nil {
+		object = Object{}
+	}
+
+	id, _ := object["id"].(string)
+	if id == "" {
+		s.nextID++
+		id = fmt.Sprintf("%s-%d", strings.TrimSuffix(collection, "s"), s.nextID)
+		object["id"] = id
+		object["createdAt"] = now()
+	}
+	if _, ok := object["vendorId"]; !ok {
+		object["vendorId"] = DefaultVendorID
+	}
+
+	if s.collections[collection] == nil {
+		s.collections[collection] = map[string]Object{}
+	}
+	s.collections[collection][id] = object
+	return id
+}
+
+// list returns copies of the objects of a collection matching every non-empty filter value
+func (s *Server) list(collection string, filter Object) []Object {
+	result := []Object{}
+	for _, object := range s.collections[collection] {
+		matches := true
+		for k, v := range filter {
+			if v != "" && v != nil && object[k] != v {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			result = append(result, copyObject(object))
+		}
+	}
+	return result
+}
+
+func (s *Server) listPolicies(policyType string) []Object {
+	return s.list(Policies, Object{"type": policyType})
+}
+
+// policyType returns the default type of policies created at path, or "" if path is not a policy collection
+func policyType(path string) string {
+	switch path {
+	case policiesPath:
+		return "CONDITIONAL"
+	case policiesPath + "/rbac":
+		return "RBAC_ROLES"
+	case policiesPath + "/masking":
+		return "MASKING"
+	default:
+		return ""
+	}
+}
+
+func copyObject(object Object) Object {
+	result := make(Object, len(object))
+	for k, v := range object {
+		result[k] = v
+	}
+	return result
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("frontegg-trace-id", "fake-trace-id")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, Object{"statusCode": status, "message": message})
+}
@@ -0,0 +1,243 @@
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+)
+
+func newTestClient(t *testing.T) (*Server, *client.Client) {
+	t.Helper()
+	server := NewServer()
+	t.Cleanup(server.Close)
+	return server, client.NewClient(server.URL, DefaultClientID, DefaultSecret)
+}
+
+func TestServer_Authentication(t *testing.T) {
+	server := NewServer(WithCredentials("id", "secret"))
+	defer server.Close()
+
+	if err := client.NewClient(server.URL, "id", "wrong").Authenticate(context.Background()); err == nil {
+		t.Error("Expected authentication with wrong secret to fail")
+	}
+	if err := client.NewClient(server.URL, "id", "secret").Authenticate(context.Background()); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestServer_ApplicationLifecycle(t *testing.T) {
+	_, c := newTestClient(t)
+	ctx := context.Background()
+
+	app, err := c.CreateApplication(ctx, client.CreateApplicationRequest{Name: "My App", AppURL: "https://app.example.com"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if app.ID == "" || app.VendorID != DefaultVendorID {
+		t.Errorf("Expected ID and vendor ID to be set, got %+v", app)
+	}
+
+	updated, err := c.UpdateApplication(ctx, app.ID, client.UpdateApplicationRequest{Name: "Renamed"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if updated.Name != "Renamed" || updated.AppURL != "https://app.example.com" {
+		t.Errorf("Expected partial update, got %+v", updated)
+	}
+
+	if err := c.DeleteApplication(ctx, app.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := c.GetApplicationByID(ctx, app.ID); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Expected deleted application to be gone, got %v", err)
+	}
+}
+
+func TestServer_SourcesAreScopedToApplication(t *testing.T) {
+	server, c := newTestClient(t)
+	ctx := context.Background()
+
+	server.Put(Sources, Object{"id": "other", "appId": "app-2", "name": "Other"})
+
+	source, err := c.CreateSource(ctx, client.CreateSourceRequest{AppID: "app-1", Name: "API", Type: "REST"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	sources, err := c.GetSources(ctx, "app-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sources) != 1 || sources[0].ID != source.ID {
+		t.Errorf("Expected only the app-1 source, got %+v", sources)
+	}
+
+	if err := c.DeleteSource(ctx, "app-1", source.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if server.Get(Sources, source.ID) != nil {
+		t.Error("Expected source to be deleted")
+	}
+}
+
+func TestServer_UpsertAndDeleteTools(t *testing.T) {
+	server, c := newTestClient(t)
+	ctx := context.Background()
+
+	req := client.UpsertToolsRequest{
+		AppID: "app-1",
+		Tools: []client.InternalTool{{Name: "list_users", SourceID: "src-1"}},
+	}
+	first, err := c.UpsertTools(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	second, err := c.UpsertTools(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(first) != 1 || len(second) != 1 || first[0].ID != second[0].ID {
+		t.Errorf("Expected upsert to reuse the tool ID, got %+v and %+v", first, second)
+	}
+
+	if err := c.DeleteToolsBySource(ctx, "app-1", "src-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tools := server.List(Tools); len(tools) != 0 {
+		t.Errorf("Expected tools to be deleted, got %+v", tools)
+	}
+}
+
+func TestServer_PolicyLifecycle(t *testing.T) {
+	_, c := newTestClient(t)
+	ctx := context.Background()
+
+	policy, err := c.CreateRbacPolicy(ctx, client.CreateRbacPolicyRequest{Name: "Admins", Type: "RBAC_PERMISSIONS"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if policy.Type != "RBAC_PERMISSIONS" {
+		t.Errorf("Expected RBAC_PERMISSIONS policy type, got %q", policy.Type)
+	}
+
+	policies, err := c.GetRbacPolicies(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(policies) != 1 || policies[0].ID != policy.ID {
+		t.Errorf("Expected the RBAC policy to be listed, got %+v", policies)
+	}
+
+	if err := c.DeletePolicy(ctx, policy.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := c.GetRbacPolicy(ctx, policy.ID); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Expected deleted policy to be gone, got %v", err)
+	}
+}
+
+func TestServer_FailNext(t *testing.T) {
+	server, c := newTestClient(t)
+	ctx := context.Background()
+
+	server.FailNext(http.MethodGet, "/applications/resources/applications/v1", http.StatusInternalServerError)
+
+	if _, err := c.GetApplications(ctx); err == nil {
+		t.Error("Expected injected failure")
+	}
+	if _, err := c.GetApplications(ctx); err != nil {
+		t.Errorf("Expected failure to apply once, got %v", err)
+	}
+	if got := len(server.Requests()); got != 2 {
+		t.Errorf("Expected 2 recorded requests, got %d", got)
+	}
+}
+
+func TestServer_InMemory(t *testing.T) {
+	server := NewInMemoryServer()
+	defer server.Close()
+
+	c := client.NewClient(server.URL, DefaultClientID, DefaultSecret)
+	c.SetTransport(server.Transport())
+	ctx := context.Background()
+
+	app, err := c.CreateApplication(ctx, client.CreateApplicationRequest{Name: "My App", AppURL: "https://app.example.com"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got, err := c.GetApplicationByID(ctx, app.ID)
+	if err != nil || got == nil || got.Name != "My App" {
+		t.Errorf("Expected to read back the application, got %+v, %v", got, err)
+	}
+	if len(server.List(Applications)) != 1 {
+		t.Errorf("Expected 1 stored application, got %d", len(server.List(Applications)))
+	}
+}
+
+func TestServer_CloneApplication(t *testing.T) {
+	server, c := newTestClient(t)
+	ctx := context.Background()
+
+	server.Put(Sources, Object{"id": "src-1", "appId": "template", "name": "Orders", "type": "REST", "sourceUrl": "https://orders.example.com", "enabled": true})
+	server.Put(Tools, Object{"id": "tool-1", "appId": "template", "sourceId": "src-1", "name": "list_orders"})
+	server.Put(McpConfigurations, Object{"id": "mcp-1", "appId": "template", "baseUrl": "https://mcp.example.com", "apiTimeout": 3000})
+	server.Put(Policies, Object{"id": "policy-1", "type": "CONDITIONAL", "appIds": []interface{}{"template"}, "internalToolIds": []interface{}{"tool-1"}})
+	server.Put(Policies, Object{"id": "policy-2", "type": "CONDITIONAL", "appIds": []interface{}{"other"}})
+
+	if err := c.CloneApplication(ctx, "template", "staging"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	sources, err := c.GetSources(ctx, "staging")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sources) != 1 || sources[0].Name != "Orders" || sources[0].ID == "src-1" {
+		t.Fatalf("Expected the source to be cloned, got %+v", sources)
+	}
+
+	tools, err := c.GetToolsBySource(ctx, "staging", sources[0].ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tools) != 1 || tools[0].Name != "list_orders" {
+		t.Fatalf("Expected the tool to be cloned, got %+v", tools)
+	}
+
+	config, err := c.GetMcpConfiguration(ctx, "staging")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.BaseURL != "https://mcp.example.com" {
+		t.Errorf("Expected the MCP configuration to be cloned, got %+v", config)
+	}
+
+	// The template policy is copied rather than changed, so resources managing it see no drift
+	template := server.Get(Policies, "policy-1")
+	if appIDs, _ := template["appIds"].([]interface{}); len(appIDs) != 1 {
+		t.Errorf("Expected the template policy to be untouched, got %v", template["appIds"])
+	}
+
+	policies, err := c.ListPolicies(ctx, client.PolicyFilters{AppID: "staging"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(policies) != 1 || policies[0].ID == "policy-1" || len(policies[0].AppIDs) != 1 {
+		t.Fatalf("Expected a copy of the policy applying to the clone, got %+v", policies)
+	}
+	if len(policies[0].InternalToolIDs) != 1 || policies[0].InternalToolIDs[0] != tools[0].ID {
+		t.Errorf("Expected the copy to be scoped to the cloned tool, got %v", policies[0].InternalToolIDs)
+	}
+
+	other, err := c.GetConditionalPolicy(ctx, "policy-2")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(other.AppIDs) != 1 {
+		t.Errorf("Expected unrelated policies to be untouched, got %v", other.AppIDs)
+	}
+}
@@ -2,16 +2,27 @@ package client
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gopkg.in/yaml.v3"
 )
 
 // Client is the Frontegg API client
@@ -25,6 +36,45 @@ type Client struct {
 	accessToken string
 	tokenExpiry time.Time
 
+	// authMu serializes token refreshes so concurrent requests authenticate only once
+	authMu sync.Mutex
+
+	vendorMu           sync.Mutex
+	vendorConfig       *VendorConfig
+	vendorConfigExpiry time.Time
+
+	etagMu sync.Mutex
+	etags  map[string]etagEntry
+
+	// logPayloads enables TRACE-level logging of redacted request and response bodies
+	logPayloads bool
+
+	// metrics receives a measurement of every API request, when set
+	metrics MetricsRecorder
+
+	// requestSlots limits the number of API requests in flight, when set
+	requestSlots chan struct{}
+
+	// authRetryDelay is the delay before the first authentication retry; it doubles per attempt
+	authRetryDelay time.Duration
+
+	// conflictRetryDelay is the delay before the first retry of a write rejected by a
+	// concurrent change; it doubles per attempt
+	conflictRetryDelay time.Duration
+
+	// maintenanceRetryDelay is the delay before the first retry of a request rejected by a
+	// maintenance window; it doubles per attempt up to maintenanceMaxDelay
+	maintenanceRetryDelay time.Duration
+	// maintenanceMaxWait is the total time requests are retried during a maintenance window
+	maintenanceMaxWait time.Duration
+
+	// maxRetries is the number of retries of a request rejected with a transient error
+	maxRetries int
+	// retryWaitMin is the delay before the first retry of a request rejected with a transient
+	// error; it doubles per retry up to retryWaitMax
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+
 	// ApplicationID stores the resolved application ID
 	ApplicationID string
 	// ApplicationName stores the resolved application name
@@ -57,6 +107,9 @@ type Application struct {
 	Metadata              map[string]interface{} `json:"metadata,omitempty"`
 	AppHost               string                 `json:"appHost,omitempty"`
 	AllowDcr              bool                   `json:"allowDcr"`
+	AgentFramework        string                 `json:"agentFramework,omitempty"`
+	ModelProviders        []string               `json:"modelProviders,omitempty"`
+	CallbackURLs          []string               `json:"callbackUrls,omitempty"`
 }
 
 // CreateApplicationRequest represents the request to create an application
@@ -73,6 +126,10 @@ type CreateApplicationRequest struct {
 	Description   string                 `json:"description,omitempty"`
 	Metadata      map[string]interface{} `json:"metadata,omitempty"`
 	AllowDcr      *bool                  `json:"allowDcr,omitempty"`
+	// Agent settings are only accepted for applications of type agent
+	AgentFramework string   `json:"agentFramework,omitempty"`
+	ModelProviders []string `json:"modelProviders,omitempty"`
+	CallbackURLs   []string `json:"callbackUrls,omitempty"`
 }
 
 // Source represents a Frontegg MCP configuration source
@@ -107,11 +164,181 @@ func NewClient(baseURL, clientID, secret string) *Client {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		authRetryDelay:        time.Second,
+		conflictRetryDelay:    500 * time.Millisecond,
+		maintenanceRetryDelay: 10 * time.Second,
+		maintenanceMaxWait:    DefaultMaintenanceMaxWait,
+		maxRetries:            DefaultMaxRetries,
+		retryWaitMin:          time.Second,
+		retryWaitMax:          DefaultRetryWaitMax,
+	}
+}
+
+// ClientID returns the vendor client ID used to authenticate
+func (c *Client) ClientID() string {
+	return c.clientID
+}
+
+// BaseURL returns the base URL API requests are sent to
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// SetTransport replaces the HTTP transport used for API requests, e.g. with a Recorder
+func (c *Client) SetTransport(transport http.RoundTripper) {
+	c.httpClient.Transport = transport
+}
+
+// SetMaxConcurrentRequests limits the number of API requests in flight at once. Further requests
+// wait for a slot, which keeps parallel Terraform operations below the API rate limits. A limit of
+// 0 or less removes the limit.
+func (c *Client) SetMaxConcurrentRequests(limit int) {
+	if limit <= 0 {
+		c.requestSlots = nil
+		return
+	}
+	c.requestSlots = make(chan struct{}, limit)
+}
+
+// acquireRequestSlot waits until fewer than the maximum number of requests are in flight. The
+// returned function releases the slot. A request is in flight until its response body is closed,
+// see releaseOnClose.
+func (c *Client) acquireRequestSlot(ctx context.Context) (func(), error) {
+	slots := c.requestSlots
+	if slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// releaseOnClose makes closing the body of resp release its request slot, so the slot stays taken
+// while the response is read. Closing the body more than once releases the slot once.
+func releaseOnClose(resp *http.Response, release func()) {
+	resp.Body = &slotBody{ReadCloser: resp.Body, release: release}
+}
+
+// slotBody is a response body holding a request slot until it is closed
+type slotBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+// Close closes the body and releases its request slot
+func (b *slotBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// bufferBody reads and closes the body of resp, releasing its request slot, and replaces it with
+// the bytes read, so it stays readable for the caller
+func bufferBody(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+// SkipTLSHostnameVerification accepts server certificates that do not match the API host name,
+// e.g. temporary certificates of pre-release environments. The certificate chain is still verified.
+// It applies to the transport in use, so call it after SetTransport.
+func (c *Client) SkipTLSHostnameVerification() {
+	c.httpClient.Transport = withoutHostnameVerification(c.httpClient.Transport)
+}
+
+// withoutHostnameVerification returns a copy of transport that verifies certificate chains without
+// checking the host name. A Recorder keeps wrapping the adjusted transport; other transports that do
+// not dial the API themselves are returned unchanged.
+func withoutHostnameVerification(transport http.RoundTripper) http.RoundTripper {
+	switch t := transport.(type) {
+	case nil:
+		return withoutHostnameVerification(http.DefaultTransport)
+	case *Recorder:
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if t.transport != nil {
+			t.transport = withoutHostnameVerification(t.transport)
+		}
+		return t
+	case *http.Transport:
+		clone := t.Clone()
+		tlsConfig := clone.TLSClientConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+		roots := tlsConfig.RootCAs
+		// Go verifies the chain and the host name together, so verification is done in VerifyConnection instead
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 {
+				return errors.New("the server presented no certificate")
+			}
+			opts := x509.VerifyOptions{Roots: roots, Intermediates: x509.NewCertPool()}
+			for _, cert := range state.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(cert)
+			}
+			_, err := state.PeerCertificates[0].Verify(opts)
+			return err
+		}
+		clone.TLSClientConfig = tlsConfig
+		return clone
+	default:
+		return transport
 	}
 }
 
-// Authenticate authenticates with the Frontegg API and retrieves an access token
+// authMaxAttempts is the number of times authentication is attempted before giving up
+const authMaxAttempts = 4
+
+// Authenticate authenticates with the Frontegg API and retrieves an access token. Transient
+// failures, such as network errors, rate limiting and server errors, are retried with
+// exponential backoff; rejected credentials fail immediately.
 func (c *Client) Authenticate(ctx context.Context) error {
+	delay := c.authRetryDelay
+
+	for attempt := 1; ; attempt++ {
+		err := c.authenticate(ctx)
+		if err == nil || attempt == authMaxAttempts || !isTransientAuthError(err) {
+			return err
+		}
+
+		tflog.Warn(ctx, "Transient authentication failure, retrying", map[string]interface{}{
+			"attempt": attempt,
+			"delay":   delay.String(),
+			"error":   err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// isTransientAuthError reports whether an authentication error may succeed when retried.
+// Connectivity errors, 429 and 5xx responses are transient; other API errors, such as
+// 401 for invalid credentials, are not.
+func isTransientAuthError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// authenticate makes a single authentication request and stores the access token
+func (c *Client) authenticate(ctx context.Context) error {
 	authURL := fmt.Sprintf("%s/auth/vendor", c.baseURL)
 
 	tflog.Info(ctx, "Authenticating with Frontegg API", map[string]interface{}{
@@ -128,6 +355,7 @@ func (c *Client) Authenticate(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to marshal auth request: %w", err)
 	}
+	c.logRequestPayload(ctx, http.MethodPost, "/auth/vendor", body)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, authURL, bytes.NewReader(body))
 	if err != nil {
@@ -137,6 +365,12 @@ func (c *Client) Authenticate(ctx context.Context) error {
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
+	release, err := c.acquireRequestSlot(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		tflog.Error(ctx, "Failed to execute authentication request", map[string]interface{}{
@@ -148,6 +382,7 @@ func (c *Client) Authenticate(ctx context.Context) error {
 
 	// Log the trace ID for debugging
 	logTraceID(ctx, resp, "POST /auth/vendor")
+	c.logResponsePayload(ctx, resp, "POST /auth/vendor")
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
@@ -156,7 +391,7 @@ func (c *Client) Authenticate(ctx context.Context) error {
 			"response":          string(bodyBytes),
 			"frontegg_trace_id": resp.Header.Get("frontegg-trace-id"),
 		})
-		return fmt.Errorf("authentication failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return newAPIError(resp, bodyBytes, "authentication failed")
 	}
 
 	var authResp AuthResponse
@@ -177,18 +412,25 @@ func (c *Client) Authenticate(ctx context.Context) error {
 	return nil
 }
 
-// GetAccessToken returns a valid access token, refreshing if necessary
+// GetAccessToken returns a valid access token, authenticating on first use and refreshing
+// it when expired
 func (c *Client) GetAccessToken(ctx context.Context) (string, error) {
-	c.mu.RLock()
-	token := c.accessToken
-	expiry := c.tokenExpiry
-	c.mu.RUnlock()
+	if token, ok := c.validToken(); ok {
+		return token, nil
+	}
+
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
 
-	if token != "" && time.Now().Before(expiry) {
+	// Another request may have refreshed the token while we waited
+	if token, ok := c.validToken(); ok {
 		return token, nil
 	}
 
 	if err := c.Authenticate(ctx); err != nil {
+		if IsUnauthorized(err) {
+			return "", fmt.Errorf("the Frontegg API rejected the configured client_id and secret: %w", err)
+		}
 		return "", err
 	}
 
@@ -197,6 +439,200 @@ func (c *Client) GetAccessToken(ctx context.Context) (string, error) {
 	return c.accessToken, nil
 }
 
+// validToken returns the cached access token if it has not expired
+func (c *Client) validToken() (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.accessToken, c.accessToken != "" && time.Now().Before(c.tokenExpiry)
+}
+
+// CreateTenantAccessTokenRequest represents the request to create a tenant access token
+type CreateTenantAccessTokenRequest struct {
+	Description      string   `json:"description,omitempty"`
+	ExpiresInMinutes int      `json:"expiresInMinutes,omitempty"`
+	RoleIDs          []string `json:"roleIds,omitempty"`
+}
+
+// TenantAccessToken represents an access token scoped to a single tenant. The secret is
+// only returned when the token is created.
+type TenantAccessToken struct {
+	ID          string   `json:"id"`
+	Description string   `json:"description,omitempty"`
+	CreatedAt   string   `json:"createdAt,omitempty"`
+	Secret      string   `json:"secret,omitempty"`
+	Expires     string   `json:"expires,omitempty"`
+	RoleIDs     []string `json:"roleIds,omitempty"`
+}
+
+// CreateTenantAccessToken creates an access token scoped to a single tenant
+func (c *Client) CreateTenantAccessToken(ctx context.Context, tenantID string, req CreateTenantAccessTokenRequest) (*TenantAccessToken, error) {
+	tflog.Info(ctx, "Creating tenant access token", map[string]interface{}{
+		"tenant_id": tenantID,
+	})
+
+	resp, err := c.DoRequest(WithTenantID(ctx, tenantID), http.MethodPost, "/identity/resources/tenants/access-tokens/v1", req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tenant access token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, bodyBytes, "failed to create tenant access token")
+	}
+
+	var token TenantAccessToken
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, fmt.Errorf("failed to decode tenant access token response: %w", err)
+	}
+
+	return &token, nil
+}
+
+// DeleteTenantAccessToken deletes a tenant access token. A token that no longer exists is
+// not an error.
+func (c *Client) DeleteTenantAccessToken(ctx context.Context, tenantID, id string) error {
+	tflog.Info(ctx, "Deleting tenant access token", map[string]interface{}{
+		"tenant_id": tenantID,
+		"id":        id,
+	})
+
+	path := fmt.Sprintf("/identity/resources/tenants/access-tokens/v1/%s", id)
+	resp, err := c.DoRequest(WithTenantID(ctx, tenantID), http.MethodDelete, path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete tenant access token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, bodyBytes, "failed to delete tenant access token")
+	}
+
+	return nil
+}
+
+// APIError is returned when the Frontegg API responds with an unexpected status. It carries
+// the request and trace ID so failures can be reported to Frontegg support.
+type APIError struct {
+	Action     string
+	StatusCode int
+	Body       string
+	// Messages are the error messages of a JSON error body, empty when the body is not one
+	Messages []string
+	Method   string
+	Path     string
+	TraceID  string
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s with status %d: %s", e.Action, e.StatusCode, e.Message())
+
+	details := []string{}
+	if e.Path != "" {
+		details = append(details, "request: "+strings.TrimSpace(e.Method+" "+e.Path))
+	}
+	if e.TraceID != "" {
+		details = append(details, "frontegg-trace-id: "+e.TraceID)
+	}
+	if len(details) > 0 {
+		msg += " (" + strings.Join(details, ", ") + ")"
+	}
+
+	return msg
+}
+
+// Message returns the parsed error messages, or the raw response body when it has none
+func (e *APIError) Message() string {
+	if len(e.Messages) > 0 {
+		return strings.Join(e.Messages, "; ")
+	}
+	return e.Body
+}
+
+// ErrNotFound matches errors returned by getters when the requested object does not exist.
+// Check for it with errors.Is; the returned error is an APIError carrying the trace ID.
+var ErrNotFound = errors.New("not found")
+
+// Is reports whether the error matches target, so a 404 Not Found response matches ErrNotFound
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// IsConflict reports whether err is an APIError for a 409 Conflict response, e.g. when
+// creating an object whose name is already taken
+func IsConflict(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
+
+// IsUnauthorized reports whether err is an APIError for a 401 Unauthorized or 403 Forbidden
+// response, e.g. when the vendor credentials are rejected
+func IsUnauthorized(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden)
+}
+
+// newAPIError builds an APIError from an unexpected response and its already-read body
+func newAPIError(resp *http.Response, body []byte, action string) error {
+	apiErr := &APIError{
+		Action:     action,
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		Messages:   parseErrorMessages(body),
+		TraceID:    resp.Header.Get("frontegg-trace-id"),
+	}
+	if resp.Request != nil && resp.Request.URL != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Path = resp.Request.URL.RequestURI()
+	}
+	return apiErr
+}
+
+// parseErrorMessages returns the messages of a JSON error body. Frontegg services respond with
+// either {"errors": [...]} or {"message": ...}, where message is a string or a list of
+// validation errors; errors may also be objects with a message.
+func parseErrorMessages(body []byte) []string {
+	var document struct {
+		Message json.RawMessage `json:"message"`
+		Errors  json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return nil
+	}
+
+	var messages []string
+	for _, raw := range []json.RawMessage{document.Message, document.Errors} {
+		var text string
+		var list []json.RawMessage
+		switch {
+		case len(raw) == 0:
+		case json.Unmarshal(raw, &text) == nil:
+			messages = append(messages, text)
+		case json.Unmarshal(raw, &list) == nil:
+			for _, item := range list {
+				var object struct {
+					Message string `json:"message"`
+				}
+				if json.Unmarshal(item, &text) == nil {
+					messages = append(messages, text)
+				} else if json.Unmarshal(item, &object) == nil && object.Message != "" {
+					messages = append(messages, object.Message)
+				}
+			}
+		}
+	}
+
+	nonEmpty := messages[:0]
+	for _, message := range messages {
+		if message = strings.TrimSpace(message); message != "" {
+			nonEmpty = append(nonEmpty, message)
+		}
+	}
+	return nonEmpty
+}
+
 // logTraceID logs the frontegg-trace-id header from the response
 func logTraceID(ctx context.Context, resp *http.Response, operation string) {
 	traceID := resp.Header.Get("frontegg-trace-id")
@@ -209,25 +645,128 @@ func logTraceID(ctx context.Context, resp *http.Response, operation string) {
 	}
 }
 
+// tenantIDKey is the context key of the tenant API requests are scoped to
+type tenantIDKey struct{}
+
+// WithTenantID returns a context that scopes the API requests made with it to a tenant by
+// sending the frontegg-tenant-id header. An empty tenantID keeps requests vendor-scoped.
+func WithTenantID(ctx context.Context, tenantID string) context.Context {
+	if tenantID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, tenantIDKey{}, tenantID)
+}
+
 // DoRequest executes an authenticated HTTP request
 func (c *Client) DoRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	return c.doRequest(ctx, method, path, body, nil)
+}
+
+// doRequest executes an authenticated HTTP request with additional request headers
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, headers map[string]string) (*http.Response, error) {
+	var jsonBody []byte
+	if body != nil {
+		var err error
+		jsonBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		c.logRequestPayload(ctx, method, path, jsonBody)
+	}
+
+	return c.send(ctx, method, path, jsonBody, headers)
+}
+
+// send executes an authenticated HTTP request with a body that is already encoded. The body is
+// kept as bytes rather than a reader, so every attempt of a request gets a complete payload.
+// Requests rejected by an API maintenance window are retried with backoff until the maximum
+// maintenance wait is used up, and the time waited is recorded in the MaintenanceWaits of ctx, if
+// any. Requests rejected with other transient errors, such as 429 or
+// 502, are retried with backoff up to the maximum number of retries.
+func (c *Client) send(ctx context.Context, method, path string, body []byte, headers map[string]string) (*http.Response, error) {
+	operation := fmt.Sprintf("%s %s", method, path)
+	delay := c.maintenanceRetryDelay
+	var waited time.Duration
+	retries := 0
+
+	defer func() { recordMaintenanceWait(ctx, operation, waited) }()
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.sendOnce(ctx, method, path, body, headers)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case isMaintenanceResponse(resp):
+			wait, ok := c.waitForMaintenance(ctx, resp, operation, attempt, delay, waited)
+			if !ok {
+				return resp, nil
+			}
+			waited += wait
+			delay = min(delay*2, maintenanceMaxDelay)
+		case isRetryableResponse(method, resp) && retries < c.maxRetries:
+			retries++
+			// Free the request slot while waiting
+			_, _ = bufferBody(resp)
+			if !c.waitForRetry(ctx, resp, operation, retries) {
+				return resp, nil
+			}
+		default:
+			return resp, nil
+		}
+		_ = resp.Body.Close()
+	}
+}
+
+// sendOnce makes a single attempt of an authenticated HTTP request
+func (c *Client) sendOnce(ctx context.Context, method, path string, body []byte, headers map[string]string) (*http.Response, error) {
+	ctx, span := startSpan(ctx, method, path)
+
+	req, err := c.newRequest(ctx, method, path, body, headers)
+	if err != nil {
+		endSpan(span, nil, err)
+		return nil, err
+	}
+
+	release, err := c.acquireRequestSlot(ctx)
+	if err != nil {
+		endSpan(span, nil, err)
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	c.recordRequest(ctx, method, path, start, resp, err)
+	endSpan(span, resp, err)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	releaseOnClose(resp, release)
+
+	// Log the trace ID for debugging
+	logTraceID(ctx, resp, fmt.Sprintf("%s %s", method, path))
+	c.logResponsePayload(ctx, resp, fmt.Sprintf("%s %s", method, path))
+
+	return resp, nil
+}
+
+// newRequest builds an authenticated HTTP request that reads body from the start. Requests are
+// never reused: each attempt builds a new one, and GetBody returns the complete body for
+// redirects and transports that read it, such as the Recorder.
+func (c *Client) newRequest(ctx context.Context, method, path string, body []byte, headers map[string]string) (*http.Request, error) {
 	token, err := c.GetAccessToken(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get access token: %w", err)
 	}
 
-	url := fmt.Sprintf("%s%s", c.baseURL, path)
-
 	var reqBody io.Reader
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
-		}
-		reqBody = bytes.NewReader(jsonBody)
+		reqBody = bytes.NewReader(body)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -235,16 +774,66 @@ func (c *Client) DoRequest(ctx context.Context, method, path string, body interf
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	if tenantID, ok := ctx.Value(tenantIDKey{}).(string); ok {
+		req.Header.Set("frontegg-tenant-id", tenantID)
+	}
+	injectTraceContext(ctx, req.Header)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
 
-	resp, err := c.httpClient.Do(req)
+	return req, nil
+}
+
+// etagEntry is a decoded GET response cached until the API reports a newer ETag
+type etagEntry struct {
+	etag  string
+	value interface{}
+}
+
+// conditionalGet performs a GET request, sending If-None-Match when a previous response for the path
+// carried an ETag. When the API responds 304 Not Modified, the value cached for that ETag is returned.
+func (c *Client) conditionalGet(ctx context.Context, path string) (*http.Response, interface{}, error) {
+	c.etagMu.Lock()
+	entry, ok := c.etags[path]
+	c.etagMu.Unlock()
+
+	var headers map[string]string
+	if ok {
+		headers = map[string]string{"If-None-Match": entry.etag}
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil, headers)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// Log the trace ID for debugging
-	logTraceID(ctx, resp, fmt.Sprintf("%s %s", method, path))
+	if ok && resp.StatusCode == http.StatusNotModified {
+		tflog.Debug(ctx, "Response not modified, using cached value", map[string]interface{}{
+			"path": path,
+		})
+		return resp, entry.value, nil
+	}
 
-	return resp, nil
+	return resp, nil, nil
+}
+
+// storeETag caches the decoded value of a GET response when the API returned an ETag for it.
+// A nil value drops any cached response for the path.
+func (c *Client) storeETag(resp *http.Response, path string, value interface{}) {
+	c.etagMu.Lock()
+	defer c.etagMu.Unlock()
+
+	etag := resp.Header.Get("ETag")
+	if etag == "" || value == nil {
+		delete(c.etags, path)
+		return
+	}
+
+	if c.etags == nil {
+		c.etags = map[string]etagEntry{}
+	}
+	c.etags[path] = etagEntry{etag: etag, value: value}
 }
 
 // GetApplications retrieves all applications
@@ -263,7 +852,7 @@ func (c *Client) GetApplications(ctx context.Context) ([]Application, error) {
 			"status_code": resp.StatusCode,
 			"response":    string(bodyBytes),
 		})
-		return nil, fmt.Errorf("failed to get applications with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to get applications")
 	}
 
 	var applications []Application
@@ -308,6 +897,39 @@ func (c *Client) FindApplicationByName(ctx context.Context, name string) (*Appli
 	return nil, nil
 }
 
+// FindApplicationByHost searches for an application by its app host. The host is compared case
+// insensitively, ignoring a scheme and path, so the MCP server URL of an application also matches.
+func (c *Client) FindApplicationByHost(ctx context.Context, host string) (*Application, error) {
+	applications, err := c.GetApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, app := range applications {
+		if app.AppHost != "" && strings.EqualFold(normalizeHost(app.AppHost), normalizeHost(host)) {
+			tflog.Info(ctx, "Found application by host", map[string]interface{}{
+				"host": host,
+				"id":   app.ID,
+			})
+			return &app, nil
+		}
+	}
+
+	tflog.Info(ctx, "Application not found by host", map[string]interface{}{
+		"host": host,
+	})
+	return nil, nil
+}
+
+// normalizeHost strips the scheme, path and trailing dot of a host or URL
+func normalizeHost(host string) string {
+	if _, rest, ok := strings.Cut(host, "://"); ok {
+		host = rest
+	}
+	host, _, _ = strings.Cut(host, "/")
+	return strings.TrimSuffix(host, ".")
+}
+
 // CreateApplication creates a new application
 func (c *Client) CreateApplication(ctx context.Context, req CreateApplicationRequest) (*Application, error) {
 	tflog.Info(ctx, "Creating application", map[string]interface{}{
@@ -326,7 +948,7 @@ func (c *Client) CreateApplication(ctx context.Context, req CreateApplicationReq
 			"status_code": resp.StatusCode,
 			"response":    string(bodyBytes),
 		})
-		return nil, fmt.Errorf("failed to create application with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to create application")
 	}
 
 	var application Application
@@ -383,25 +1005,30 @@ func (c *Client) GetSources(ctx context.Context, appID string) ([]Source, error)
 	})
 
 	path := fmt.Sprintf("/app-integrations/resources/app-mcp-configuration-sources/v1?appId=%s", appID)
-	resp, err := c.DoRequest(ctx, http.MethodGet, path, nil)
+	resp, cached, err := c.conditionalGet(ctx, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sources: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	if cached != nil {
+		return append([]Source(nil), cached.([]Source)...), nil
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
 		tflog.Error(ctx, "Failed to get sources", map[string]interface{}{
 			"status_code": resp.StatusCode,
 			"response":    string(bodyBytes),
 		})
-		return nil, fmt.Errorf("failed to get sources with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to get sources")
 	}
 
 	var sources []Source
 	if err := json.NewDecoder(resp.Body).Decode(&sources); err != nil {
 		return nil, fmt.Errorf("failed to decode sources response: %w", err)
 	}
+	c.storeETag(resp, path, append([]Source(nil), sources...))
 
 	// Collect source names for logging
 	sourceNames := make([]string, len(sources))
@@ -460,7 +1087,7 @@ func (c *Client) CreateSource(ctx context.Context, req CreateSourceRequest) (*So
 			"status_code": resp.StatusCode,
 			"response":    string(bodyBytes),
 		})
-		return nil, fmt.Errorf("failed to create source with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to create source")
 	}
 
 	var source Source
@@ -521,6 +1148,10 @@ type InternalTool struct {
 	Schema             map[string]interface{} `json:"schema,omitempty"`
 	AuthenticationType string                 `json:"authenticationType,omitempty"`
 	SourceID           string                 `json:"sourceId,omitempty"`
+	Tags               []string               `json:"tags,omitempty"`
+	RedactedParameters []string               `json:"redactedParameters,omitempty"`
+	CreatedAt          string                 `json:"createdAt,omitempty"`
+	UpdatedAt          string                 `json:"updatedAt,omitempty"`
 }
 
 // UpsertToolsRequest represents the request to upsert tools
@@ -550,13 +1181,24 @@ func (c *Client) ImportGraphQLSchema(ctx context.Context, appID string, schemaCo
 	return c.importSchema(ctx, appID, schemaContent, filename, "graphql", "/app-integrations/resources/internal-tools/v1/graphql/import")
 }
 
-// importSchema is a helper function for importing schemas via multipart form
-func (c *Client) importSchema(ctx context.Context, appID string, schemaContent []byte, filename, fieldName, endpoint string) ([]InternalTool, error) {
-	token, err := c.GetAccessToken(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get access token: %w", err)
+// schemaUploadGzipThreshold is the multipart body size above which schema uploads are gzipped
+const schemaUploadGzipThreshold = 1 << 20
+
+// gzipBytes returns the gzip compression of data
+func gzipBytes(data []byte) ([]byte, error) {
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write(data); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
 	}
+	return compressed.Bytes(), nil
+}
 
+// importSchema is a helper function for importing schemas via multipart form
+func (c *Client) importSchema(ctx context.Context, appID string, schemaContent []byte, filename, fieldName, endpoint string) ([]InternalTool, error) {
 	// Create multipart form
 	var body bytes.Buffer
 	writer := multipart.NewWriter(&body)
@@ -579,25 +1221,29 @@ func (c *Client) importSchema(ctx context.Context, appID string, schemaContent [
 		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
 	}
 
-	url := fmt.Sprintf("%s%s", c.baseURL, endpoint)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create import request: %w", err)
-	}
+	headers := map[string]string{"Content-Type": writer.FormDataContentType()}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set("Accept", "application/json")
+	// Large schemas are compressed so uploads do not time out on slow connections
+	payload := body.Bytes()
+	if len(payload) > schemaUploadGzipThreshold {
+		var err error
+		payload, err = gzipBytes(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress schema upload: %w", err)
+		}
+		headers["Content-Encoding"] = "gzip"
+		tflog.Debug(ctx, "Compressed schema upload", map[string]interface{}{
+			"uncompressed_bytes": body.Len(),
+			"compressed_bytes":   len(payload),
+		})
+	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(ctx, http.MethodPost, endpoint, payload, headers)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute import request: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	// Log the trace ID for debugging
-	logTraceID(ctx, resp, fmt.Sprintf("POST %s", endpoint))
-
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
 		bodyBytes, _ := io.ReadAll(resp.Body)
 		tflog.Error(ctx, "Failed to import schema", map[string]interface{}{
@@ -605,7 +1251,7 @@ func (c *Client) importSchema(ctx context.Context, appID string, schemaContent [
 			"response":          string(bodyBytes),
 			"frontegg_trace_id": resp.Header.Get("frontegg-trace-id"),
 		})
-		return nil, fmt.Errorf("failed to import schema with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to import schema")
 	}
 
 	var tools []InternalTool
@@ -640,7 +1286,7 @@ func (c *Client) UpsertTools(ctx context.Context, req UpsertToolsRequest) ([]Int
 			"status_code": resp.StatusCode,
 			"response":    string(bodyBytes),
 		})
-		return nil, fmt.Errorf("failed to upsert tools with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to upsert tools")
 	}
 
 	var tools []InternalTool
@@ -655,23 +1301,205 @@ func (c *Client) UpsertTools(ctx context.Context, req UpsertToolsRequest) ([]Int
 	return tools, nil
 }
 
-// ImportAndUpsertSchema imports a schema and then upserts the resulting tools
-func (c *Client) ImportAndUpsertSchema(ctx context.Context, appID, sourceID, sourceType string, schemaContent []byte, filename string) error {
-	var tools []InternalTool
-	var err error
+// Tool naming strategies supported by ImportAndUpsertSchema
+const (
+	NamingStrategyOperationID = "operation_id"
+	NamingStrategyMethodPath  = "method_path"
+	NamingStrategySummarySlug = "summary_slug"
+)
 
-	// Import schema based on source type
-	switch sourceType {
-	case "REST":
-		tools, err = c.ImportOpenAPISchema(ctx, appID, schemaContent, filename)
-	case "GRAPHQL":
-		tools, err = c.ImportGraphQLSchema(ctx, appID, schemaContent, filename)
-	default:
-		return fmt.Errorf("schema import not supported for source type: %s", sourceType)
+// ImportOptions controls how imported tools are transformed before they are upserted
+type ImportOptions struct {
+	// NamingStrategy selects how tool names are derived. Defaults to NamingStrategyOperationID.
+	NamingStrategy string
+	// NamePrefix and NameSuffix are added around every derived tool name
+	NamePrefix string
+	NameSuffix string
+	// Description, when set, rebuilds OpenAPI tool descriptions from the schema documentation.
+	// A nil value keeps the descriptions generated by the import endpoint.
+	Description *DescriptionOptions
+	// MaxDescriptionLength truncates tool descriptions to this many characters. Zero disables truncation.
+	MaxDescriptionLength int
+	// Tags are set on every imported tool, so policies targeting them apply to the tool
+	Tags []string
+}
+
+// DescriptionOptions selects which OpenAPI documentation is concatenated into tool descriptions
+type DescriptionOptions struct {
+	IncludeSummary       bool
+	IncludeDescription   bool
+	IncludeParameterDocs bool
+}
+
+// openAPIParameter is the documentation of a single OpenAPI operation parameter
+type openAPIParameter struct {
+	Name        string `yaml:"name"`
+	In          string `yaml:"in"`
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+}
+
+// openAPIOperation is the documentation of a single OpenAPI operation
+type openAPIOperation struct {
+	Summary     string             `yaml:"summary"`
+	Description string             `yaml:"description"`
+	Parameters  []openAPIParameter `yaml:"parameters"`
+}
+
+// openAPIPathItem is the documentation of an OpenAPI path and its operations
+type openAPIPathItem struct {
+	Parameters []openAPIParameter `yaml:"parameters"`
+	Get        *openAPIOperation  `yaml:"get"`
+	Put        *openAPIOperation  `yaml:"put"`
+	Post       *openAPIOperation  `yaml:"post"`
+	Delete     *openAPIOperation  `yaml:"delete"`
+	Options    *openAPIOperation  `yaml:"options"`
+	Head       *openAPIOperation  `yaml:"head"`
+	Patch      *openAPIOperation  `yaml:"patch"`
+	Trace      *openAPIOperation  `yaml:"trace"`
+}
+
+// parseOpenAPIOperations extracts the operation documentation of an OpenAPI document (JSON or YAML),
+// keyed by upper-case method and path, e.g. "GET /users/{id}"
+func parseOpenAPIOperations(schemaContent []byte) (map[string]openAPIOperation, error) {
+	var document struct {
+		Paths map[string]openAPIPathItem `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(schemaContent, &document); err != nil {
+		return nil, err
+	}
+
+	operations := make(map[string]openAPIOperation)
+	for path, item := range document.Paths {
+		methods := map[string]*openAPIOperation{
+			"GET":     item.Get,
+			"PUT":     item.Put,
+			"POST":    item.Post,
+			"DELETE":  item.Delete,
+			"OPTIONS": item.Options,
+			"HEAD":    item.Head,
+			"PATCH":   item.Patch,
+			"TRACE":   item.Trace,
+		}
+		for method, operation := range methods {
+			if operation == nil {
+				continue
+			}
+			op := *operation
+			op.Parameters = mergeOpenAPIParameters(item.Parameters, operation.Parameters)
+			operations[method+" "+path] = op
+		}
+	}
+
+	return operations, nil
+}
+
+// mergeOpenAPIParameters combines path-level and operation-level parameters.
+// Operation parameters override path parameters with the same name and location.
+func mergeOpenAPIParameters(pathParams, operationParams []openAPIParameter) []openAPIParameter {
+	merged := make([]openAPIParameter, 0, len(pathParams)+len(operationParams))
+	for _, p := range pathParams {
+		overridden := false
+		for _, o := range operationParams {
+			if o.Name == p.Name && o.In == p.In {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, p)
+		}
+	}
+	return append(merged, operationParams...)
+}
+
+// build concatenates the selected documentation of an OpenAPI operation into a tool description
+func (d DescriptionOptions) build(operation openAPIOperation) string {
+	var parts []string
+
+	summary := strings.TrimSpace(operation.Summary)
+	if d.IncludeSummary && summary != "" {
+		parts = append(parts, summary)
+	}
+
+	description := strings.TrimSpace(operation.Description)
+	if d.IncludeDescription && description != "" && description != summary {
+		parts = append(parts, description)
+	}
+
+	if d.IncludeParameterDocs && len(operation.Parameters) > 0 {
+		lines := []string{"Parameters:"}
+		for _, p := range operation.Parameters {
+			line := fmt.Sprintf("- %s (%s", p.Name, p.In)
+			if p.Required {
+				line += ", required"
+			}
+			line += ")"
+			if doc := strings.TrimSpace(p.Description); doc != "" {
+				line += ": " + doc
+			}
+			lines = append(lines, line)
+		}
+		parts = append(parts, strings.Join(lines, "\n"))
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// truncateDescription shortens a description to at most maxLength characters, marking the cut with an ellipsis
+func truncateDescription(description string, maxLength int) string {
+	runes := []rune(description)
+	if maxLength <= 0 || len(runes) <= maxLength {
+		return description
+	}
+	if maxLength <= 3 {
+		return string(runes[:maxLength])
+	}
+	return strings.TrimRight(string(runes[:maxLength-3]), " \t\n") + "..."
+}
+
+// toolName derives the name of an imported tool according to the import options
+func (o ImportOptions) toolName(tool InternalTool) string {
+	name := tool.Name
+
+	switch o.NamingStrategy {
+	case NamingStrategyMethodPath:
+		if tool.OriginalMethod != "" && tool.OriginalPath != "" {
+			name = slugify(tool.OriginalMethod + " " + tool.OriginalPath)
+		}
+	case NamingStrategySummarySlug:
+		summary := strings.SplitN(strings.TrimSpace(tool.Description), "\n", 2)[0]
+		if slug := slugify(summary); slug != "" {
+			name = slug
+		}
+	}
+
+	return o.NamePrefix + name + o.NameSuffix
+}
+
+// slugify lowercases s and replaces every run of non-alphanumeric characters with an underscore
+func slugify(s string) string {
+	var b strings.Builder
+	pendingSeparator := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pendingSeparator && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			pendingSeparator = false
+			b.WriteRune(r)
+			continue
+		}
+		pendingSeparator = true
 	}
+	return b.String()
+}
 
+// ImportAndUpsertSchema imports a schema and then upserts the resulting tools
+func (c *Client) ImportAndUpsertSchema(ctx context.Context, appID, sourceID, sourceType string, schemaContent []byte, filename string, opts ImportOptions) error {
+	tools, err := c.BuildSchemaTools(ctx, appID, sourceID, sourceType, schemaContent, filename, opts)
 	if err != nil {
-		return fmt.Errorf("failed to import schema: %w", err)
+		return err
 	}
 
 	if len(tools) == 0 {
@@ -679,11 +1507,6 @@ func (c *Client) ImportAndUpsertSchema(ctx context.Context, appID, sourceID, sou
 		return nil
 	}
 
-	// Set sourceId on all tools
-	for i := range tools {
-		tools[i].SourceID = sourceID
-	}
-
 	// Upsert the tools
 	_, err = c.UpsertTools(ctx, UpsertToolsRequest{
 		AppID:    appID,
@@ -697,6 +1520,49 @@ func (c *Client) ImportAndUpsertSchema(ctx context.Context, appID, sourceID, sou
 	return nil
 }
 
+// BuildSchemaTools converts a schema into the tools ImportAndUpsertSchema would upsert, without
+// changing the tools of the source
+func (c *Client) BuildSchemaTools(ctx context.Context, appID, sourceID, sourceType string, schemaContent []byte, filename string, opts ImportOptions) ([]InternalTool, error) {
+	var tools []InternalTool
+	var err error
+
+	// Import schema based on source type
+	switch sourceType {
+	case "REST":
+		tools, err = c.ImportOpenAPISchema(ctx, appID, schemaContent, filename)
+	case "GRAPHQL":
+		tools, err = c.ImportGraphQLSchema(ctx, appID, schemaContent, filename)
+	default:
+		return nil, fmt.Errorf("schema import not supported for source type: %s", sourceType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to import schema: %w", err)
+	}
+
+	// Rebuild descriptions from the OpenAPI documentation when requested
+	var operations map[string]openAPIOperation
+	if opts.Description != nil && sourceType == "REST" && len(tools) > 0 {
+		operations, err = parseOpenAPIOperations(schemaContent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse schema documentation: %w", err)
+		}
+	}
+
+	// Set sourceId, the derived name and the description on all tools
+	for i := range tools {
+		tools[i].SourceID = sourceID
+		tools[i].Name = opts.toolName(tools[i])
+		if operation, ok := operations[strings.ToUpper(tools[i].OriginalMethod)+" "+tools[i].OriginalPath]; ok {
+			tools[i].Description = opts.Description.build(operation)
+		}
+		tools[i].Description = truncateDescription(tools[i].Description, opts.MaxDescriptionLength)
+		tools[i].Tags = opts.Tags
+	}
+
+	return tools, nil
+}
+
 // ============================================================================
 // Application CRUD Methods
 // ============================================================================
@@ -713,6 +1579,11 @@ type UpdateApplicationRequest struct {
 	Type        string `json:"type,omitempty"`
 	Description string `json:"description,omitempty"`
 	AllowDcr    *bool  `json:"allowDcr,omitempty"`
+	// Agent settings are only accepted for applications of type agent. They are pointers so an
+	// update can clear them.
+	AgentFramework *string   `json:"agentFramework,omitempty"`
+	ModelProviders *[]string `json:"modelProviders,omitempty"`
+	CallbackURLs   *[]string `json:"callbackUrls,omitempty"`
 }
 
 // GetApplicationByID retrieves an application by ID
@@ -722,25 +1593,32 @@ func (c *Client) GetApplicationByID(ctx context.Context, id string) (*Applicatio
 	})
 
 	path := fmt.Sprintf("/applications/resources/applications/v1/%s", id)
-	resp, err := c.DoRequest(ctx, http.MethodGet, path, nil)
+	resp, cached, err := c.conditionalGet(ctx, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get application: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	if cached != nil {
+		application := cached.(Application)
+		return &application, nil
+	}
+
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, nil
+		// Forget the cached application so a recreated one is not served from the cache
+		c.storeETag(resp, path, nil)
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get application with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to get application")
 	}
 
 	var application Application
 	if err := json.NewDecoder(resp.Body).Decode(&application); err != nil {
 		return nil, fmt.Errorf("failed to decode application response: %w", err)
 	}
+	c.storeETag(resp, path, application)
 
 	return &application, nil
 }
@@ -760,10 +1638,11 @@ func (c *Client) UpdateApplication(ctx context.Context, id string, req UpdateApp
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to update application with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to update application")
 	}
 
-	// Fetch the updated application
+	// Fetch the updated application after closing the response, which holds a request slot until closed
+	_ = resp.Body.Close()
 	return c.GetApplicationByID(ctx, id)
 }
 
@@ -782,7 +1661,169 @@ func (c *Client) DeleteApplication(ctx context.Context, id string) error {
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete application with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return newAPIError(resp, bodyBytes, "failed to delete application")
+	}
+
+	return nil
+}
+
+// UploadAssetRequest represents the request to upload a vendor asset
+type UploadAssetRequest struct {
+	AssetName string `json:"assetName"`
+	Asset     string `json:"asset"`
+}
+
+// UploadApplicationLogo uploads an application logo as a vendor asset and returns the URL it
+// is hosted at
+func (c *Client) UploadApplicationLogo(ctx context.Context, content []byte, filename string) (string, error) {
+	tflog.Info(ctx, "Uploading application logo", map[string]interface{}{
+		"filename": filename,
+		"bytes":    len(content),
+	})
+
+	req := UploadAssetRequest{
+		AssetName: filename,
+		Asset:     base64.StdEncoding.EncodeToString(content),
+	}
+	resp, err := c.DoRequest(ctx, http.MethodPost, "/vendors/assets", req)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload logo: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return "", newAPIError(resp, bodyBytes, "failed to upload logo")
+	}
+
+	var logoURL string
+	if err := json.NewDecoder(resp.Body).Decode(&logoURL); err != nil {
+		return "", fmt.Errorf("failed to decode logo upload response: %w", err)
+	}
+	if logoURL == "" {
+		return "", fmt.Errorf("logo upload response did not include a URL")
+	}
+
+	return logoURL, nil
+}
+
+// ApplicationCredentials represents the OAuth client secrets of an application
+type ApplicationCredentials struct {
+	ClientSecret string `json:"clientSecret"`
+	SharedSecret string `json:"sharedSecret"`
+}
+
+// GetApplicationCredentials retrieves the OAuth client credentials of an application
+func (c *Client) GetApplicationCredentials(ctx context.Context, appID string) (*ApplicationCredentials, error) {
+	tflog.Info(ctx, "Fetching application credentials", map[string]interface{}{
+		"app_id": appID,
+	})
+
+	path := fmt.Sprintf("/applications/resources/applications/v1/credentials/%s", appID)
+	resp, err := c.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application credentials: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, bodyBytes, "failed to get application credentials")
+	}
+
+	var credentials ApplicationCredentials
+	if err := json.NewDecoder(resp.Body).Decode(&credentials); err != nil {
+		return nil, fmt.Errorf("failed to decode application credentials response: %w", err)
+	}
+
+	return &credentials, nil
+}
+
+// tenantAssignment represents the applications assigned to a tenant
+type tenantAssignment struct {
+	TenantID string   `json:"tenantId"`
+	AppIDs   []string `json:"appIds"`
+}
+
+// GetTenantApplications retrieves the IDs of the applications assigned to a tenant
+func (c *Client) GetTenantApplications(ctx context.Context, tenantID string) ([]string, error) {
+	tflog.Info(ctx, "Fetching tenant applications", map[string]interface{}{
+		"tenant_id": tenantID,
+	})
+
+	path := "/applications/resources/applications/tenant-assignments/v1?tenantIds=" + url.QueryEscape(tenantID)
+	resp, err := c.DoRequest(WithTenantID(ctx, tenantID), http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant applications: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, bodyBytes, "failed to get tenant applications")
+	}
+
+	var assignments []tenantAssignment
+	if err := json.NewDecoder(resp.Body).Decode(&assignments); err != nil {
+		return nil, fmt.Errorf("failed to decode tenant applications response: %w", err)
+	}
+
+	appIDs := []string{}
+	for _, assignment := range assignments {
+		if assignment.TenantID == tenantID {
+			appIDs = append(appIDs, assignment.AppIDs...)
+		}
+	}
+	return appIDs, nil
+}
+
+// AssignTenantApplications makes applications available to a tenant, keeping the applications
+// already assigned to it. The API assigns one application per request.
+func (c *Client) AssignTenantApplications(ctx context.Context, tenantID string, appIDs []string) error {
+	for _, appID := range appIDs {
+		tflog.Info(ctx, "Assigning application to tenant", map[string]interface{}{
+			"tenant_id": tenantID,
+			"app_id":    appID,
+		})
+
+		path := fmt.Sprintf("/applications/resources/applications/tenant-assignments/v1/%s", appID)
+		resp, err := c.DoRequest(ctx, http.MethodPost, path, map[string]string{"tenantId": tenantID})
+		if err != nil {
+			return fmt.Errorf("failed to assign application: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			return newAPIError(resp, bodyBytes, "failed to assign application")
+		}
+		_ = resp.Body.Close()
+	}
+
+	return nil
+}
+
+// UnassignTenantApplications removes applications from a tenant. Applications that are not
+// assigned to the tenant are ignored.
+func (c *Client) UnassignTenantApplications(ctx context.Context, tenantID string, appIDs []string) error {
+	for _, appID := range appIDs {
+		tflog.Info(ctx, "Unassigning application from tenant", map[string]interface{}{
+			"tenant_id": tenantID,
+			"app_id":    appID,
+		})
+
+		path := fmt.Sprintf("/applications/resources/applications/tenant-assignments/v1/%s/%s", appID, tenantID)
+		resp, err := c.DoRequest(ctx, http.MethodDelete, path, nil)
+		if err != nil {
+			return fmt.Errorf("failed to unassign application: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			return newAPIError(resp, bodyBytes, "failed to unassign application")
+		}
+		_ = resp.Body.Close()
 	}
 
 	return nil
@@ -808,14 +1849,106 @@ type CreateOrUpdateMcpConfigurationRequest struct {
 	APITimeout int    `json:"apiTimeout"`
 }
 
+// mcpConfigurationMaxAttempts is the number of times an MCP configuration write is attempted
+// when it races with a concurrent write
+const mcpConfigurationMaxAttempts = 3
+
+// McpConfigurationConflictError is returned when the MCP configuration of an app was changed
+// concurrently, e.g. by another Terraform workspace managing the same app
+type McpConfigurationConflictError struct {
+	AppID   string
+	Current *McpConfiguration
+	Err     error
+}
+
+// Error implements the error interface
+func (e *McpConfigurationConflictError) Error() string {
+	msg := fmt.Sprintf("MCP configuration of app %s was changed concurrently", e.AppID)
+	if e.Current != nil {
+		msg += fmt.Sprintf(" (now baseUrl=%s, apiTimeout=%d)", e.Current.BaseURL, e.Current.APITimeout)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the API error that reported the conflict, if any
+func (e *McpConfigurationConflictError) Unwrap() error {
+	return e.Err
+}
+
 // CreateOrUpdateMcpConfiguration creates or updates MCP configuration
 func (c *Client) CreateOrUpdateMcpConfiguration(ctx context.Context, req CreateOrUpdateMcpConfigurationRequest) (*McpConfiguration, error) {
+	return c.postMcpConfiguration(ctx, req, nil)
+}
+
+// UpdateMcpConfiguration writes the MCP configuration of an app with optimistic concurrency.
+// When expected is set, the write is rejected with a McpConfigurationConflictError if the
+// current configuration matches neither expected nor the request, i.e. it was changed by
+// someone else since it was read. When the API returns an ETag the write is conditional on it,
+// and writes rejected with 409 or 412 are retried after re-reading the configuration.
+func (c *Client) UpdateMcpConfiguration(ctx context.Context, req CreateOrUpdateMcpConfigurationRequest, expected *McpConfiguration) (*McpConfiguration, error) {
+	delay := c.conflictRetryDelay
+
+	for attempt := 1; ; attempt++ {
+		// A missing configuration is created by the write
+		current, etag, err := c.getMcpConfiguration(ctx, req.AppID)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+
+		if expected != nil && current != nil && !current.matches(expected.BaseURL, expected.APITimeout) && !current.matches(req.BaseURL, req.APITimeout) {
+			return nil, &McpConfigurationConflictError{AppID: req.AppID, Current: current}
+		}
+
+		var headers map[string]string
+		if etag != "" {
+			headers = map[string]string{"If-Match": etag}
+		}
+
+		config, err := c.postMcpConfiguration(ctx, req, headers)
+		if err == nil || !isConcurrentWriteError(err) {
+			return config, err
+		}
+		if attempt == mcpConfigurationMaxAttempts {
+			return nil, &McpConfigurationConflictError{AppID: req.AppID, Current: current, Err: err}
+		}
+
+		tflog.Warn(ctx, "MCP configuration changed concurrently, retrying", map[string]interface{}{
+			"app_id":  req.AppID,
+			"attempt": attempt,
+			"delay":   delay.String(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// matches reports whether the configuration has the given settings
+func (m *McpConfiguration) matches(baseURL string, apiTimeout int) bool {
+	return m.BaseURL == baseURL && m.APITimeout == apiTimeout
+}
+
+// isConcurrentWriteError reports whether a write failed because of a concurrent change
+func isConcurrentWriteError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusConflict || apiErr.StatusCode == http.StatusPreconditionFailed)
+}
+
+// postMcpConfiguration creates or updates the MCP configuration with extra request headers
+func (c *Client) postMcpConfiguration(ctx context.Context, req CreateOrUpdateMcpConfigurationRequest, headers map[string]string) (*McpConfiguration, error) {
 	tflog.Info(ctx, "Creating/updating MCP configuration", map[string]interface{}{
 		"app_id":   req.AppID,
 		"base_url": req.BaseURL,
 	})
 
-	resp, err := c.DoRequest(ctx, http.MethodPost, "/app-integrations/resources/app-mcp-configurations/v1", req)
+	resp, err := c.doRequest(ctx, http.MethodPost, "/app-integrations/resources/app-mcp-configurations/v1", req, headers)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create/update MCP configuration: %w", err)
 	}
@@ -823,7 +1956,7 @@ func (c *Client) CreateOrUpdateMcpConfiguration(ctx context.Context, req CreateO
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to create/update MCP configuration with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to create/update MCP configuration")
 	}
 
 	var config McpConfiguration
@@ -836,6 +1969,12 @@ func (c *Client) CreateOrUpdateMcpConfiguration(ctx context.Context, req CreateO
 
 // GetMcpConfiguration retrieves MCP configuration for an app
 func (c *Client) GetMcpConfiguration(ctx context.Context, appID string) (*McpConfiguration, error) {
+	config, _, err := c.getMcpConfiguration(ctx, appID)
+	return config, err
+}
+
+// getMcpConfiguration retrieves the MCP configuration for an app and the ETag of the response
+func (c *Client) getMcpConfiguration(ctx context.Context, appID string) (*McpConfiguration, string, error) {
 	tflog.Info(ctx, "Fetching MCP configuration", map[string]interface{}{
 		"app_id": appID,
 	})
@@ -843,25 +1982,21 @@ func (c *Client) GetMcpConfiguration(ctx context.Context, appID string) (*McpCon
 	path := fmt.Sprintf("/app-integrations/resources/app-mcp-configurations/v1?appId=%s", appID)
 	resp, err := c.DoRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get MCP configuration: %w", err)
+		return nil, "", fmt.Errorf("failed to get MCP configuration: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, nil
-	}
-
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get MCP configuration with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, "", newAPIError(resp, bodyBytes, "failed to get MCP configuration")
 	}
 
 	var config McpConfiguration
 	if err := json.NewDecoder(resp.Body).Decode(&config); err != nil {
-		return nil, fmt.Errorf("failed to decode MCP configuration response: %w", err)
+		return nil, "", fmt.Errorf("failed to decode MCP configuration response: %w", err)
 	}
 
-	return &config, nil
+	return &config, resp.Header.Get("ETag"), nil
 }
 
 // ============================================================================
@@ -878,7 +2013,8 @@ type UpdateSourceRequest struct {
 	Enabled    *bool  `json:"enabled,omitempty"`
 }
 
-// GetSourceByID retrieves a source by ID
+// GetSourceByID retrieves a source by ID from the sources listed for an app. The API has no GET
+// for a single source, so a source that is not listed for the app returns ErrNotFound.
 func (c *Client) GetSourceByID(ctx context.Context, appID, sourceID string) (*Source, error) {
 	sources, err := c.GetSources(ctx, appID)
 	if err != nil {
@@ -886,12 +2022,16 @@ func (c *Client) GetSourceByID(ctx context.Context, appID, sourceID string) (*So
 	}
 
 	for _, src := range sources {
-		if src.ID == sourceID {
-			return &src, nil
+		if src.ID != sourceID {
+			continue
+		}
+		if src.AppID != appID {
+			return nil, fmt.Errorf("source %s belongs to application %q, not %s: %w", sourceID, src.AppID, appID, ErrNotFound)
 		}
+		return &src, nil
 	}
 
-	return nil, nil
+	return nil, fmt.Errorf("source %s not found in application %s: %w", sourceID, appID, ErrNotFound)
 }
 
 // UpdateSource updates an existing source
@@ -909,7 +2049,7 @@ func (c *Client) UpdateSource(ctx context.Context, sourceID string, req UpdateSo
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to update source with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to update source")
 	}
 
 	var source Source
@@ -935,7 +2075,7 @@ func (c *Client) DeleteSource(ctx context.Context, appID, sourceID string) error
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete source with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return newAPIError(resp, bodyBytes, "failed to delete source")
 	}
 
 	return nil
@@ -953,6 +2093,60 @@ type PolicyCondition struct {
 	Value     map[string]interface{} `json:"value"`
 }
 
+// numberConditionValueKeys are the condition value keys whose values are numbers in the API
+var numberConditionValueKeys = map[string]bool{
+	"number": true,
+	"start":  true,
+	"end":    true,
+}
+
+// booleanConditionValueKey is the condition value key whose value is a boolean in the API
+const booleanConditionValueKey = "boolean"
+
+// MarshalJSON encodes the condition with the values of numeric and boolean keys sent as their
+// native JSON types, since Terraform passes every condition value as a string. Values of other
+// keys, such as string, list and date, are sent as strings even when they look like numbers.
+func (c PolicyCondition) MarshalJSON() ([]byte, error) {
+	type policyCondition PolicyCondition
+
+	value := make(map[string]interface{}, len(c.Value))
+	for k, v := range c.Value {
+		value[k] = v
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		switch {
+		case numberConditionValueKeys[k]:
+			value[k] = numberConditionValue(s)
+		case k == booleanConditionValueKey:
+			if b, err := strconv.ParseBool(s); err == nil {
+				value[k] = b
+			}
+		}
+	}
+
+	condition := policyCondition(c)
+	condition.Value = value
+	return json.Marshal(condition)
+}
+
+// numberConditionValue returns s as a JSON number when it is one, and s otherwise, so the API
+// reports invalid numbers
+func numberConditionValue(s string) interface{} {
+	decoder := json.NewDecoder(strings.NewReader(s))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil || decoder.More() {
+		return s
+	}
+	if n, ok := v.(json.Number); ok {
+		return n
+	}
+	return s
+}
+
 // PolicyIfBlock represents the if block in targeting
 type PolicyIfBlock struct {
 	Conditions []PolicyCondition `json:"conditions"`
@@ -966,8 +2160,9 @@ type PolicyThenBlock struct {
 
 // PolicyTargeting represents policy targeting rules
 type PolicyTargeting struct {
-	If   PolicyIfBlock   `json:"if"`
-	Then PolicyThenBlock `json:"then"`
+	If   PolicyIfBlock    `json:"if"`
+	Then PolicyThenBlock  `json:"then"`
+	Else *PolicyThenBlock `json:"else,omitempty"`
 }
 
 // MaskingPolicyConfiguration represents the configuration for data masking
@@ -987,6 +2182,16 @@ type MaskingPolicyConfiguration struct {
 	EthereumAddress bool `json:"ethereumAddress,omitempty"`
 	CvvCvc          bool `json:"cvvCvc,omitempty"`
 	Url             bool `json:"url,omitempty"`
+
+	// EntityFormats holds per-entity mask formatting, keyed by API entity name (e.g. creditCard)
+	EntityFormats map[string]MaskingEntityFormat `json:"entityFormats,omitempty"`
+}
+
+// MaskingEntityFormat represents how a single detected entity is masked. VisibleCharsCount is a
+// pointer so that 0, masking the entity completely, is sent.
+type MaskingEntityFormat struct {
+	MaskChar          string `json:"maskChar,omitempty"`
+	VisibleCharsCount *int   `json:"visibleCharsCount,omitempty"`
 }
 
 // Policy represents a generic policy response
@@ -999,7 +2204,9 @@ type Policy struct {
 	Enabled             bool                        `json:"enabled"`
 	AppIDs              []string                    `json:"appIds,omitempty"`
 	TenantID            string                      `json:"tenantId,omitempty"`
+	TenantIDs           []string                    `json:"tenantIds,omitempty"`
 	InternalToolIDs     []string                    `json:"internalToolIds,omitempty"`
+	ToolTags            []string                    `json:"toolTags,omitempty"`
 	Targeting           *PolicyTargeting            `json:"targeting,omitempty"`
 	Keys                []string                    `json:"keys,omitempty"`
 	PolicyConfiguration *MaskingPolicyConfiguration `json:"policyConfiguration,omitempty"`
@@ -1015,21 +2222,26 @@ type CreateConditionalPolicyRequest struct {
 	Enabled         bool                   `json:"enabled"`
 	AppIDs          []string               `json:"appIds,omitempty"`
 	TenantID        string                 `json:"tenantId,omitempty"`
+	TenantIDs       []string               `json:"tenantIds,omitempty"`
 	InternalToolIDs []string               `json:"internalToolIds"`
+	ToolTags        []string               `json:"toolTags"`
 	Targeting       *PolicyTargeting       `json:"targeting,omitempty"`
 	Metadata        map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // CreateRbacPolicyRequest represents the request to create an RBAC policy
 type CreateRbacPolicyRequest struct {
-	Name            string   `json:"name"`
-	Description     string   `json:"description,omitempty"`
-	Enabled         bool     `json:"enabled"`
-	AppIDs          []string `json:"appIds,omitempty"`
-	TenantID        string   `json:"tenantId,omitempty"`
-	InternalToolIDs []string `json:"internalToolIds"`
-	Type            string   `json:"type"` // "RBAC_ROLES" or "RBAC_PERMISSIONS"
-	Keys            []string `json:"keys"`
+	Name            string           `json:"name"`
+	Description     string           `json:"description,omitempty"`
+	Enabled         bool             `json:"enabled"`
+	AppIDs          []string         `json:"appIds,omitempty"`
+	TenantID        string           `json:"tenantId,omitempty"`
+	TenantIDs       []string         `json:"tenantIds,omitempty"`
+	InternalToolIDs []string         `json:"internalToolIds"`
+	ToolTags        []string         `json:"toolTags"`
+	Type            string           `json:"type"` // "RBAC_ROLES" or "RBAC_PERMISSIONS"
+	Keys            []string         `json:"keys"`
+	Targeting       *PolicyTargeting `json:"targeting,omitempty"`
 }
 
 // CreateMaskingPolicyRequest represents the request to create a masking policy
@@ -1039,7 +2251,9 @@ type CreateMaskingPolicyRequest struct {
 	Enabled             bool                        `json:"enabled"`
 	AppIDs              []string                    `json:"appIds,omitempty"`
 	TenantID            string                      `json:"tenantId,omitempty"`
+	TenantIDs           []string                    `json:"tenantIds,omitempty"`
 	InternalToolIDs     []string                    `json:"internalToolIds"`
+	ToolTags            []string                    `json:"toolTags"`
 	Targeting           *PolicyTargeting            `json:"targeting,omitempty"`
 	PolicyConfiguration *MaskingPolicyConfiguration `json:"policyConfiguration"`
 	Metadata            map[string]interface{}      `json:"metadata,omitempty"`
@@ -1051,21 +2265,26 @@ type UpdateConditionalPolicyRequest struct {
 	Description     string                 `json:"description,omitempty"`
 	Enabled         *bool                  `json:"enabled,omitempty"`
 	AppIDs          []string               `json:"appIds,omitempty"`
-	TenantID        string                 `json:"tenantId,omitempty"`
+	TenantID        *string                `json:"tenantId,omitempty"`
+	TenantIDs       *[]string              `json:"tenantIds,omitempty"`
 	InternalToolIDs []string               `json:"internalToolIds,omitempty"`
-	Targeting       *PolicyTargeting       `json:"targeting,omitempty"`
+	ToolTags        []string               `json:"toolTags"`
+	Targeting       *PolicyTargeting       `json:"targeting"`
 	Metadata        map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // UpdateRbacPolicyRequest represents the request to update an RBAC policy
 type UpdateRbacPolicyRequest struct {
-	Name            string   `json:"name,omitempty"`
-	Description     string   `json:"description,omitempty"`
-	Enabled         *bool    `json:"enabled,omitempty"`
-	AppIDs          []string `json:"appIds,omitempty"`
-	TenantID        string   `json:"tenantId,omitempty"`
-	InternalToolIDs []string `json:"internalToolIds,omitempty"`
-	Keys            []string `json:"keys,omitempty"`
+	Name            string           `json:"name,omitempty"`
+	Description     string           `json:"description,omitempty"`
+	Enabled         *bool            `json:"enabled,omitempty"`
+	AppIDs          []string         `json:"appIds,omitempty"`
+	TenantID        *string          `json:"tenantId,omitempty"`
+	TenantIDs       *[]string        `json:"tenantIds,omitempty"`
+	InternalToolIDs []string         `json:"internalToolIds,omitempty"`
+	ToolTags        []string         `json:"toolTags"`
+	Keys            []string         `json:"keys,omitempty"`
+	Targeting       *PolicyTargeting `json:"targeting"`
 }
 
 // UpdateMaskingPolicyRequest represents the request to update a masking policy
@@ -1074,9 +2293,11 @@ type UpdateMaskingPolicyRequest struct {
 	Description         string                      `json:"description,omitempty"`
 	Enabled             *bool                       `json:"enabled,omitempty"`
 	AppIDs              []string                    `json:"appIds,omitempty"`
-	TenantID            string                      `json:"tenantId,omitempty"`
+	TenantID            *string                     `json:"tenantId,omitempty"`
+	TenantIDs           *[]string                   `json:"tenantIds,omitempty"`
 	InternalToolIDs     []string                    `json:"internalToolIds,omitempty"`
-	Targeting           *PolicyTargeting            `json:"targeting,omitempty"`
+	ToolTags            []string                    `json:"toolTags"`
+	Targeting           *PolicyTargeting            `json:"targeting"`
 	PolicyConfiguration *MaskingPolicyConfiguration `json:"policyConfiguration,omitempty"`
 	Metadata            map[string]interface{}      `json:"metadata,omitempty"`
 }
@@ -1099,7 +2320,7 @@ func (c *Client) CreateConditionalPolicy(ctx context.Context, req CreateConditio
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to create conditional policy with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to create conditional policy")
 	}
 
 	var result struct {
@@ -1109,7 +2330,8 @@ func (c *Client) CreateConditionalPolicy(ctx context.Context, req CreateConditio
 		return nil, fmt.Errorf("failed to decode policy response: %w", err)
 	}
 
-	// Fetch the full policy
+	// Fetch the full policy after closing the response, which holds a request slot until closed
+	_ = resp.Body.Close()
 	return c.GetConditionalPolicy(ctx, result.ID)
 }
 
@@ -1126,13 +2348,9 @@ func (c *Client) GetConditionalPolicy(ctx context.Context, id string) (*Policy,
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, nil
-	}
-
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get conditional policy with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to get conditional policy")
 	}
 
 	var policy Policy
@@ -1158,10 +2376,11 @@ func (c *Client) UpdateConditionalPolicy(ctx context.Context, id string, req Upd
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to update conditional policy with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to update conditional policy")
 	}
 
-	// Fetch the updated policy
+	// Fetch the updated policy after closing the response, which holds a request slot until closed
+	_ = resp.Body.Close()
 	return c.GetConditionalPolicy(ctx, id)
 }
 
@@ -1180,12 +2399,98 @@ func (c *Client) DeletePolicy(ctx context.Context, id string) error {
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete policy with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return newAPIError(resp, bodyBytes, "failed to delete policy")
+	}
+
+	return nil
+}
+
+// SetPolicyEnabled enables or disables a policy of any type without changing its other settings.
+// Policies are updated at the path of their type, so the policy type has to be passed along.
+func (c *Client) SetPolicyEnabled(ctx context.Context, id, policyType string, enabled bool) error {
+	tflog.Info(ctx, "Setting policy enabled", map[string]interface{}{
+		"id":      id,
+		"type":    policyType,
+		"enabled": enabled,
+	})
+
+	path, err := policyItemPath(id, policyType)
+	if err != nil {
+		return err
+	}
+	resp, err := c.DoRequest(ctx, http.MethodPatch, path, map[string]bool{"enabled": enabled})
+	if err != nil {
+		return fmt.Errorf("failed to update policy: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, bodyBytes, "failed to update policy")
 	}
 
 	return nil
 }
 
+// AttachPolicyApplications adds application IDs to a policy, keeping the applications it already applies to
+func (c *Client) AttachPolicyApplications(ctx context.Context, id string, appIDs []string) (*Policy, error) {
+	return c.updatePolicyApplications(ctx, id, func(current []string) []string {
+		result := append([]string{}, current...)
+		for _, appID := range appIDs {
+			if !slices.Contains(result, appID) {
+				result = append(result, appID)
+			}
+		}
+		return result
+	})
+}
+
+// DetachPolicyApplications removes application IDs from a policy, keeping its other applications
+func (c *Client) DetachPolicyApplications(ctx context.Context, id string, appIDs []string) (*Policy, error) {
+	return c.updatePolicyApplications(ctx, id, func(current []string) []string {
+		result := []string{}
+		for _, appID := range current {
+			if !slices.Contains(appIDs, appID) {
+				result = append(result, appID)
+			}
+		}
+		return result
+	})
+}
+
+// updatePolicyApplications reads the application IDs of a policy and writes back the result of
+// update, leaving the other fields of the policy untouched. It returns ErrNotFound if the policy does not exist.
+func (c *Client) updatePolicyApplications(ctx context.Context, id string, update func([]string) []string) (*Policy, error) {
+	policy, err := c.GetConditionalPolicy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	appIDs := update(policy.AppIDs)
+	tflog.Info(ctx, "Updating policy applications", map[string]interface{}{
+		"id":      id,
+		"app_ids": appIDs,
+	})
+
+	path, err := policyItemPath(id, policy.Type)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.DoRequest(ctx, http.MethodPatch, path, map[string][]string{"appIds": appIDs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update policy applications: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, bodyBytes, "failed to update policy applications")
+	}
+
+	policy.AppIDs = appIDs
+	return policy, nil
+}
+
 // ============================================================================
 // RBAC Policy CRUD
 // ============================================================================
@@ -1205,7 +2510,7 @@ func (c *Client) CreateRbacPolicy(ctx context.Context, req CreateRbacPolicyReque
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to create RBAC policy with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to create RBAC policy")
 	}
 
 	var result struct {
@@ -1215,7 +2520,8 @@ func (c *Client) CreateRbacPolicy(ctx context.Context, req CreateRbacPolicyReque
 		return nil, fmt.Errorf("failed to decode policy response: %w", err)
 	}
 
-	// Fetch the full policy
+	// Fetch the full policy after closing the response, which holds a request slot until closed
+	_ = resp.Body.Close()
 	return c.GetRbacPolicy(ctx, result.ID)
 }
 
@@ -1232,13 +2538,9 @@ func (c *Client) GetRbacPolicy(ctx context.Context, id string) (*Policy, error)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, nil
-	}
-
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get RBAC policy with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to get RBAC policy")
 	}
 
 	var policy Policy
@@ -1264,10 +2566,11 @@ func (c *Client) UpdateRbacPolicy(ctx context.Context, id string, req UpdateRbac
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to update RBAC policy with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to update RBAC policy")
 	}
 
-	// Fetch the updated policy
+	// Fetch the updated policy after closing the response, which holds a request slot until closed
+	_ = resp.Body.Close()
 	return c.GetRbacPolicy(ctx, id)
 }
 
@@ -1289,7 +2592,7 @@ func (c *Client) CreateMaskingPolicy(ctx context.Context, req CreateMaskingPolic
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to create masking policy with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to create masking policy")
 	}
 
 	var result struct {
@@ -1299,7 +2602,8 @@ func (c *Client) CreateMaskingPolicy(ctx context.Context, req CreateMaskingPolic
 		return nil, fmt.Errorf("failed to decode policy response: %w", err)
 	}
 
-	// Fetch the full policy
+	// Fetch the full policy after closing the response, which holds a request slot until closed
+	_ = resp.Body.Close()
 	return c.GetMaskingPolicy(ctx, result.ID)
 }
 
@@ -1316,13 +2620,9 @@ func (c *Client) GetMaskingPolicy(ctx context.Context, id string) (*Policy, erro
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, nil
-	}
-
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get masking policy with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to get masking policy")
 	}
 
 	var policy Policy
@@ -1348,46 +2648,144 @@ func (c *Client) UpdateMaskingPolicy(ctx context.Context, id string, req UpdateM
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to update masking policy with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to update masking policy")
 	}
 
-	// Fetch the updated policy
+	// Fetch the updated policy after closing the response, which holds a request slot until closed
+	_ = resp.Body.Close()
 	return c.GetMaskingPolicy(ctx, id)
 }
 
 // ============================================================================
-// Tools Methods (additional)
+// Policy Listing
 // ============================================================================
 
-// DeleteToolsBySource deletes all tools associated with a source
-func (c *Client) DeleteToolsBySource(ctx context.Context, appID, sourceID string) error {
-	tflog.Info(ctx, "Deleting tools by source", map[string]interface{}{
-		"app_id":    appID,
-		"source_id": sourceID,
-	})
+// GetConditionalPolicies retrieves all conditional policies of the vendor
+func (c *Client) GetConditionalPolicies(ctx context.Context) ([]Policy, error) {
+	return c.listPolicies(ctx, "/app-integrations/resources/policies/v1", "conditional policies")
+}
 
-	// Get tools for this source and delete them
+// GetRbacPolicies retrieves all RBAC policies of the vendor
+func (c *Client) GetRbacPolicies(ctx context.Context) ([]Policy, error) {
+	return c.listPolicies(ctx, "/app-integrations/resources/policies/v1/rbac", "RBAC policies")
+}
+
+// GetMaskingPolicies retrieves all masking policies of the vendor
+func (c *Client) GetMaskingPolicies(ctx context.Context) ([]Policy, error) {
+	return c.listPolicies(ctx, "/app-integrations/resources/policies/v1/masking", "masking policies")
+}
+
+// PolicyFilters narrows the policies returned by ListPolicies. Empty fields match every policy.
+type PolicyFilters struct {
+	// Types lists the policy types to return, e.g. MASKING or RBAC_ROLES
+	Types []string
+	// AppID only returns policies applied to the application
+	AppID string
+}
+
+// policyListPaths are the policy collections merged by ListPolicies. Each endpoint only lists the
+// policies of its own kind, so every collection has to be read to see all policies of the vendor.
+var policyListPaths = []string{
+	"/app-integrations/resources/policies/v1",
+	"/app-integrations/resources/policies/v1/rbac",
+	"/app-integrations/resources/policies/v1/masking",
+}
+
+// ListPolicies retrieves all policies of the vendor matching the filters across every policy collection
+func (c *Client) ListPolicies(ctx context.Context, filters PolicyFilters) ([]Policy, error) {
+	result := []Policy{}
+	seen := map[string]bool{}
+	for _, path := range policyListPaths {
+		policies, err := c.listPolicies(ctx, path, "policies")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, policy := range policies {
+			if seen[policy.ID] {
+				continue
+			}
+			seen[policy.ID] = true
+
+			if len(filters.Types) > 0 && !slices.Contains(filters.Types, policy.Type) {
+				continue
+			}
+			if filters.AppID != "" && !slices.Contains(policy.AppIDs, filters.AppID) {
+				continue
+			}
+			result = append(result, policy)
+		}
+	}
+
+	return result, nil
+}
+
+// listPolicies retrieves every policy of a policy collection
+func (c *Client) listPolicies(ctx context.Context, path, kind string) ([]Policy, error) {
+	tflog.Info(ctx, "Fetching "+kind)
+
+	resp, err := c.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", kind, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, bodyBytes, "failed to get "+kind)
+	}
+
+	policies := []Policy{}
+	if err := json.NewDecoder(resp.Body).Decode(&policies); err != nil {
+		return nil, fmt.Errorf("failed to decode %s response: %w", kind, err)
+	}
+
+	return policies, nil
+}
+
+// ============================================================================
+// Tools Methods (additional)
+// ============================================================================
+
+// GetToolsBySource retrieves the tools imported from a source
+func (c *Client) GetToolsBySource(ctx context.Context, appID, sourceID string) ([]InternalTool, error) {
 	path := fmt.Sprintf("/app-integrations/resources/internal-tools/v1?appId=%s&sourceId=%s", appID, sourceID)
 	resp, err := c.DoRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return fmt.Errorf("failed to get tools: %w", err)
+		return nil, fmt.Errorf("failed to get tools: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to get tools with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to get tools")
 	}
 
 	var result struct {
 		Items []InternalTool `json:"items"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to decode tools response: %w", err)
+		return nil, fmt.Errorf("failed to decode tools response: %w", err)
+	}
+
+	return result.Items, nil
+}
+
+// DeleteToolsBySource deletes all tools associated with a source
+func (c *Client) DeleteToolsBySource(ctx context.Context, appID, sourceID string) error {
+	tflog.Info(ctx, "Deleting tools by source", map[string]interface{}{
+		"app_id":    appID,
+		"source_id": sourceID,
+	})
+
+	// Get tools for this source and delete them
+	tools, err := c.GetToolsBySource(ctx, appID, sourceID)
+	if err != nil {
+		return err
 	}
 
 	// Delete each tool
-	for _, tool := range result.Items {
+	for _, tool := range tools {
 		if err := c.DeleteTool(ctx, appID, tool.ID); err != nil {
 			tflog.Warn(ctx, "Failed to delete tool", map[string]interface{}{
 				"tool_id": tool.ID,
@@ -1410,7 +2808,7 @@ func (c *Client) DeleteTool(ctx context.Context, appID, toolID string) error {
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete tool with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return newAPIError(resp, bodyBytes, "failed to delete tool")
 	}
 
 	return nil
@@ -1418,20 +2816,62 @@ func (c *Client) DeleteTool(ctx context.Context, appID, toolID string) error {
 
 // VendorConfig represents the vendor configuration response
 type VendorConfig struct {
-	ID             string   `json:"id"`
-	Name           string   `json:"name"`
-	AllowedOrigins []string `json:"allowedOrigins"`
+	ID                   string   `json:"id"`
+	Name                 string   `json:"name"`
+	AllowedOrigins       []string `json:"allowedOrigins"`
+	Host                 string   `json:"host"`
+	CustomDomain         string   `json:"customDomain"`
+	CustomDomainVerified bool     `json:"customDomainVerified"`
+	SupportEmail         string   `json:"supportEmail"`
+	Logo                 string   `json:"logo"`
+	Icon                 string   `json:"icon"`
+	AppURL               string   `json:"appURL"`
+	LoginURL             string   `json:"loginURL"`
+	Country              string   `json:"country"`
+	Timezone             string   `json:"timezone"`
+	DateFormat           string   `json:"dateFormat"`
+	TimeFormat           string   `json:"timeFormat"`
+	StartWeekDay         string   `json:"startWeekDay"`
+	Currency             string   `json:"currency"`
+	OpenSaaSInstalled    bool     `json:"openSaaSInstalled"`
+}
+
+// UpdateVendorSettingsRequest represents the request to update vendor-level settings.
+// Allowed origins are managed separately through UpdateAllowedOrigins.
+type UpdateVendorSettingsRequest struct {
+	Name              string `json:"name,omitempty"`
+	SupportEmail      string `json:"supportEmail,omitempty"`
+	Logo              string `json:"logo,omitempty"`
+	Icon              string `json:"icon,omitempty"`
+	AppURL            string `json:"appURL,omitempty"`
+	LoginURL          string `json:"loginURL,omitempty"`
+	Country           string `json:"country,omitempty"`
+	Timezone          string `json:"timezone,omitempty"`
+	DateFormat        string `json:"dateFormat,omitempty"`
+	TimeFormat        string `json:"timeFormat,omitempty"`
+	StartWeekDay      string `json:"startWeekDay,omitempty"`
+	Currency          string `json:"currency,omitempty"`
+	OpenSaaSInstalled *bool  `json:"openSaaSInstalled,omitempty"`
 }
 
 // IdentityConfiguration represents the identity configuration response
 type IdentityConfiguration struct {
-	ID                      string `json:"id"`
-	DefaultTokenExpiration  int    `json:"defaultTokenExpiration"`
+	ID                            string `json:"id"`
+	DefaultTokenExpiration        int    `json:"defaultTokenExpiration"`
+	DefaultRefreshTokenExpiration int    `json:"defaultRefreshTokenExpiration"`
+	RotateRefreshTokens           bool   `json:"rotateRefreshTokens"`
+	RefreshTokensRotationLimit    int    `json:"refreshTokensRotationLimit"`
+	JwtAlgorithm                  string `json:"jwtAlgorithm"`
+	PublicKey                     string `json:"publicKey"`
 }
 
 // UpdateIdentityConfigurationRequest represents the request to update identity configuration
 type UpdateIdentityConfigurationRequest struct {
-	DefaultTokenExpiration *int `json:"defaultTokenExpiration,omitempty"`
+	DefaultTokenExpiration        *int    `json:"defaultTokenExpiration,omitempty"`
+	DefaultRefreshTokenExpiration *int    `json:"defaultRefreshTokenExpiration,omitempty"`
+	RotateRefreshTokens           *bool   `json:"rotateRefreshTokens,omitempty"`
+	RefreshTokensRotationLimit    *int    `json:"refreshTokensRotationLimit,omitempty"`
+	JwtAlgorithm                  *string `json:"jwtAlgorithm,omitempty"`
 }
 
 // UpdateAllowedOriginsRequest represents the request to update allowed origins
@@ -1439,8 +2879,20 @@ type UpdateAllowedOriginsRequest struct {
 	AllowedOrigins []string `json:"allowedOrigins"`
 }
 
-// GetVendorConfig retrieves the vendor configuration
+// vendorConfigCacheTTL is how long a fetched vendor configuration is reused. Several resources and
+// data sources read the vendor configuration during a single plan or apply.
+const vendorConfigCacheTTL = 30 * time.Second
+
+// GetVendorConfig retrieves the vendor configuration, reusing a recently fetched copy
 func (c *Client) GetVendorConfig(ctx context.Context) (*VendorConfig, error) {
+	c.vendorMu.Lock()
+	defer c.vendorMu.Unlock()
+
+	if c.vendorConfig != nil && time.Now().Before(c.vendorConfigExpiry) {
+		tflog.Debug(ctx, "Using cached vendor configuration")
+		return c.vendorConfig.clone(), nil
+	}
+
 	tflog.Info(ctx, "Fetching vendor configuration")
 
 	resp, err := c.DoRequest(ctx, http.MethodGet, "/vendors", nil)
@@ -1451,7 +2903,7 @@ func (c *Client) GetVendorConfig(ctx context.Context) (*VendorConfig, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get vendor config with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to get vendor config")
 	}
 
 	var config VendorConfig
@@ -1464,9 +2916,28 @@ func (c *Client) GetVendorConfig(ctx context.Context) (*VendorConfig, error) {
 		"allowed_origins": config.AllowedOrigins,
 	})
 
+	c.vendorConfig = config.clone()
+	c.vendorConfigExpiry = time.Now().Add(vendorConfigCacheTTL)
+
 	return &config, nil
 }
 
+// invalidateVendorConfig drops the cached vendor configuration after it is modified
+func (c *Client) invalidateVendorConfig() {
+	c.vendorMu.Lock()
+	c.vendorConfig = nil
+	c.vendorMu.Unlock()
+}
+
+// clone returns a copy of the configuration that does not share the allowed origins slice
+func (v *VendorConfig) clone() *VendorConfig {
+	config := *v
+	if v.AllowedOrigins != nil {
+		config.AllowedOrigins = append([]string{}, v.AllowedOrigins...)
+	}
+	return &config
+}
+
 // UpdateAllowedOrigins updates the vendor's allowed origins
 func (c *Client) UpdateAllowedOrigins(ctx context.Context, origins []string) (*VendorConfig, error) {
 	tflog.Info(ctx, "Updating allowed origins", map[string]interface{}{
@@ -1477,6 +2948,9 @@ func (c *Client) UpdateAllowedOrigins(ctx context.Context, origins []string) (*V
 		AllowedOrigins: origins,
 	}
 
+	// The vendor configuration changes even if the request fails part way
+	defer c.invalidateVendorConfig()
+
 	resp, err := c.DoRequest(ctx, http.MethodPut, "/vendors", req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update allowed origins: %w", err)
@@ -1485,7 +2959,7 @@ func (c *Client) UpdateAllowedOrigins(ctx context.Context, origins []string) (*V
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to update allowed origins with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to update allowed origins")
 	}
 
 	var config VendorConfig
@@ -1501,6 +2975,38 @@ func (c *Client) UpdateAllowedOrigins(ctx context.Context, origins []string) (*V
 	return &config, nil
 }
 
+// UpdateVendorSettings updates vendor-level settings
+func (c *Client) UpdateVendorSettings(ctx context.Context, req UpdateVendorSettingsRequest) (*VendorConfig, error) {
+	tflog.Info(ctx, "Updating vendor settings", map[string]interface{}{
+		"name": req.Name,
+	})
+
+	// The vendor configuration changes even if the request fails part way
+	defer c.invalidateVendorConfig()
+
+	resp, err := c.DoRequest(ctx, http.MethodPut, "/vendors", req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update vendor settings: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, bodyBytes, "failed to update vendor settings")
+	}
+
+	var config VendorConfig
+	if err := json.NewDecoder(resp.Body).Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to decode vendor config response: %w", err)
+	}
+
+	tflog.Info(ctx, "Successfully updated vendor settings", map[string]interface{}{
+		"vendor_id": config.ID,
+	})
+
+	return &config, nil
+}
+
 // ============================================================================
 // Identity Configuration Methods
 // ============================================================================
@@ -1509,16 +3015,15 @@ func (c *Client) UpdateAllowedOrigins(ctx context.Context, origins []string) (*V
 func (c *Client) GetIdentityConfiguration(ctx context.Context) (*IdentityConfiguration, error) {
 	tflog.Info(ctx, "Fetching identity configuration")
 
-	// Use POST with empty body to get current config (addOrUpdate returns current state)
-	resp, err := c.DoRequest(ctx, http.MethodPost, "/identity/resources/configurations/v1", map[string]interface{}{})
+	resp, err := c.DoRequest(ctx, http.MethodGet, "/identity/resources/configurations/v1", nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get identity configuration: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get identity configuration with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to get identity configuration")
 	}
 
 	var config IdentityConfiguration
@@ -1534,13 +3039,36 @@ func (c *Client) GetIdentityConfiguration(ctx context.Context) (*IdentityConfigu
 	return &config, nil
 }
 
-// UpdateIdentityConfiguration updates the identity configuration
+// identityConfigurationReadOnlyFields are returned by the identity configuration endpoint but not accepted on update
+var identityConfigurationReadOnlyFields = []string{"id", "publicKey", "createdAt", "updatedAt"}
+
+// UpdateIdentityConfiguration updates the identity configuration. The endpoint replaces the whole
+// configuration, so the current settings are fetched and merged with the request to keep settings
+// the request does not set, including ones this client does not model.
 func (c *Client) UpdateIdentityConfiguration(ctx context.Context, req UpdateIdentityConfigurationRequest) (*IdentityConfiguration, error) {
 	tflog.Info(ctx, "Updating identity configuration", map[string]interface{}{
-		"default_token_expiration": req.DefaultTokenExpiration,
+		"default_token_expiration":         req.DefaultTokenExpiration,
+		"default_refresh_token_expiration": req.DefaultRefreshTokenExpiration,
+		"rotate_refresh_tokens":            req.RotateRefreshTokens,
 	})
 
-	resp, err := c.DoRequest(ctx, http.MethodPost, "/identity/resources/configurations/v1", req)
+	body, err := c.getIdentityConfigurationFields(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update identity configuration: %w", err)
+	}
+	for _, field := range identityConfigurationReadOnlyFields {
+		delete(body, field)
+	}
+
+	changes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal identity configuration request: %w", err)
+	}
+	if err := json.Unmarshal(changes, &body); err != nil {
+		return nil, fmt.Errorf("failed to merge identity configuration request: %w", err)
+	}
+
+	resp, err := c.DoRequest(ctx, http.MethodPost, "/identity/resources/configurations/v1", body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update identity configuration: %w", err)
 	}
@@ -1548,7 +3076,7 @@ func (c *Client) UpdateIdentityConfiguration(ctx context.Context, req UpdateIden
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to update identity configuration with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes, "failed to update identity configuration")
 	}
 
 	var config IdentityConfiguration
@@ -1563,3 +3091,667 @@ func (c *Client) UpdateIdentityConfiguration(ctx context.Context, req UpdateIden
 
 	return &config, nil
 }
+
+// getIdentityConfigurationFields retrieves the identity configuration with all fields the API returns
+func (c *Client) getIdentityConfigurationFields(ctx context.Context) (map[string]interface{}, error) {
+	resp, err := c.DoRequest(ctx, http.MethodGet, "/identity/resources/configurations/v1", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identity configuration: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, bodyBytes, "failed to get identity configuration")
+	}
+
+	fields := map[string]interface{}{}
+	if err := json.NewDecoder(resp.Body).Decode(&fields); err != nil {
+		return nil, fmt.Errorf("failed to decode identity configuration response: %w", err)
+	}
+
+	return fields, nil
+}
+
+// ============================================================================
+// SMS Provider Methods
+// ============================================================================
+
+// SmsConfiguration represents the vendor's SMS provider used to deliver OTP codes.
+// The provider token is write-only and never returned by the API.
+type SmsConfiguration struct {
+	Provider   string `json:"provider"`
+	AccountID  string `json:"accountId"`
+	ServiceID  string `json:"serviceId"`
+	SenderName string `json:"senderName"`
+	Channel    string `json:"channel"`
+}
+
+// UpdateSmsConfigurationRequest represents the request to create/update the SMS provider
+type UpdateSmsConfigurationRequest struct {
+	Provider   string `json:"provider"`
+	AccountID  string `json:"accountId"`
+	Token      string `json:"token"`
+	ServiceID  string `json:"serviceId,omitempty"`
+	SenderName string `json:"senderName,omitempty"`
+	Channel    string `json:"channel,omitempty"`
+}
+
+// GetSmsConfiguration retrieves the vendor's SMS provider configuration. It returns ErrNotFound
+// when no SMS provider is configured.
+func (c *Client) GetSmsConfiguration(ctx context.Context) (*SmsConfiguration, error) {
+	tflog.Info(ctx, "Fetching SMS configuration")
+
+	resp, err := c.DoRequest(ctx, http.MethodGet, "/identity/resources/configurations/v1/sms", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SMS configuration: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, bodyBytes, "failed to get SMS configuration")
+	}
+
+	var config SmsConfiguration
+	if err := json.NewDecoder(resp.Body).Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to decode SMS configuration response: %w", err)
+	}
+
+	// An empty configuration means no SMS provider is configured
+	if config.AccountID == "" {
+		return nil, fmt.Errorf("SMS configuration: %w", ErrNotFound)
+	}
+
+	return &config, nil
+}
+
+// UpdateSmsConfiguration creates or updates the vendor's SMS provider configuration
+func (c *Client) UpdateSmsConfiguration(ctx context.Context, req UpdateSmsConfigurationRequest) (*SmsConfiguration, error) {
+	tflog.Info(ctx, "Updating SMS configuration", map[string]interface{}{
+		"provider":   req.Provider,
+		"account_id": req.AccountID,
+	})
+
+	resp, err := c.DoRequest(ctx, http.MethodPost, "/identity/resources/configurations/v1/sms", req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update SMS configuration: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, bodyBytes, "failed to update SMS configuration")
+	}
+
+	var config SmsConfiguration
+	if err := json.NewDecoder(resp.Body).Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to decode SMS configuration response: %w", err)
+	}
+
+	return &config, nil
+}
+
+// DeleteSmsConfiguration removes the vendor's SMS provider configuration
+func (c *Client) DeleteSmsConfiguration(ctx context.Context) error {
+	tflog.Info(ctx, "Deleting SMS configuration")
+
+	resp, err := c.DoRequest(ctx, http.MethodDelete, "/identity/resources/configurations/v1/sms", nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete SMS configuration: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, bodyBytes, "failed to delete SMS configuration")
+	}
+
+	return nil
+}
+
+// ============================================================================
+// Approval Flow Methods
+// ============================================================================
+
+// ApprovalFlow represents an approval flow referenced by policies with an APPROVAL_REQUIRED result
+type ApprovalFlow struct {
+	ID            string                    `json:"id"`
+	Name          string                    `json:"name"`
+	Description   string                    `json:"description"`
+	IsActive      bool                      `json:"isActive"`
+	Channels      ApprovalFlowChannels      `json:"channels"`
+	Configuration ApprovalFlowConfiguration `json:"configuration"`
+	Steps         []ApprovalFlowStep        `json:"steps"`
+}
+
+// ApprovalFlowChannels selects how approvers are notified
+type ApprovalFlowChannels struct {
+	Email bool `json:"email"`
+	Sms   bool `json:"sms"`
+}
+
+// ApprovalFlowConfiguration holds the timeouts and notifications of an approval flow
+type ApprovalFlowConfiguration struct {
+	AutoApproveInMinutes    int    `json:"autoApproveInMinutes,omitempty"`
+	ReminderIntervalMinutes int    `json:"reminderIntervalMinutes,omitempty"`
+	NotifyOnDecisions       *bool  `json:"notifyOnDecisions,omitempty"`
+	TimeoutMinutes          int    `json:"timeoutMinutes,omitempty"`
+	WebhookURL              string `json:"webhookUrl,omitempty"`
+}
+
+// ApprovalFlowStep is a single step of an approval flow. Steps run in ascending StepOrder.
+type ApprovalFlowStep struct {
+	StepOrder         int                    `json:"stepOrder"`
+	ApproverSelectors ApproverSelectors      `json:"approverSelectors"`
+	Configuration     ApprovalFlowStepQuorum `json:"configuration"`
+}
+
+// ApproverSelectors selects the approvers of a step
+type ApproverSelectors struct {
+	RoleKeys     []string `json:"roleKeys"`
+	Emails       []string `json:"emails"`
+	PhoneNumbers []string `json:"phoneNumbers"`
+}
+
+// ApprovalFlowStepQuorum holds the number of approvals required to complete a step
+type ApprovalFlowStepQuorum struct {
+	MinApprovals int `json:"minApprovals"`
+}
+
+// ApprovalFlowRequest represents the request to create or update an approval flow
+type ApprovalFlowRequest struct {
+	Name          string                    `json:"name"`
+	Description   string                    `json:"description,omitempty"`
+	IsActive      bool                      `json:"isActive"`
+	Channels      ApprovalFlowChannels      `json:"channels"`
+	Configuration ApprovalFlowConfiguration `json:"configuration"`
+	Steps         []ApprovalFlowStep        `json:"steps"`
+}
+
+// CreateApprovalFlow creates a new approval flow
+func (c *Client) CreateApprovalFlow(ctx context.Context, req ApprovalFlowRequest) (*ApprovalFlow, error) {
+	tflog.Info(ctx, "Creating approval flow", map[string]interface{}{
+		"name":  req.Name,
+		"steps": len(req.Steps),
+	})
+
+	resp, err := c.DoRequest(ctx, http.MethodPost, "/identity/resources/approval-flows/v1", req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create approval flow: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, bodyBytes, "failed to create approval flow")
+	}
+
+	var flow ApprovalFlow
+	if err := json.NewDecoder(resp.Body).Decode(&flow); err != nil {
+		return nil, fmt.Errorf("failed to decode approval flow response: %w", err)
+	}
+
+	tflog.Info(ctx, "Successfully created approval flow", map[string]interface{}{
+		"id": flow.ID,
+	})
+
+	return &flow, nil
+}
+
+// GetApprovalFlow retrieves an approval flow by ID
+func (c *Client) GetApprovalFlow(ctx context.Context, id string) (*ApprovalFlow, error) {
+	tflog.Info(ctx, "Fetching approval flow", map[string]interface{}{
+		"id": id,
+	})
+
+	path := fmt.Sprintf("/identity/resources/approval-flows/v1/%s", id)
+	resp, err := c.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval flow: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, bodyBytes, "failed to get approval flow")
+	}
+
+	var flow ApprovalFlow
+	if err := json.NewDecoder(resp.Body).Decode(&flow); err != nil {
+		return nil, fmt.Errorf("failed to decode approval flow response: %w", err)
+	}
+
+	return &flow, nil
+}
+
+// UpdateApprovalFlow updates an existing approval flow. The steps replace the current steps.
+func (c *Client) UpdateApprovalFlow(ctx context.Context, id string, req ApprovalFlowRequest) (*ApprovalFlow, error) {
+	tflog.Info(ctx, "Updating approval flow", map[string]interface{}{
+		"id":    id,
+		"steps": len(req.Steps),
+	})
+
+	path := fmt.Sprintf("/identity/resources/approval-flows/v1/%s", id)
+	resp, err := c.DoRequest(ctx, http.MethodPatch, path, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update approval flow: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, bodyBytes, "failed to update approval flow")
+	}
+
+	var flow ApprovalFlow
+	if err := json.NewDecoder(resp.Body).Decode(&flow); err != nil {
+		return nil, fmt.Errorf("failed to decode approval flow response: %w", err)
+	}
+
+	return &flow, nil
+}
+
+// DeleteApprovalFlow deletes an approval flow
+func (c *Client) DeleteApprovalFlow(ctx context.Context, id string) error {
+	tflog.Info(ctx, "Deleting approval flow", map[string]interface{}{
+		"id": id,
+	})
+
+	path := fmt.Sprintf("/identity/resources/approval-flows/v1/%s", id)
+	resp, err := c.DoRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete approval flow: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, bodyBytes, "failed to delete approval flow")
+	}
+
+	return nil
+}
+
+// ============================================================================
+// Application Cloning Methods
+// ============================================================================
+
+// CloneApplication copies the sources, tools, MCP configuration and policies of a template app
+// into another app. Tools are upserted in one batch per source. Policies applying to the template
+// are copied into policies applying only to the target app, scoped to the cloned tools instead of
+// the template tools. Source secrets are not returned by the API and are not copied.
+func (c *Client) CloneApplication(ctx context.Context, fromAppID, toAppID string) error {
+	tflog.Info(ctx, "Cloning application", map[string]interface{}{
+		"from_app_id": fromAppID,
+		"to_app_id":   toAppID,
+	})
+
+	toolIDs, err := c.cloneSources(ctx, fromAppID, toAppID)
+	if err != nil {
+		return err
+	}
+
+	config, err := c.GetMcpConfiguration(ctx, fromAppID)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	if err == nil {
+		_, err = c.UpdateMcpConfiguration(ctx, CreateOrUpdateMcpConfigurationRequest{
+			AppID:      toAppID,
+			BaseURL:    config.BaseURL,
+			APITimeout: config.APITimeout,
+		}, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	policies, err := c.ListPolicies(ctx, PolicyFilters{AppID: fromAppID})
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		if err := c.clonePolicy(ctx, policy, toAppID, toolIDs); err != nil {
+			return err
+		}
+	}
+
+	tflog.Info(ctx, "Successfully cloned application", map[string]interface{}{
+		"to_app_id": toAppID,
+		"tools":     len(toolIDs),
+		"policies":  len(policies),
+	})
+
+	return nil
+}
+
+// cloneSources copies the sources of an app and their tools into another app. It returns the
+// cloned tool IDs keyed by the ID of the template tool.
+func (c *Client) cloneSources(ctx context.Context, fromAppID, toAppID string) (map[string]string, error) {
+	sources, err := c.GetSources(ctx, fromAppID)
+	if err != nil {
+		return nil, err
+	}
+
+	toolIDs := map[string]string{}
+	for _, src := range sources {
+		created, err := c.CreateSource(ctx, CreateSourceRequest{
+			AppID:      toAppID,
+			Name:       src.Name,
+			Type:       src.Type,
+			SourceURL:  src.SourceURL,
+			APITimeout: src.APITimeout,
+			Enabled:    src.Enabled,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		tools, err := c.GetToolsBySource(ctx, fromAppID, src.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(tools) == 0 {
+			continue
+		}
+
+		templateIDs := make(map[string]string, len(tools))
+		clones := make([]InternalTool, 0, len(tools))
+		for _, tool := range tools {
+			templateIDs[tool.Name] = tool.ID
+			tool.ID = ""
+			tool.VendorID = ""
+			tool.AppID = toAppID
+			tool.SourceID = created.ID
+			tool.CreatedAt = ""
+			tool.UpdatedAt = ""
+			clones = append(clones, tool)
+		}
+
+		upserted, err := c.UpsertTools(ctx, UpsertToolsRequest{AppID: toAppID, ToolType: created.Type, Tools: clones})
+		if err != nil {
+			return nil, err
+		}
+		for _, tool := range upserted {
+			if templateID, ok := templateIDs[tool.Name]; ok {
+				toolIDs[templateID] = tool.ID
+			}
+		}
+	}
+
+	return toolIDs, nil
+}
+
+// policyCollectionPaths maps policy types to the path policies of the type are created at
+var policyCollectionPaths = map[string]string{
+	"CONDITIONAL":      "/app-integrations/resources/policies/v1",
+	"RBAC_ROLES":       "/app-integrations/resources/policies/v1/rbac",
+	"RBAC_PERMISSIONS": "/app-integrations/resources/policies/v1/rbac",
+	"MASKING":          "/app-integrations/resources/policies/v1/masking",
+}
+
+// policyItemPath returns the path a policy of the given type is updated at
+func policyItemPath(id, policyType string) (string, error) {
+	collection, ok := policyCollectionPaths[policyType]
+	if !ok {
+		return "", fmt.Errorf("failed to update policy %s: unsupported policy type %q", id, policyType)
+	}
+	return fmt.Sprintf("%s/%s", collection, id), nil
+}
+
+// clonePolicy creates a copy of a template policy applying only to an app. Template tools the
+// policy is scoped to are replaced with their clones. The template policy is left unchanged, so
+// Terraform resources managing it see no drift.
+func (c *Client) clonePolicy(ctx context.Context, policy Policy, toAppID string, toolIDs map[string]string) error {
+	path, ok := policyCollectionPaths[policy.Type]
+	if !ok {
+		return fmt.Errorf("failed to clone policy %s: unsupported policy type %q", policy.ID, policy.Type)
+	}
+
+	encoded, err := json.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("failed to encode policy: %w", err)
+	}
+	var copied map[string]interface{}
+	if err := json.Unmarshal(encoded, &copied); err != nil {
+		return fmt.Errorf("failed to encode policy: %w", err)
+	}
+	for _, key := range []string{"id", "vendorId", "createdAt", "updatedAt"} {
+		delete(copied, key)
+	}
+	copied["appIds"] = []string{toAppID}
+
+	if len(policy.InternalToolIDs) > 0 {
+		internalToolIDs := make([]string, 0, len(policy.InternalToolIDs))
+		for _, id := range policy.InternalToolIDs {
+			if cloneID, ok := toolIDs[id]; ok {
+				id = cloneID
+			}
+			internalToolIDs = append(internalToolIDs, id)
+		}
+		copied["internalToolIds"] = internalToolIDs
+	}
+
+	tflog.Info(ctx, "Copying policy to cloned application", map[string]interface{}{
+		"id":        policy.ID,
+		"to_app_id": toAppID,
+	})
+
+	resp, err := c.DoRequest(ctx, http.MethodPost, path, copied)
+	if err != nil {
+		return fmt.Errorf("failed to create policy: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, bodyBytes, "failed to create policy")
+	}
+
+	return nil
+}
+
+// ============================================================================
+// Internal Tool Hook Methods
+// ============================================================================
+
+// Internal tool hook types
+const (
+	ToolHookTypeListTools = "LIST_TOOLS"
+	ToolHookTypeCallTool  = "CALL_TOOL"
+)
+
+// InternalToolHook represents code run by Frontegg when agents list or call the tools of an app.
+// An app has at most one hook of each type.
+type InternalToolHook struct {
+	ID              string                 `json:"id"`
+	HookType        string                 `json:"hookType"`
+	InternalToolIDs []string               `json:"internalToolIds,omitempty"`
+	Prehook         map[string]interface{} `json:"prehook,omitempty"`
+}
+
+// CreateInternalToolHookRequest represents the request to create an internal tool hook
+type CreateInternalToolHookRequest struct {
+	AppID           string   `json:"appId"`
+	IsActive        bool     `json:"isActive"`
+	HookType        string   `json:"hookType"`
+	Code            string   `json:"code"`
+	Runtime         string   `json:"runtime"`
+	FailMethod      string   `json:"failMethod"`
+	Timeout         int      `json:"timeout,omitempty"`
+	InternalToolIDs []string `json:"internalToolIds,omitempty"`
+}
+
+// UpdateInternalToolHookRequest represents the request to update an internal tool hook. The hook is
+// identified by its app and type.
+type UpdateInternalToolHookRequest struct {
+	AppID           string   `json:"appId"`
+	HookType        string   `json:"hookType"`
+	IsActive        bool     `json:"isActive"`
+	Code            string   `json:"code,omitempty"`
+	FailMethod      string   `json:"failMethod,omitempty"`
+	Timeout         int      `json:"timeout,omitempty"`
+	InternalToolIDs []string `json:"internalToolIds"`
+}
+
+// CreateInternalToolHook creates an internal tool hook for an app
+func (c *Client) CreateInternalToolHook(ctx context.Context, req CreateInternalToolHookRequest) (*InternalToolHook, error) {
+	tflog.Info(ctx, "Creating internal tool hook", map[string]interface{}{
+		"app_id":    req.AppID,
+		"hook_type": req.HookType,
+	})
+
+	resp, err := c.DoRequest(ctx, http.MethodPost, "/app-integrations/resources/internal-tool-hooks/v1", req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create internal tool hook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, bodyBytes, "failed to create internal tool hook")
+	}
+
+	var hook InternalToolHook
+	if err := json.NewDecoder(resp.Body).Decode(&hook); err != nil {
+		return nil, fmt.Errorf("failed to decode internal tool hook response: %w", err)
+	}
+
+	return &hook, nil
+}
+
+// GetInternalToolHooks retrieves the internal tool hooks of an app
+func (c *Client) GetInternalToolHooks(ctx context.Context, appID string) ([]InternalToolHook, error) {
+	tflog.Info(ctx, "Fetching internal tool hooks", map[string]interface{}{
+		"app_id": appID,
+	})
+
+	path := fmt.Sprintf("/app-integrations/resources/internal-tool-hooks/v1?appId=%s", url.QueryEscape(appID))
+	resp, err := c.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get internal tool hooks: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, bodyBytes, "failed to get internal tool hooks")
+	}
+
+	var hooks []InternalToolHook
+	if err := json.NewDecoder(resp.Body).Decode(&hooks); err != nil {
+		return nil, fmt.Errorf("failed to decode internal tool hooks response: %w", err)
+	}
+
+	return hooks, nil
+}
+
+// GetInternalToolHook retrieves the hook of an app with the given type. It returns ErrNotFound if
+// the app has no hook of the type.
+func (c *Client) GetInternalToolHook(ctx context.Context, appID, hookType string) (*InternalToolHook, error) {
+	hooks, err := c.GetInternalToolHooks(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hook := range hooks {
+		if hook.HookType == hookType {
+			return &hook, nil
+		}
+	}
+
+	return nil, fmt.Errorf("internal tool hook %s of app %s: %w", hookType, appID, ErrNotFound)
+}
+
+// UpdateInternalToolHook updates the hook of an app with the type of the request
+func (c *Client) UpdateInternalToolHook(ctx context.Context, req UpdateInternalToolHookRequest) (*InternalToolHook, error) {
+	tflog.Info(ctx, "Updating internal tool hook", map[string]interface{}{
+		"app_id":    req.AppID,
+		"hook_type": req.HookType,
+	})
+
+	resp, err := c.DoRequest(ctx, http.MethodPatch, "/app-integrations/resources/internal-tool-hooks/v1", req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update internal tool hook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, bodyBytes, "failed to update internal tool hook")
+	}
+
+	var hook InternalToolHook
+	if err := json.NewDecoder(resp.Body).Decode(&hook); err != nil {
+		return nil, fmt.Errorf("failed to decode internal tool hook response: %w", err)
+	}
+
+	return &hook, nil
+}
+
+// DeleteInternalToolHook deletes the hook of an app with the given type
+func (c *Client) DeleteInternalToolHook(ctx context.Context, appID, hookType string) error {
+	tflog.Info(ctx, "Deleting internal tool hook", map[string]interface{}{
+		"app_id":    appID,
+		"hook_type": hookType,
+	})
+
+	path := fmt.Sprintf("/app-integrations/resources/internal-tool-hooks/v1?appId=%s&hookType=%s", url.QueryEscape(appID), url.QueryEscape(hookType))
+	resp, err := c.DoRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete internal tool hook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, bodyBytes, "failed to delete internal tool hook")
+	}
+
+	return nil
+}
+
+// ============================================================================
+// Analytics Methods
+// ============================================================================
+
+// Tool usage intervals supported by the MCP gateway analytics API
+const (
+	UsageIntervalToday      = "today"
+	UsageIntervalLast7Days  = "last-7-days"
+	UsageIntervalLast30Days = "last-30-days"
+)
+
+// ToolUsage represents the number of invocations of a tool over an interval
+type ToolUsage struct {
+	ToolName string `json:"toolName"`
+	Count    int64  `json:"count"`
+}
+
+// GetToolUsage retrieves the invocation counts of the tools used over the interval.
+// Tools that were not invoked during the interval are not returned.
+func (c *Client) GetToolUsage(ctx context.Context, interval string) ([]ToolUsage, error) {
+	tflog.Info(ctx, "Getting tool usage", map[string]interface{}{
+		"interval": interval,
+	})
+
+	path := fmt.Sprintf("/app-integrations/resources/mcp-gw-analytics/v1/top-tools?interval=%s", interval)
+	resp, err := c.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tool usage: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, bodyBytes, "failed to get tool usage")
+	}
+
+	var usage []ToolUsage
+	if err := json.NewDecoder(resp.Body).Decode(&usage); err != nil {
+		return nil, fmt.Errorf("failed to decode tool usage response: %w", err)
+	}
+
+	return usage, nil
+}
@@ -1,11 +1,24 @@
 package client
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestNewClient(t *testing.T) {
@@ -77,6 +90,95 @@ func TestAuthenticateFailure(t *testing.T) {
 	}
 }
 
+func TestAuthenticateRetriesTransientFailures(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(AuthResponse{Token: "test-token", ExpiresIn: 3600})
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	c.authRetryDelay = time.Millisecond
+
+	if err := c.Authenticate(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestAuthenticateDoesNotRetryRejectedCredentials(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "bad-client", "bad-secret")
+	c.authRetryDelay = time.Millisecond
+
+	err := c.Authenticate(context.Background())
+	if !IsUnauthorized(err) {
+		t.Errorf("expected an unauthorized error, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestAuthenticateGivesUpAfterMaxAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	c.authRetryDelay = time.Millisecond
+
+	err := c.Authenticate(context.Background())
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !isTransientAuthError(err) {
+		t.Errorf("expected a connection error to be transient, got %v", err)
+	}
+}
+
+func TestGetAccessTokenAuthenticatesOnce(t *testing.T) {
+	var mu sync.Mutex
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		mu.Unlock()
+		_ = json.NewEncoder(w).Encode(AuthResponse{Token: "test-token", ExpiresIn: 3600})
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetAccessToken(context.Background()); err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if attempts != 1 {
+		t.Errorf("expected 1 authentication, got %d", attempts)
+	}
+}
+
 func TestGetApplications(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -198,8 +300,8 @@ func TestGetApplicationByIDNotFound(t *testing.T) {
 	c := NewClient(server.URL, "client", "secret")
 	app, err := c.GetApplicationByID(context.Background(), "nonexistent")
 
-	if err != nil {
-		t.Fatalf("expected no error for not found, got %v", err)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
 	}
 	if app != nil {
 		t.Errorf("expected nil app, got %+v", app)
@@ -266,6 +368,40 @@ func TestDeleteApplication(t *testing.T) {
 	}
 }
 
+func TestUploadApplicationLogo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/vendors/assets":
+			if r.Method != http.MethodPost {
+				t.Errorf("expected POST, got %s", r.Method)
+			}
+			var req UploadAssetRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			content, _ := base64.StdEncoding.DecodeString(req.Asset)
+			if req.AssetName != "logo.png" || string(content) != "png-bytes" {
+				t.Errorf("unexpected upload %q with content %q", req.AssetName, content)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode("https://assets.example.com/logo.png")
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	url, err := c.UploadApplicationLogo(context.Background(), []byte("png-bytes"), "logo.png")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if url != "https://assets.example.com/logo.png" {
+		t.Errorf("expected the uploaded logo URL, got %q", url)
+	}
+}
+
 func TestGetSources(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -297,6 +433,51 @@ func TestGetSources(t *testing.T) {
 	}
 }
 
+func TestGetSourceByID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/app-integrations/resources/app-mcp-configuration-sources/v1":
+			if r.Method != http.MethodGet {
+				t.Errorf("expected GET, got %s", r.Method)
+			}
+			sources := []Source{}
+			switch r.URL.Query().Get("appId") {
+			case "app-123":
+				sources = append(sources, Source{ID: "src-1", AppID: "app-123", Name: "Source One", Enabled: false})
+			case "app-789":
+				// A source without its application must not be attributed to the requested one
+				sources = append(sources, Source{ID: "src-3", Name: "Source Three"})
+			}
+			_ = json.NewEncoder(w).Encode(sources)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+
+	source, err := c.GetSourceByID(context.Background(), "app-123", "src-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if source.ID != "src-1" || source.Enabled {
+		t.Errorf("expected disabled source 'src-1', got %+v", source)
+	}
+
+	if _, err := c.GetSourceByID(context.Background(), "app-456", "src-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a source of another application, got %v", err)
+	}
+	if _, err := c.GetSourceByID(context.Background(), "app-123", "src-2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a deleted source, got %v", err)
+	}
+	if _, err := c.GetSourceByID(context.Background(), "app-789", "src-3"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a source without an application, got %v", err)
+	}
+}
+
 func TestCreateSource(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -446,6 +627,75 @@ func TestCreateOrUpdateMcpConfiguration(t *testing.T) {
 	}
 }
 
+func TestUpdateMcpConfigurationRetriesConcurrentWrites(t *testing.T) {
+	posts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case r.Method == http.MethodGet:
+			w.Header().Set("ETag", fmt.Sprintf(`"v%d"`, posts+1))
+			_ = json.NewEncoder(w).Encode(McpConfiguration{AppID: "app-123", BaseURL: "https://old.example.com", APITimeout: 3000})
+		case r.Method == http.MethodPost:
+			posts++
+			if got := r.Header.Get("If-Match"); got != fmt.Sprintf(`"v%d"`, posts) {
+				t.Errorf("expected If-Match with the ETag of the latest read, got %q", got)
+			}
+			if posts == 1 {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(McpConfiguration{ID: "mcp-config-id", AppID: "app-123", BaseURL: "https://new.example.com", APITimeout: 3000})
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	c.conflictRetryDelay = time.Millisecond
+	expected := &McpConfiguration{BaseURL: "https://old.example.com", APITimeout: 3000}
+	config, err := c.UpdateMcpConfiguration(context.Background(), CreateOrUpdateMcpConfigurationRequest{
+		AppID:      "app-123",
+		BaseURL:    "https://new.example.com",
+		APITimeout: 3000,
+	}, expected)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if posts != 2 || config.BaseURL != "https://new.example.com" {
+		t.Errorf("expected the write to succeed on the second attempt, got %d attempts and %+v", posts, config)
+	}
+}
+
+func TestUpdateMcpConfigurationDetectsConcurrentChange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(McpConfiguration{AppID: "app-123", BaseURL: "https://other.example.com", APITimeout: 3000})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	_, err := c.UpdateMcpConfiguration(context.Background(), CreateOrUpdateMcpConfigurationRequest{
+		AppID:      "app-123",
+		BaseURL:    "https://new.example.com",
+		APITimeout: 3000,
+	}, &McpConfiguration{BaseURL: "https://old.example.com", APITimeout: 3000})
+
+	var conflict *McpConfigurationConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("expected a conflict error, got %v", err)
+	}
+	if conflict.Current.BaseURL != "https://other.example.com" {
+		t.Errorf("expected the current configuration in the error, got %+v", conflict.Current)
+	}
+}
+
 func TestGetMcpConfiguration(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -478,6 +728,207 @@ func TestGetMcpConfiguration(t *testing.T) {
 	}
 }
 
+func TestGetIdentityConfiguration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/identity/resources/configurations/v1":
+			if r.Method != http.MethodGet {
+				t.Errorf("expected GET, got %s", r.Method)
+			}
+			if body, _ := io.ReadAll(r.Body); len(body) != 0 {
+				t.Errorf("expected no request body, got %s", body)
+			}
+			_ = json.NewEncoder(w).Encode(IdentityConfiguration{
+				ID:                     "config-id",
+				DefaultTokenExpiration: 300,
+			})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	config, err := c.GetIdentityConfiguration(context.Background())
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if config.DefaultTokenExpiration != 300 {
+		t.Errorf("expected token expiration 300, got %d", config.DefaultTokenExpiration)
+	}
+}
+
+func TestUpdateIdentityConfiguration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/identity/resources/configurations/v1":
+			if r.Method == http.MethodGet {
+				_, _ = w.Write([]byte(`{"id":"config-id","publicKey":"public-key","defaultTokenExpiration":60,"refreshTokensRotationLimit":2,"cookieSameSite":"STRICT"}`))
+				return
+			}
+			if r.Method != http.MethodPost {
+				t.Errorf("expected POST, got %s", r.Method)
+			}
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+
+			if body["defaultTokenExpiration"] != float64(300) {
+				t.Errorf("expected defaultTokenExpiration 300, got %v", body["defaultTokenExpiration"])
+			}
+			if body["cookieSameSite"] != "STRICT" {
+				t.Errorf("expected unmanaged cookieSameSite to be kept, got %v", body["cookieSameSite"])
+			}
+			if body["refreshTokensRotationLimit"] != float64(2) {
+				t.Errorf("expected current refreshTokensRotationLimit 2 to be kept, got %v", body["refreshTokensRotationLimit"])
+			}
+			if _, ok := body["publicKey"]; ok {
+				t.Error("expected read-only publicKey to be omitted")
+			}
+			if body["defaultRefreshTokenExpiration"] != float64(86400) {
+				t.Errorf("expected defaultRefreshTokenExpiration 86400, got %v", body["defaultRefreshTokenExpiration"])
+			}
+			if body["rotateRefreshTokens"] != false {
+				t.Errorf("expected rotateRefreshTokens false, got %v", body["rotateRefreshTokens"])
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(IdentityConfiguration{
+				ID:                            "config-id",
+				DefaultTokenExpiration:        300,
+				DefaultRefreshTokenExpiration: 86400,
+				RefreshTokensRotationLimit:    2,
+			})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	tokenExpiration := 300
+	refreshExpiration := 86400
+	rotate := false
+
+	c := NewClient(server.URL, "client", "secret")
+	config, err := c.UpdateIdentityConfiguration(context.Background(), UpdateIdentityConfigurationRequest{
+		DefaultTokenExpiration:        &tokenExpiration,
+		DefaultRefreshTokenExpiration: &refreshExpiration,
+		RotateRefreshTokens:           &rotate,
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if config.DefaultRefreshTokenExpiration != 86400 {
+		t.Errorf("expected refresh token expiration 86400, got %d", config.DefaultRefreshTokenExpiration)
+	}
+	if config.RefreshTokensRotationLimit != 2 {
+		t.Errorf("expected rotation limit 2, got %d", config.RefreshTokensRotationLimit)
+	}
+}
+
+func TestUpdateVendorSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/vendors":
+			if r.Method != http.MethodPut {
+				t.Errorf("expected PUT, got %s", r.Method)
+			}
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+
+			if _, ok := body["allowedOrigins"]; ok {
+				t.Error("expected allowedOrigins to be omitted")
+			}
+			if _, ok := body["timezone"]; ok {
+				t.Error("expected unset timezone to be omitted")
+			}
+			if body["supportEmail"] != "support@example.com" {
+				t.Errorf("expected supportEmail 'support@example.com', got %v", body["supportEmail"])
+			}
+
+			_ = json.NewEncoder(w).Encode(VendorConfig{
+				ID:           "vendor-123",
+				Name:         "Acme",
+				SupportEmail: "support@example.com",
+				Timezone:     "UTC",
+			})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	config, err := c.UpdateVendorSettings(context.Background(), UpdateVendorSettingsRequest{
+		Name:         "Acme",
+		SupportEmail: "support@example.com",
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if config.ID != "vendor-123" {
+		t.Errorf("expected ID 'vendor-123', got '%s'", config.ID)
+	}
+	if config.Timezone != "UTC" {
+		t.Errorf("expected timezone 'UTC', got '%s'", config.Timezone)
+	}
+}
+
+func TestGetVendorConfig_Cached(t *testing.T) {
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/vendors":
+			if r.Method == http.MethodGet {
+				fetches++
+			}
+			_ = json.NewEncoder(w).Encode(VendorConfig{ID: "vendor-123", AllowedOrigins: []string{"https://a.example.com"}})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	ctx := context.Background()
+
+	first, err := c.GetVendorConfig(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	first.AllowedOrigins[0] = "mutated"
+
+	second, err := c.GetVendorConfig(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if fetches != 1 {
+		t.Errorf("expected 1 fetch, got %d", fetches)
+	}
+	if second.AllowedOrigins[0] != "https://a.example.com" {
+		t.Errorf("expected cached config to be unaffected by callers, got %v", second.AllowedOrigins)
+	}
+
+	if _, err := c.UpdateAllowedOrigins(ctx, []string{"https://b.example.com"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := c.GetVendorConfig(ctx); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if fetches != 2 {
+		t.Errorf("expected update to invalidate the cache, got %d fetches", fetches)
+	}
+}
+
 func TestCreateConditionalPolicy(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -557,6 +1008,9 @@ func TestCreateRbacPolicy(t *testing.T) {
 			}
 			var req CreateRbacPolicyRequest
 			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.Targeting == nil || len(req.Targeting.If.Conditions) != 1 || req.Targeting.Then.Result != "ALLOW" {
+				t.Errorf("expected targeting in request, got %+v", req.Targeting)
+			}
 
 			w.WriteHeader(http.StatusCreated)
 			_ = json.NewEncoder(w).Encode(map[string]string{"id": "rbac-policy-123"})
@@ -581,6 +1035,14 @@ func TestCreateRbacPolicy(t *testing.T) {
 		Type:            "RBAC_ROLES",
 		Keys:            []string{"admin"},
 		InternalToolIDs: []string{"tool-1"},
+		Targeting: &PolicyTargeting{
+			If: PolicyIfBlock{Conditions: []PolicyCondition{{
+				Attribute: "tenant.environment",
+				Op:        "equals",
+				Value:     map[string]interface{}{"string": "production"},
+			}}},
+			Then: PolicyThenBlock{Result: "ALLOW"},
+		},
 	})
 
 	if err != nil {
@@ -635,6 +1097,10 @@ func TestCreateMaskingPolicy(t *testing.T) {
 			if !req.PolicyConfiguration.CreditCard {
 				t.Error("expected credit card masking to be enabled")
 			}
+			// Masking every character is sent explicitly
+			if format := req.PolicyConfiguration.EntityFormats["creditCard"]; format.MaskChar != "#" || format.VisibleCharsCount == nil || *format.VisibleCharsCount != 0 {
+				t.Errorf("expected creditCard format {# 0}, got %+v", format)
+			}
 
 			w.WriteHeader(http.StatusCreated)
 			_ = json.NewEncoder(w).Encode(map[string]string{"id": "masking-policy-123"})
@@ -652,6 +1118,7 @@ func TestCreateMaskingPolicy(t *testing.T) {
 	defer server.Close()
 
 	c := NewClient(server.URL, "client", "secret")
+	visibleChars := 0
 	policy, err := c.CreateMaskingPolicy(context.Background(), CreateMaskingPolicyRequest{
 		Name:            "PII Masking",
 		Enabled:         true,
@@ -659,6 +1126,9 @@ func TestCreateMaskingPolicy(t *testing.T) {
 		PolicyConfiguration: &MaskingPolicyConfiguration{
 			CreditCard:   true,
 			EmailAddress: true,
+			EntityFormats: map[string]MaskingEntityFormat{
+				"creditCard": {MaskChar: "#", VisibleCharsCount: &visibleChars},
+			},
 		},
 	})
 
@@ -730,13 +1200,88 @@ func TestDeletePolicy(t *testing.T) {
 	}
 }
 
-func TestUpsertTools(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		switch r.URL.Path {
-		case "/auth/vendor":
-			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
-		case "/app-integrations/resources/internal-tools/v1/upsert":
-			if r.Method != http.MethodPost {
+func TestPolicyConditionMarshalsNativeValues(t *testing.T) {
+	condition := PolicyCondition{
+		Attribute: "hour",
+		Op:        "in_range",
+		Value: map[string]interface{}{
+			"number":  "42",
+			"start":   "9",
+			"end":     "17.5",
+			"boolean": "true",
+			"string":  "42",
+			"list":    "1,2",
+			"date":    "2025",
+		},
+	}
+
+	body, err := json.Marshal(condition)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var decoded struct {
+		Value map[string]interface{} `json:"value"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Values are typed by key, so a year under date stays a string
+	expected := map[string]interface{}{
+		"number":  float64(42),
+		"start":   float64(9),
+		"end":     17.5,
+		"boolean": true,
+		"string":  "42",
+		"list":    "1,2",
+		"date":    "2025",
+	}
+	for k, v := range expected {
+		if decoded.Value[k] != v {
+			t.Errorf("expected value %q to be %#v, got %#v", k, v, decoded.Value[k])
+		}
+	}
+}
+
+func TestPolicyConditionKeepsInvalidTypedValues(t *testing.T) {
+	condition := PolicyCondition{
+		Attribute: "hour",
+		Op:        "equals",
+		Value: map[string]interface{}{
+			"number":  "nine",
+			"boolean": "yes",
+			"date":    "true",
+		},
+	}
+
+	body, err := json.Marshal(condition)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var decoded struct {
+		Value map[string]interface{} `json:"value"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Invalid values are sent as configured, so the API reports them
+	for k, v := range condition.Value {
+		if decoded.Value[k] != v {
+			t.Errorf("expected value %q to be %#v, got %#v", k, v, decoded.Value[k])
+		}
+	}
+}
+
+func TestUpsertTools(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/app-integrations/resources/internal-tools/v1/upsert":
+			if r.Method != http.MethodPost {
 				t.Errorf("expected POST, got %s", r.Method)
 			}
 			var req UpsertToolsRequest
@@ -774,6 +1319,208 @@ func TestUpsertTools(t *testing.T) {
 	}
 }
 
+func TestImportSchemaCompressesLargeUploads(t *testing.T) {
+	var encoding string
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/app-integrations/resources/internal-tools/v1/openapi/import":
+			encoding = r.Header.Get("Content-Encoding")
+			if encoding == "gzip" {
+				gz, err := gzip.NewReader(r.Body)
+				if err != nil {
+					t.Fatalf("expected a gzip body, got %v", err)
+				}
+				r.Body = gz
+			}
+			file, _, err := r.FormFile("openapi")
+			if err != nil {
+				t.Fatalf("expected a schema file, got %v", err)
+			}
+			received, _ = io.ReadAll(file)
+			_ = json.NewEncoder(w).Encode([]InternalTool{})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+
+	small := []byte(`{"openapi":"3.0.0"}`)
+	if _, err := c.ImportOpenAPISchema(context.Background(), "app-123", small, "openapi.json"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if encoding != "" || !bytes.Equal(received, small) {
+		t.Errorf("expected small schemas to be sent uncompressed, got encoding %q", encoding)
+	}
+
+	large := bytes.Repeat([]byte(`{"openapi":"3.0.0"}`), schemaUploadGzipThreshold/10)
+	if _, err := c.ImportOpenAPISchema(context.Background(), "app-123", large, "openapi.json"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if encoding != "gzip" || !bytes.Equal(received, large) {
+		t.Errorf("expected large schemas to be gzipped, got encoding %q and %d bytes", encoding, len(received))
+	}
+}
+
+func TestImportAndUpsertSchemaNamingStrategy(t *testing.T) {
+	var upserted []InternalTool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/app-integrations/resources/internal-tools/v1/openapi/import":
+			_ = json.NewEncoder(w).Encode([]InternalTool{
+				{Name: "listUsers", Description: "List users\nReturns all users.", OriginalMethod: "GET", OriginalPath: "/users/{id}"},
+				{Name: "createUser", Description: "", OriginalMethod: "POST", OriginalPath: "/users"},
+			})
+		case "/app-integrations/resources/internal-tools/v1/upsert":
+			var req UpsertToolsRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			upserted = req.Tools
+			_ = json.NewEncoder(w).Encode(req.Tools)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		opts     ImportOptions
+		expected []string
+	}{
+		{ImportOptions{NamingStrategy: NamingStrategyOperationID}, []string{"listUsers", "createUser"}},
+		{ImportOptions{NamingStrategy: NamingStrategyMethodPath}, []string{"get_users_id", "post_users"}},
+		{ImportOptions{NamingStrategy: NamingStrategySummarySlug}, []string{"list_users", "createUser"}},
+		{ImportOptions{NamePrefix: "crm_", NameSuffix: "_v1"}, []string{"crm_listUsers_v1", "crm_createUser_v1"}},
+	}
+
+	c := NewClient(server.URL, "client", "secret")
+	for _, tt := range tests {
+		err := c.ImportAndUpsertSchema(context.Background(), "app-123", "source-123", "REST", []byte("{}"), "openapi.json", tt.opts)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(upserted) != len(tt.expected) {
+			t.Fatalf("expected %d tools, got %d", len(tt.expected), len(upserted))
+		}
+		for i, name := range tt.expected {
+			if upserted[i].Name != name {
+				t.Errorf("strategy %q: expected name '%s', got '%s'", tt.opts.NamingStrategy, name, upserted[i].Name)
+			}
+			if upserted[i].SourceID != "source-123" {
+				t.Errorf("expected sourceId 'source-123', got '%s'", upserted[i].SourceID)
+			}
+		}
+	}
+}
+
+func TestImportAndUpsertSchemaTags(t *testing.T) {
+	var upserted []InternalTool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/app-integrations/resources/internal-tools/v1/graphql/import":
+			_ = json.NewEncoder(w).Encode([]InternalTool{{Name: "users"}, {Name: "orders"}})
+		case "/app-integrations/resources/internal-tools/v1/upsert":
+			var req UpsertToolsRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			upserted = req.Tools
+			_ = json.NewEncoder(w).Encode(req.Tools)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	err := c.ImportAndUpsertSchema(context.Background(), "app-123", "source-123", "GRAPHQL", []byte("type Query"), "schema.graphql", ImportOptions{
+		Tags: []string{"crm", "pii"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(upserted) != 2 {
+		t.Fatalf("expected 2 tools, got %d", len(upserted))
+	}
+	for _, tool := range upserted {
+		if len(tool.Tags) != 2 || tool.Tags[0] != "crm" || tool.Tags[1] != "pii" {
+			t.Errorf("expected tags [crm pii] on %s, got %v", tool.Name, tool.Tags)
+		}
+	}
+}
+
+func TestImportAndUpsertSchemaDescriptions(t *testing.T) {
+	schema := `
+openapi: 3.0.0
+paths:
+  /users/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        description: The user ID.
+    get:
+      summary: Get a user
+      description: Returns a single user by ID.
+      parameters:
+        - name: expand
+          in: query
+`
+
+	var upserted []InternalTool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/app-integrations/resources/internal-tools/v1/openapi/import":
+			_ = json.NewEncoder(w).Encode([]InternalTool{
+				{Name: "getUser", Description: "server description", OriginalMethod: "get", OriginalPath: "/users/{id}"},
+			})
+		case "/app-integrations/resources/internal-tools/v1/upsert":
+			var req UpsertToolsRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			upserted = req.Tools
+			_ = json.NewEncoder(w).Encode(req.Tools)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		opts     ImportOptions
+		expected string
+	}{
+		{ImportOptions{}, "server description"},
+		{ImportOptions{Description: &DescriptionOptions{IncludeSummary: true}}, "Get a user"},
+		{
+			ImportOptions{Description: &DescriptionOptions{IncludeSummary: true, IncludeDescription: true, IncludeParameterDocs: true}},
+			"Get a user\n\nReturns a single user by ID.\n\nParameters:\n- id (path, required): The user ID.\n- expand (query)",
+		},
+		{ImportOptions{Description: &DescriptionOptions{IncludeDescription: true}, MaxDescriptionLength: 12}, "Returns a..."},
+	}
+
+	c := NewClient(server.URL, "client", "secret")
+	for _, tt := range tests {
+		err := c.ImportAndUpsertSchema(context.Background(), "app-123", "source-123", "REST", []byte(schema), "openapi.yaml", tt.opts)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(upserted) != 1 {
+			t.Fatalf("expected 1 tool, got %d", len(upserted))
+		}
+		if upserted[0].Description != tt.expected {
+			t.Errorf("expected description %q, got %q", tt.expected, upserted[0].Description)
+		}
+	}
+}
+
 func TestDeleteTool(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -843,6 +1590,46 @@ func TestFindApplicationByName(t *testing.T) {
 	}
 }
 
+func TestFindApplicationByHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/applications/resources/applications/v1":
+			apps := []Application{
+				{ID: "app-1", Name: "No Host"},
+				{ID: "app-2", Name: "Staging", AppHost: "staging-agent.frontegg.com"},
+				{ID: "app-3", Name: "Production", AppHost: "agent.frontegg.com"},
+			}
+			_ = json.NewEncoder(w).Encode(apps)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+
+	for _, host := range []string{"agent.frontegg.com", "Agent.Frontegg.com", "https://agent.frontegg.com/mcp"} {
+		app, err := c.FindApplicationByHost(context.Background(), host)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if app == nil || app.ID != "app-3" {
+			t.Errorf("expected app-3 for host %q, got %+v", host, app)
+		}
+	}
+
+	// Applications without a host never match
+	app, err := c.FindApplicationByHost(context.Background(), "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if app != nil {
+		t.Errorf("expected nil, got %+v", app)
+	}
+}
+
 func TestFindSourceByName(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -873,3 +1660,981 @@ func TestFindSourceByName(t *testing.T) {
 		t.Errorf("expected ID 'src-2', got '%s'", source.ID)
 	}
 }
+
+func TestGetApplicationByID_ETag(t *testing.T) {
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/applications/resources/applications/v1/app-123":
+			fetches++
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			_ = json.NewEncoder(w).Encode(Application{ID: "app-123", Name: "Test App"})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+
+	for i := 0; i < 2; i++ {
+		app, err := c.GetApplicationByID(context.Background(), "app-123")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if app == nil || app.Name != "Test App" {
+			t.Errorf("expected cached application, got %+v", app)
+		}
+	}
+	if fetches != 2 {
+		t.Errorf("expected 2 requests, got %d", fetches)
+	}
+}
+
+func TestGetSources_ETag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/app-integrations/resources/app-mcp-configuration-sources/v1":
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			_ = json.NewEncoder(w).Encode([]Source{{ID: "src-1", Name: "API"}})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+
+	first, err := c.GetSources(context.Background(), "app-123")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	first[0].Name = "mutated"
+
+	second, err := c.GetSources(context.Background(), "app-123")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(second) != 1 || second[0].Name != "API" {
+		t.Errorf("expected cached sources unaffected by callers, got %+v", second)
+	}
+}
+
+func TestAPIError_IncludesRequestAndTraceID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		default:
+			w.Header().Set("frontegg-trace-id", "trace-abc")
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"message":"boom"}`))
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	_, err := c.GetSources(context.Background(), "app-123")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %T", err)
+	}
+	if apiErr.TraceID != "trace-abc" {
+		t.Errorf("expected trace ID 'trace-abc', got '%s'", apiErr.TraceID)
+	}
+
+	expected := `failed to get sources with status 500: boom (request: GET /app-integrations/resources/app-mcp-configuration-sources/v1?appId=app-123, frontegg-trace-id: trace-abc)`
+	if err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err.Error())
+	}
+}
+
+func TestParseErrorMessages(t *testing.T) {
+	tests := []struct {
+		body     string
+		expected []string
+	}{
+		{`{"message":"Application not found"}`, []string{"Application not found"}},
+		{`{"statusCode":400,"message":["name should not be empty","appURL must be a URL"],"error":"Bad Request"}`, []string{"name should not be empty", "appURL must be a URL"}},
+		{`{"errors":["Policy name already exists"]}`, []string{"Policy name already exists"}},
+		{`{"errors":[{"message":"Invalid scope"},{"code":"E1"}]}`, []string{"Invalid scope"}},
+		{`{"message":" "}`, nil},
+		{`upstream connect error`, nil},
+		{``, nil},
+	}
+
+	for _, tt := range tests {
+		if got := parseErrorMessages([]byte(tt.body)); !slices.Equal(got, tt.expected) {
+			t.Errorf("parseErrorMessages(%q) = %q, expected %q", tt.body, got, tt.expected)
+		}
+	}
+
+	// The raw body is reported when it has no messages
+	apiErr := &APIError{Action: "failed to get sources", StatusCode: http.StatusBadGateway, Body: "upstream connect error"}
+	if apiErr.Message() != "upstream connect error" {
+		t.Errorf("expected the raw body, got %q", apiErr.Message())
+	}
+}
+
+func TestUpdatePolicyRequestsSendNullTargeting(t *testing.T) {
+	// Removing the targeting of a policy must clear it, so unset targeting is sent as null
+	for _, req := range []interface{}{UpdateConditionalPolicyRequest{}, UpdateRbacPolicyRequest{}, UpdateMaskingPolicyRequest{}} {
+		body, err := json.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(body), `"targeting":null`) {
+			t.Errorf("expected %T to send null targeting, got %s", req, body)
+		}
+	}
+}
+
+func TestIsConflict(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		default:
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message":"Application with this name already exists"}`))
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	_, err := c.CreateApplication(context.Background(), CreateApplicationRequest{Name: "My Agent"})
+	if !IsConflict(err) {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+
+	if IsConflict(errors.New("failed to create application")) || IsConflict(nil) {
+		t.Error("expected non-API errors not to be conflicts")
+	}
+}
+
+func TestGetMaskingPolicies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/app-integrations/resources/policies/v1/masking":
+			if r.Method != http.MethodGet {
+				t.Errorf("expected GET, got %s", r.Method)
+			}
+			_ = json.NewEncoder(w).Encode([]Policy{{ID: "policy-1", Name: "Mask PII", Type: "MASKING"}})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	policies, err := c.GetMaskingPolicies(context.Background())
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(policies) != 1 || policies[0].ID != "policy-1" {
+		t.Errorf("expected one masking policy, got %+v", policies)
+	}
+}
+
+func TestUpdateSmsConfiguration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/identity/resources/configurations/v1/sms":
+			if r.Method != http.MethodPost {
+				t.Errorf("expected POST, got %s", r.Method)
+			}
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+
+			if body["token"] != "auth-token" {
+				t.Errorf("expected token 'auth-token', got %v", body["token"])
+			}
+			if _, ok := body["serviceId"]; ok {
+				t.Error("expected serviceId to be omitted")
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(SmsConfiguration{
+				Provider:   "twilio",
+				AccountID:  "AC123",
+				SenderName: "Acme",
+			})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	config, err := c.UpdateSmsConfiguration(context.Background(), UpdateSmsConfigurationRequest{
+		Provider:   "twilio",
+		AccountID:  "AC123",
+		Token:      "auth-token",
+		SenderName: "Acme",
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if config.SenderName != "Acme" {
+		t.Errorf("expected sender name 'Acme', got '%s'", config.SenderName)
+	}
+}
+
+func TestGetSmsConfigurationNotConfigured(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/identity/resources/configurations/v1/sms":
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	config, err := c.GetSmsConfiguration(context.Background())
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if config != nil {
+		t.Errorf("expected no SMS configuration, got %+v", config)
+	}
+}
+
+func TestSetPolicyEnabled(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/app-integrations/resources/policies/v1/policy-1",
+			"/app-integrations/resources/policies/v1/rbac/policy-2",
+			"/app-integrations/resources/policies/v1/masking/policy-3":
+			if r.Method != http.MethodPatch {
+				t.Errorf("expected PATCH, got %s", r.Method)
+			}
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+
+			if len(body) != 1 || body["enabled"] != false {
+				t.Errorf("expected only enabled=false, got %v", body)
+			}
+			paths = append(paths, r.URL.Path)
+			_ = json.NewEncoder(w).Encode(Policy{ID: "policy-1"})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	for id, policyType := range map[string]string{"policy-1": "CONDITIONAL", "policy-2": "RBAC_PERMISSIONS", "policy-3": "MASKING"} {
+		if err := c.SetPolicyEnabled(context.Background(), id, policyType, false); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	if len(paths) != 3 {
+		t.Errorf("expected every policy to be patched at the path of its type, got %v", paths)
+	}
+
+	if err := c.SetPolicyEnabled(context.Background(), "policy-4", "", false); err == nil {
+		t.Error("expected an error for a policy without a type")
+	}
+}
+
+func TestAttachAndDetachPolicyApplications(t *testing.T) {
+	appIDs := []string{"app-1", "app-2"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/app-integrations/resources/policies/v1/policy-1":
+			switch r.Method {
+			case http.MethodGet:
+				_ = json.NewEncoder(w).Encode(Policy{ID: "policy-1", Name: "PII", Type: "CONDITIONAL", AppIDs: appIDs})
+			case http.MethodPatch:
+				var body map[string][]string
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				if len(body) != 1 {
+					t.Errorf("expected only appIds, got %v", body)
+				}
+				appIDs = body["appIds"]
+				_ = json.NewEncoder(w).Encode(Policy{ID: "policy-1", AppIDs: appIDs})
+			default:
+				t.Errorf("unexpected method: %s", r.Method)
+			}
+		case "/app-integrations/resources/policies/v1/missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	policy, err := c.AttachPolicyApplications(context.Background(), "policy-1", []string{"app-2", "app-3"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if strings.Join(policy.AppIDs, ",") != "app-1,app-2,app-3" {
+		t.Errorf("expected app-3 to be added once, got %v", policy.AppIDs)
+	}
+
+	policy, err = c.DetachPolicyApplications(context.Background(), "policy-1", []string{"app-1", "app-3"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if strings.Join(appIDs, ",") != "app-2" || strings.Join(policy.AppIDs, ",") != "app-2" {
+		t.Errorf("expected only app-2 to remain, got %v", appIDs)
+	}
+
+	policy, err = c.AttachPolicyApplications(context.Background(), "missing", []string{"app-1"})
+	if !errors.Is(err, ErrNotFound) || policy != nil {
+		t.Errorf("expected ErrNotFound for a missing policy, got %v, %v", policy, err)
+	}
+}
+
+func TestSendRebuildsRequestBodyPerAttempt(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/upload":
+			body, _ := io.ReadAll(r.Body)
+			received = append(received, string(body))
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	payload := []byte("--boundary\r\nschema\r\n--boundary--")
+	for i := 0; i < 2; i++ {
+		resp, err := c.send(context.Background(), http.MethodPost, "/upload", payload, map[string]string{"Content-Type": "multipart/form-data; boundary=boundary"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if len(received) != 2 || received[0] != string(payload) || received[1] != string(payload) {
+		t.Errorf("expected the complete payload on every attempt, got %q", received)
+	}
+
+	// GetBody returns the complete body after the request body was read
+	req, err := c.newRequest(context.Background(), http.MethodPost, "/upload", payload, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	_, _ = io.ReadAll(req.Body)
+	replay, err := req.GetBody()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if body, _ := io.ReadAll(replay); string(body) != string(payload) {
+		t.Errorf("expected GetBody to return the complete payload, got %q", body)
+	}
+}
+
+func TestTenantApplications(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/vendor" {
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, strings.TrimSpace(r.Method+" "+r.URL.RequestURI()+" "+string(body)))
+		switch r.Method {
+		case http.MethodGet:
+			if r.Header.Get("frontegg-tenant-id") != "tenant-1" {
+				t.Errorf("expected the tenant header, got %q", r.Header.Get("frontegg-tenant-id"))
+			}
+			_, _ = w.Write([]byte(`[{"tenantId":"tenant-1","appIds":["app-1"]},{"tenantId":"tenant-2","appIds":["app-2"]}]`))
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			// Applications that are not assigned are ignored
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	ctx := context.Background()
+
+	appIDs, err := c.GetTenantApplications(ctx, "tenant-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(appIDs) != 1 || appIDs[0] != "app-1" {
+		t.Errorf("expected only the applications of the tenant, got %v", appIDs)
+	}
+
+	if err := c.AssignTenantApplications(ctx, "tenant-1", []string{"app-2", "app-3"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := c.UnassignTenantApplications(ctx, "tenant-1", []string{"app-1"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []string{
+		"GET /applications/resources/applications/tenant-assignments/v1?tenantIds=tenant-1",
+		`POST /applications/resources/applications/tenant-assignments/v1/app-2 {"tenantId":"tenant-1"}`,
+		`POST /applications/resources/applications/tenant-assignments/v1/app-3 {"tenantId":"tenant-1"}`,
+		"DELETE /applications/resources/applications/tenant-assignments/v1/app-1/tenant-1",
+	}
+	if !slices.Equal(requests, expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
+
+func TestGetApplicationCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/applications/resources/applications/v1/credentials/app-1":
+			_ = json.NewEncoder(w).Encode(ApplicationCredentials{ClientSecret: "client-secret", SharedSecret: "shared-secret"})
+		case "/applications/resources/applications/v1/credentials/missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	credentials, err := c.GetApplicationCredentials(context.Background(), "app-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if credentials.ClientSecret != "client-secret" || credentials.SharedSecret != "shared-secret" {
+		t.Errorf("unexpected credentials: %+v", credentials)
+	}
+
+	credentials, err = c.GetApplicationCredentials(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) || credentials != nil {
+		t.Errorf("expected ErrNotFound for a missing application, got %+v, %v", credentials, err)
+	}
+}
+
+func TestWithTenantID(t *testing.T) {
+	var tenantHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			if r.Header.Get("frontegg-tenant-id") != "" {
+				t.Error("expected authentication to stay vendor-scoped")
+			}
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		default:
+			tenantHeaders = append(tenantHeaders, r.Header.Get("frontegg-tenant-id"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{})
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	ctx := context.Background()
+
+	for _, tenantCtx := range []context.Context{WithTenantID(ctx, "tenant-1"), WithTenantID(ctx, ""), ctx} {
+		resp, err := c.DoRequest(tenantCtx, http.MethodGet, "/identity/resources/configurations/v1/sms", nil)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if len(tenantHeaders) != 3 || tenantHeaders[0] != "tenant-1" || tenantHeaders[1] != "" || tenantHeaders[2] != "" {
+		t.Errorf("expected only the tenant-scoped request to carry the tenant header, got %q", tenantHeaders)
+	}
+}
+
+func TestGetToolsBySource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/app-integrations/resources/internal-tools/v1":
+			if r.URL.Query().Get("appId") != "app-1" || r.URL.Query().Get("sourceId") != "src-1" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"items":[{"id":"tool-1","name":"list_users"},{"id":"tool-2","name":"get_user"}]}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	tools, err := c.GetToolsBySource(context.Background(), "app-1", "src-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(tools) != 2 || tools[0].ID != "tool-1" {
+		t.Errorf("unexpected tools: %+v", tools)
+	}
+}
+
+func TestCreateApprovalFlow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/identity/resources/approval-flows/v1":
+			if r.Method != http.MethodPost {
+				t.Errorf("expected POST, got %s", r.Method)
+			}
+			var body ApprovalFlowRequest
+			_ = json.NewDecoder(r.Body).Decode(&body)
+
+			if len(body.Steps) != 2 || body.Steps[1].StepOrder != 2 {
+				t.Errorf("unexpected steps: %+v", body.Steps)
+			}
+			if body.Steps[0].Configuration.MinApprovals != 2 {
+				t.Errorf("expected minApprovals 2, got %d", body.Steps[0].Configuration.MinApprovals)
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(ApprovalFlow{
+				ID:    "flow-1",
+				Name:  body.Name,
+				Steps: body.Steps,
+			})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	flow, err := c.CreateApprovalFlow(context.Background(), ApprovalFlowRequest{
+		Name:     "Payments",
+		Channels: ApprovalFlowChannels{Email: true},
+		Steps: []ApprovalFlowStep{
+			{
+				StepOrder:         1,
+				ApproverSelectors: ApproverSelectors{RoleKeys: []string{"finance"}},
+				Configuration:     ApprovalFlowStepQuorum{MinApprovals: 2},
+			},
+			{
+				StepOrder:         2,
+				ApproverSelectors: ApproverSelectors{Emails: []string{"cfo@example.com"}},
+				Configuration:     ApprovalFlowStepQuorum{MinApprovals: 1},
+			},
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if flow.ID != "flow-1" || len(flow.Steps) != 2 {
+		t.Errorf("unexpected approval flow: %+v", flow)
+	}
+}
+
+func TestGetApprovalFlowNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/identity/resources/approval-flows/v1/missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	flow, err := c.GetApprovalFlow(context.Background(), "missing")
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if flow != nil {
+		t.Errorf("expected nil approval flow, got %+v", flow)
+	}
+}
+
+func TestGetToolUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/app-integrations/resources/mcp-gw-analytics/v1/top-tools":
+			if got := r.URL.Query().Get("interval"); got != UsageIntervalLast7Days {
+				t.Errorf("expected interval '%s', got '%s'", UsageIntervalLast7Days, got)
+			}
+			_ = json.NewEncoder(w).Encode([]ToolUsage{
+				{ToolName: "get_user", Count: 150},
+				{ToolName: "list_orders", Count: 3},
+			})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	usage, err := c.GetToolUsage(context.Background(), UsageIntervalLast7Days)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(usage) != 2 || usage[0].ToolName != "get_user" || usage[0].Count != 150 {
+		t.Errorf("unexpected tool usage: %+v", usage)
+	}
+}
+
+func TestListPoliciesMergesPolicyCollections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/app-integrations/resources/policies/v1":
+			_ = json.NewEncoder(w).Encode([]Policy{{ID: "policy-1", Type: "CONDITIONAL", AppIDs: []string{"app-1"}}})
+		case "/app-integrations/resources/policies/v1/rbac":
+			_ = json.NewEncoder(w).Encode([]Policy{{ID: "policy-2", Type: "RBAC_ROLES"}, {ID: "policy-3", Type: "RBAC_PERMISSIONS"}})
+		case "/app-integrations/resources/policies/v1/masking":
+			_ = json.NewEncoder(w).Encode([]Policy{{ID: "policy-4", Type: "MASKING", AppIDs: []string{"app-1"}}, {ID: "policy-5", Type: "MASKING"}})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.RawQuery != "" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	policies, err := c.ListPolicies(context.Background(), PolicyFilters{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(policies) != 5 {
+		t.Errorf("expected 5 policies, got %+v", policies)
+	}
+
+	rbac, err := c.ListPolicies(context.Background(), PolicyFilters{Types: []string{"RBAC_ROLES", "RBAC_PERMISSIONS"}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(rbac) != 2 || rbac[0].ID != "policy-2" || rbac[1].ID != "policy-3" {
+		t.Errorf("expected only the RBAC policies, got %+v", rbac)
+	}
+
+	masking, err := c.ListPolicies(context.Background(), PolicyFilters{Types: []string{"MASKING"}, AppID: "app-1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(masking) != 1 || masking[0].ID != "policy-4" {
+		t.Errorf("expected only the masking policy of app-1, got %+v", masking)
+	}
+}
+
+func TestSkipTLSHostnameVerification(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+	}))
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	// The test certificate is issued for 127.0.0.1 and example.com, not localhost
+	baseURL := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+
+	strict := NewClient(baseURL, "client", "secret")
+	strict.authRetryDelay = time.Millisecond
+	strict.httpClient.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}
+	if err := strict.Authenticate(context.Background()); err == nil {
+		t.Fatal("expected the host name mismatch to fail without skipping verification")
+	}
+
+	// The roots of the transport in use are kept
+	c := NewClient(baseURL, "client", "secret")
+	c.httpClient.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}
+	c.SkipTLSHostnameVerification()
+	if err := c.Authenticate(context.Background()); err != nil {
+		t.Errorf("expected the host name mismatch to be accepted, got %v", err)
+	}
+
+	untrusted := NewClient(baseURL, "client", "secret")
+	untrusted.authRetryDelay = time.Millisecond
+	untrusted.httpClient.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: x509.NewCertPool()}}
+	untrusted.SkipTLSHostnameVerification()
+	if err := untrusted.Authenticate(context.Background()); err == nil {
+		t.Error("expected an untrusted certificate to be rejected")
+	}
+
+	// A recorder keeps recording through the adjusted transport
+	recorder, err := NewRecorder(filepath.Join(t.TempDir(), "cassette.json"), RecorderModeRecord, &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	recorded := NewClient(baseURL, "client", "secret")
+	recorded.SetTransport(recorder)
+	recorded.SkipTLSHostnameVerification()
+	if recorded.httpClient.Transport != recorder {
+		t.Fatal("expected the recorder to be kept")
+	}
+	if err := recorded.Authenticate(context.Background()); err != nil {
+		t.Errorf("expected the host name mismatch to be accepted through the recorder, got %v", err)
+	}
+	if len(recorder.cassette.Interactions) != 1 {
+		t.Errorf("expected the request to be recorded, got %d interactions", len(recorder.cassette.Interactions))
+	}
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/vendor" {
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+			return
+		}
+
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	c.SetMaxConcurrentRequests(2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.DoRequest(context.Background(), http.MethodGet, "/applications/resources/applications/v1", nil)
+			if err != nil {
+				t.Errorf("expected no error, got %v", err)
+				return
+			}
+			_ = resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight != 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+
+	// A response holds its slot until its body is closed
+	c.SetMaxConcurrentRequests(1)
+	resp, err := c.DoRequest(context.Background(), http.MethodGet, "/applications/resources/applications/v1", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(c.requestSlots) != 1 {
+		t.Error("expected the open response to hold its slot")
+	}
+	_ = resp.Body.Close()
+	_ = resp.Body.Close()
+	if len(c.requestSlots) != 0 {
+		t.Errorf("expected closing the response to release its slot once, got %d slots taken", len(c.requestSlots))
+	}
+
+	// Authentication takes a slot as well
+	auth := NewClient(server.URL, "client", "secret")
+	auth.SetMaxConcurrentRequests(1)
+	auth.requestSlots <- struct{}{}
+	authCtx, authCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer authCancel()
+	if err := auth.Authenticate(authCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected authentication to wait for a slot, got %v", err)
+	}
+
+	// Requests waiting for a slot give up when their context is cancelled
+	c.requestSlots <- struct{}{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.DoRequest(ctx, http.MethodGet, "/applications/resources/applications/v1", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline to be exceeded while waiting, got %v", err)
+	}
+}
+
+func TestInternalToolHooks(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/vendor" {
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+			return
+		}
+		if r.URL.Path != "/app-integrations/resources/internal-tool-hooks/v1" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			return
+		}
+		requests = append(requests, r.Method+" "+r.URL.RawQuery)
+
+		switch r.Method {
+		case http.MethodPost:
+			var body CreateInternalToolHookRequest
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body.AppID != "app-1" || body.HookType != ToolHookTypeCallTool || body.Runtime != "NODE_20" || body.FailMethod != "OPEN" || !body.IsActive {
+				t.Errorf("unexpected request: %+v", body)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(InternalToolHook{ID: "hook-1", HookType: body.HookType, InternalToolIDs: body.InternalToolIDs})
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode([]InternalToolHook{{ID: "hook-0", HookType: ToolHookTypeListTools}, {ID: "hook-1", HookType: ToolHookTypeCallTool}})
+		case http.MethodPatch:
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["appId"] != "app-1" || body["hookType"] != ToolHookTypeCallTool || body["isActive"] != false {
+				t.Errorf("unexpected request: %v", body)
+			}
+			if ids, ok := body["internalToolIds"].([]interface{}); !ok || len(ids) != 0 {
+				t.Errorf("expected the tools to be cleared, got %v", body["internalToolIds"])
+			}
+			_ = json.NewEncoder(w).Encode(InternalToolHook{ID: "hook-1", HookType: ToolHookTypeCallTool})
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	c := NewClient(server.URL, "client", "secret")
+
+	hook, err := c.CreateInternalToolHook(ctx, CreateInternalToolHookRequest{
+		AppID:           "app-1",
+		IsActive:        true,
+		HookType:        ToolHookTypeCallTool,
+		Code:            "export default async () => ({})",
+		Runtime:         "NODE_20",
+		FailMethod:      "OPEN",
+		InternalToolIDs: []string{"tool-1"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if hook.ID != "hook-1" || len(hook.InternalToolIDs) != 1 {
+		t.Errorf("unexpected hook: %+v", hook)
+	}
+
+	hook, err = c.GetInternalToolHook(ctx, "app-1", ToolHookTypeCallTool)
+	if err != nil || hook.ID != "hook-1" {
+		t.Errorf("expected the tool call hook, got %+v, %v", hook, err)
+	}
+
+	if _, err := c.UpdateInternalToolHook(ctx, UpdateInternalToolHookRequest{AppID: "app-1", HookType: ToolHookTypeCallTool, InternalToolIDs: []string{}}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := c.DeleteInternalToolHook(ctx, "app-1", ToolHookTypeCallTool); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []string{"POST ", "GET appId=app-1", "PATCH ", "DELETE appId=app-1&hookType=CALL_TOOL"}
+	if !slices.Equal(requests, expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
+
+func TestGetInternalToolHookNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+		case "/app-integrations/resources/internal-tool-hooks/v1":
+			_ = json.NewEncoder(w).Encode([]InternalToolHook{{ID: "hook-0", HookType: ToolHookTypeListTools}})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	if _, err := c.GetInternalToolHook(context.Background(), "app-1", ToolHookTypeCallTool); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestTenantAccessTokens(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/vendor" {
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "token", ExpiresIn: 3600})
+			return
+		}
+		if r.Header.Get("frontegg-tenant-id") != "tenant-1" {
+			t.Errorf("expected the tenant header, got %q", r.Header.Get("frontegg-tenant-id"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, strings.TrimSpace(r.Method+" "+r.URL.RequestURI()+" "+string(body)))
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"token-1","secret":"token-secret","expires":"2026-01-01T00:30:00Z","roleIds":["role-1"]}`))
+		case http.MethodDelete:
+			// Tokens that were already deleted are ignored
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	ctx := context.Background()
+
+	token, err := c.CreateTenantAccessToken(ctx, "tenant-1", CreateTenantAccessTokenRequest{ExpiresInMinutes: 30, RoleIDs: []string{"role-1"}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if token.ID != "token-1" || token.Secret != "token-secret" || token.Expires != "2026-01-01T00:30:00Z" {
+		t.Errorf("unexpected token: %+v", token)
+	}
+
+	if err := c.DeleteTenantAccessToken(ctx, "tenant-1", "token-1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []string{
+		`POST /identity/resources/tenants/access-tokens/v1 {"expiresInMinutes":30,"roleIds":["role-1"]}`,
+		"DELETE /identity/resources/tenants/access-tokens/v1/token-1",
+	}
+	if !slices.Equal(requests, expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
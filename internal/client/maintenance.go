@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultMaintenanceMaxWait is how long requests are retried while the API is in a maintenance
+// window before the maintenance response is returned
+const DefaultMaintenanceMaxWait = 10 * time.Minute
+
+// maintenanceMaxDelay caps the delay between retries during a maintenance window
+const maintenanceMaxDelay = 2 * time.Minute
+
+// SetMaintenanceMaxWait sets how long requests are retried while the API reports a maintenance
+// window. A wait of 0 returns maintenance responses immediately.
+func (c *Client) SetMaintenanceMaxWait(wait time.Duration) {
+	c.maintenanceMaxWait = wait
+}
+
+// MaintenanceWait is a request that waited for a maintenance window of the API to end
+type MaintenanceWait struct {
+	Operation string
+	Duration  time.Duration
+}
+
+// MaintenanceWaits collects the maintenance waits of the requests made with a context returned by
+// WithMaintenanceWaits, so callers can report them
+type MaintenanceWaits struct {
+	mu    sync.Mutex
+	waits []MaintenanceWait
+}
+
+// maintenanceWaitsKey is the context key of the MaintenanceWaits requests record their waits in
+type maintenanceWaitsKey struct{}
+
+// WithMaintenanceWaits returns a context whose requests record their maintenance waits in the
+// returned MaintenanceWaits
+func WithMaintenanceWaits(ctx context.Context) (context.Context, *MaintenanceWaits) {
+	waits := &MaintenanceWaits{}
+	return context.WithValue(ctx, maintenanceWaitsKey{}, waits), waits
+}
+
+// Waits returns the maintenance waits recorded so far
+func (m *MaintenanceWaits) Waits() []MaintenanceWait {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]MaintenanceWait(nil), m.waits...)
+}
+
+// recordMaintenanceWait records that operation waited for a maintenance window, when ctx carries
+// a MaintenanceWaits
+func recordMaintenanceWait(ctx context.Context, operation string, wait time.Duration) {
+	waits, ok := ctx.Value(maintenanceWaitsKey{}).(*MaintenanceWaits)
+	if !ok || wait <= 0 {
+		return
+	}
+	waits.mu.Lock()
+	defer waits.mu.Unlock()
+	waits.waits = append(waits.waits, MaintenanceWait{Operation: operation, Duration: wait})
+}
+
+// isMaintenanceResponse reports whether resp is a 503 carrying the maintenance banner of the API.
+// The body is read and replaced, so it stays readable for the caller.
+func isMaintenanceResponse(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		return false
+	}
+
+	body, err := bufferBody(resp)
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(string(body)), "maintenance")
+}
+
+// maintenanceDelay returns how long to wait before retrying a maintenance response. Retry-After
+// is honoured when the API sends it; otherwise delay is used. The result never exceeds remaining.
+func maintenanceDelay(resp *http.Response, delay, remaining time.Duration) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		delay = time.Duration(seconds) * time.Second
+	}
+	return min(delay, remaining)
+}
+
+// waitForMaintenance waits before the next attempt of a request rejected by a maintenance window.
+// It returns false when the maximum wait is used up or ctx is cancelled, in which case the
+// maintenance response should be returned as is.
+func (c *Client) waitForMaintenance(ctx context.Context, resp *http.Response, operation string, attempt int, delay, waited time.Duration) (time.Duration, bool) {
+	remaining := c.maintenanceMaxWait - waited
+	if remaining <= 0 {
+		return 0, false
+	}
+
+	wait := maintenanceDelay(resp, delay, remaining)
+	tflog.Warn(ctx, "Frontegg API is in a maintenance window, retrying", map[string]interface{}{
+		"operation": operation,
+		"attempt":   attempt,
+		"delay":     wait.String(),
+		"remaining": remaining.String(),
+	})
+
+	select {
+	case <-time.After(wait):
+		return wait, true
+	case <-ctx.Done():
+		return 0, false
+	}
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func maintenanceTestClient(t *testing.T, maintenanceResponses int, banner string) (*Client, *int) {
+	t.Helper()
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls <= maintenanceResponses {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(banner))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"app-1"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient(server.URL, "client", "secret")
+	c.accessToken = "token"
+	c.tokenExpiry = time.Now().Add(time.Hour)
+	c.maintenanceRetryDelay = time.Millisecond
+
+	return c, &calls
+}
+
+func TestSend_RetriesMaintenanceWindow(t *testing.T) {
+	c, calls := maintenanceTestClient(t, 2, `{"message":"Scheduled maintenance in progress"}`)
+
+	resp, err := c.DoRequest(context.Background(), http.MethodGet, "/applications/resources/applications/v1/app-1", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK || *calls != 3 {
+		t.Errorf("expected success after 3 attempts, got %d after %d", resp.StatusCode, *calls)
+	}
+}
+
+func TestSend_RecordsMaintenanceWaits(t *testing.T) {
+	c, _ := maintenanceTestClient(t, 2, `{"message":"Scheduled maintenance in progress"}`)
+	ctx, waits := WithMaintenanceWaits(context.Background())
+
+	for _, path := range []string{"/applications/resources/applications/v1/app-1", "/applications/resources/applications/v1/app-2"} {
+		resp, err := c.DoRequest(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	// Only the first request hit the maintenance window
+	recorded := waits.Waits()
+	if len(recorded) != 1 || recorded[0].Operation != "GET /applications/resources/applications/v1/app-1" || recorded[0].Duration <= 0 {
+		t.Errorf("expected a single maintenance wait of the first request, got %+v", recorded)
+	}
+}
+
+func TestSend_MaintenanceMaxWait(t *testing.T) {
+	c, calls := maintenanceTestClient(t, 100, `{"message":"Scheduled maintenance in progress"}`)
+	c.SetMaintenanceMaxWait(5 * time.Millisecond)
+
+	resp, err := c.DoRequest(context.Background(), http.MethodGet, "/applications/resources/applications/v1/app-1", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusServiceUnavailable || *calls < 2 || *calls >= 100 {
+		t.Errorf("expected the maintenance response after a few attempts, got %d after %d", resp.StatusCode, *calls)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != `{"message":"Scheduled maintenance in progress"}` {
+		t.Errorf("expected the maintenance banner to stay readable, got %s", body)
+	}
+}
+
+func TestSend_OtherUnavailableResponsesUseRetryLimit(t *testing.T) {
+	c, calls := maintenanceTestClient(t, 1, `{"message":"upstream unavailable"}`)
+	c.SetMaxRetries(0)
+
+	resp, err := c.DoRequest(context.Background(), http.MethodGet, "/applications/resources/applications/v1/app-1", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusServiceUnavailable || *calls != 1 {
+		t.Errorf("expected a single attempt, got %d after %d", resp.StatusCode, *calls)
+	}
+}
+
+func TestMaintenanceDelay(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	if got := maintenanceDelay(resp, 10*time.Second, time.Minute); got != 10*time.Second {
+		t.Errorf("expected the backoff delay, got %s", got)
+	}
+
+	resp.Header.Set("Retry-After", "30")
+	if got := maintenanceDelay(resp, 10*time.Second, time.Minute); got != 30*time.Second {
+		t.Errorf("expected Retry-After to be honoured, got %s", got)
+	}
+	if got := maintenanceDelay(resp, 10*time.Second, 5*time.Second); got != 5*time.Second {
+		t.Errorf("expected the delay to be capped by the remaining wait, got %s", got)
+	}
+}
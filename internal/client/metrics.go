@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RequestMetric describes a single API request, e.g. for exporting request counts, latency and
+// error rates to OpenTelemetry
+type RequestMetric struct {
+	Method string
+	// Path is the request path without the query string
+	Path string
+	// Resource is the API resource the request targets, e.g. "applications" or "policies", so
+	// metrics can be grouped without the object IDs in Path
+	Resource string
+	// StatusCode is 0 when no response was received
+	StatusCode int
+	Duration   time.Duration
+	// Err is the transport error when no response was received. Error responses are reported
+	// through StatusCode.
+	Err error
+}
+
+// MetricsRecorder receives a RequestMetric after every API request, including each retry.
+// Implementations are called from concurrent requests and should not block.
+type MetricsRecorder interface {
+	RecordRequest(ctx context.Context, metric RequestMetric)
+}
+
+// MetricsRecorderFunc adapts a function to a MetricsRecorder
+type MetricsRecorderFunc func(ctx context.Context, metric RequestMetric)
+
+// RecordRequest calls f
+func (f MetricsRecorderFunc) RecordRequest(ctx context.Context, metric RequestMetric) {
+	f(ctx, metric)
+}
+
+// SetMetricsRecorder reports every API request to recorder. A nil recorder disables reporting.
+func (c *Client) SetMetricsRecorder(recorder MetricsRecorder) {
+	c.metrics = recorder
+}
+
+// recordRequest reports a request to the metrics recorder, if any
+func (c *Client) recordRequest(ctx context.Context, method, path string, start time.Time, resp *http.Response, err error) {
+	if c.metrics == nil {
+		return
+	}
+
+	path, _, _ = strings.Cut(path, "?")
+	metric := RequestMetric{
+		Method:   method,
+		Path:     path,
+		Resource: apiResource(path),
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if resp != nil {
+		metric.StatusCode = resp.StatusCode
+	}
+	c.metrics.RecordRequest(ctx, metric)
+}
+
+// apiResource returns the resource segment of an API path, i.e. the segment following
+// "resources", or the first segment for paths without one, such as "/auth/vendor"
+func apiResource(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments[:len(segments)-1] {
+		if segment == "resources" {
+			return segments[i+1]
+		}
+	}
+	return segments[0]
+}
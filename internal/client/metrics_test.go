@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAPIResource(t *testing.T) {
+	cases := map[string]string{
+		"/applications/resources/applications/v1/app-1":                            "applications",
+		"/app-integrations/resources/app-mcp-configuration-sources/v1?appId=app-1": "app-mcp-configuration-sources",
+		"/auth/vendor": "auth",
+		"/resources":   "resources",
+	}
+	for path, expected := range cases {
+		if got := apiResource(path); got != expected {
+			t.Errorf("apiResource(%q): expected %q, got %q", path, expected, got)
+		}
+	}
+}
+
+func TestRecordRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	c.accessToken = "token"
+	c.tokenExpiry = time.Now().Add(time.Hour)
+
+	var metrics []RequestMetric
+	c.SetMetricsRecorder(MetricsRecorderFunc(func(ctx context.Context, metric RequestMetric) {
+		metrics = append(metrics, metric)
+	}))
+
+	resp, err := c.DoRequest(context.Background(), http.MethodGet, "/applications/resources/applications/v1/app-1?include=all", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if len(metrics) != 1 {
+		t.Fatalf("expected 1 metric, got %d", len(metrics))
+	}
+	metric := metrics[0]
+	if metric.Method != http.MethodGet || metric.Path != "/applications/resources/applications/v1/app-1" || metric.Resource != "applications" {
+		t.Errorf("unexpected metric: %+v", metric)
+	}
+	if metric.StatusCode != http.StatusNotFound || metric.Err != nil {
+		t.Errorf("expected a 404 without transport error, got %+v", metric)
+	}
+	if metric.Duration <= 0 {
+		t.Errorf("expected a positive duration, got %s", metric.Duration)
+	}
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// sensitiveKeyFragments mark payload keys whose string values are redacted from logs
+var sensitiveKeyFragments = []string{"secret", "token", "password", "apikey", "privatekey", "authorization", "credential"}
+
+// piiKeys are the payload keys of PII entities handled by masking policies. Only string values are
+// redacted, so boolean masking policy configuration and numeric token settings remain readable.
+var piiKeys = map[string]bool{
+	"creditcard":      true,
+	"email":           true,
+	"emailaddress":    true,
+	"phone":           true,
+	"phonenumber":     true,
+	"ipaddress":       true,
+	"ssn":             true,
+	"usssn":           true,
+	"usdriverlicense": true,
+	"uspassport":      true,
+	"usitin":          true,
+	"usbanknumber":    true,
+	"ibancode":        true,
+	"swiftcode":       true,
+	"bitcoinaddress":  true,
+	"ethereumaddress": true,
+	"cvvcvc":          true,
+}
+
+// SetLogPayloads enables TRACE-level logging of request and response bodies
+func (c *Client) SetLogPayloads(enabled bool) {
+	c.logPayloads = enabled
+}
+
+// logRequestPayload logs a redacted JSON request body when payload logging is enabled
+func (c *Client) logRequestPayload(ctx context.Context, method, path string, body []byte) {
+	if !c.logPayloads || len(body) == 0 {
+		return
+	}
+
+	tflog.Trace(ctx, "Frontegg API request payload", map[string]interface{}{
+		"operation": method + " " + path,
+		"body":      redactPayload(body),
+	})
+}
+
+// logResponsePayload logs a redacted response body when payload logging is enabled.
+// The response body is replaced so callers can still decode it.
+func (c *Client) logResponsePayload(ctx context.Context, resp *http.Response, operation string) {
+	if !c.logPayloads {
+		return
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+
+	tflog.Trace(ctx, "Frontegg API response payload", map[string]interface{}{
+		"operation":   operation,
+		"status_code": resp.StatusCode,
+		"body":        redactPayload(body),
+	})
+}
+
+// redactPayload returns a JSON body with credentials and PII values replaced.
+// Bodies that are not JSON are omitted entirely, since they cannot be redacted reliably.
+func redactPayload(body []byte) string {
+	var payload interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "<non-JSON body omitted>"
+	}
+
+	redacted, err := json.Marshal(redactValue(payload))
+	if err != nil {
+		return "<body omitted>"
+	}
+	return string(redacted)
+}
+
+// redactValue recursively redacts sensitive fields of a decoded JSON value
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			lower := strings.ToLower(key)
+			if _, ok := field.(string); ok && (isSensitiveKey(lower) || piiKeys[lower]) {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactValue(field)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	for _, fragment := range sensitiveKeyFragments {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
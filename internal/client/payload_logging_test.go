@@ -0,0 +1,42 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestRedactPayload(t *testing.T) {
+	body := []byte(`{"clientId":"id","secret":"s3cret","token":"abc","defaultTokenExpiration":86400,` +
+		`"policyConfiguration":{"emailAddress":true},"users":[{"email":"jane@example.com","name":"Jane"}]}`)
+
+	expected := `{"clientId":"id","defaultTokenExpiration":86400,"policyConfiguration":{"emailAddress":true},` +
+		`"secret":"REDACTED","token":"REDACTED","users":[{"email":"REDACTED","name":"Jane"}]}`
+	if got := redactPayload(body); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestRedactPayload_NonJSON(t *testing.T) {
+	if got := redactPayload([]byte("secret=s3cret")); got != "<non-JSON body omitted>" {
+		t.Errorf("expected non-JSON body to be omitted, got %s", got)
+	}
+}
+
+func TestLogResponsePayload_PreservesBody(t *testing.T) {
+	c := NewClient("https://api.example.com", "client", "secret")
+	c.SetLogPayloads(true)
+
+	resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader([]byte(`{"id":"app-1"}`)))}
+	c.logResponsePayload(context.Background(), resp, "GET /applications")
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if string(body) != `{"id":"app-1"}` {
+		t.Errorf("expected body to be readable after logging, got %s", body)
+	}
+}
@@ -0,0 +1,244 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// RecorderMode selects whether a Recorder captures live traffic or replays a cassette
+type RecorderMode string
+
+const (
+	// RecorderModeRecord sends requests to the API and saves the interactions to the cassette
+	RecorderModeRecord RecorderMode = "record"
+	// RecorderModeReplay serves responses from the cassette without any network access
+	RecorderModeReplay RecorderMode = "replay"
+)
+
+// redactedValue replaces credentials and tokens stored in cassettes
+const redactedValue = "REDACTED"
+
+// Cassette is a recorded sequence of HTTP interactions
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of a request used to match it during replay
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded API response
+type RecordedResponse struct {
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+// recordedHeaders are the response headers kept in cassettes
+var recordedHeaders = []string{"Content-Type", "ETag", "frontegg-trace-id"}
+
+// Recorder is an http.RoundTripper that records API interactions to a cassette file
+// or replays them from it. Only the request path and query are recorded, so a cassette
+// recorded against one region can be replayed against any base URL.
+type Recorder struct {
+	mode      RecorderMode
+	path      string
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder creates a Recorder for the cassette at path. In replay mode the cassette
+// must already exist. In record mode requests are sent through transport, or
+// http.DefaultTransport when transport is nil.
+func NewRecorder(path string, mode RecorderMode, transport http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{
+		mode:      mode,
+		path:      path,
+		transport: transport,
+	}
+
+	switch mode {
+	case RecorderModeRecord:
+		if r.transport == nil {
+			r.transport = http.DefaultTransport
+		}
+	case RecorderModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("unknown recorder mode %q", mode)
+	}
+
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+
+		// RoundTrippers must not modify the request, which the caller may send again through GetBody
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL.RequestURI(),
+		Body:   redactBody(body, "secret"),
+	}
+
+	if r.mode == RecorderModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	headers := map[string]string{}
+	for _, name := range recordedHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    headers,
+			Body:       redactBody(respBody, "token"),
+		},
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay returns the first unused interaction matching the request. Interactions with the
+// same method, URL and body are preferred; multipart uploads and other bodies that vary
+// between runs fall back to matching on method and URL.
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || interaction.Request.Method != recorded.Method || interaction.Request.URL != recorded.URL {
+			continue
+		}
+		if interaction.Request.Body == recorded.Body {
+			match = i
+			break
+		}
+		if match == -1 {
+			match = i
+		}
+	}
+
+	if match == -1 {
+		return nil, fmt.Errorf("no recorded interaction for %s %s in cassette %s", recorded.Method, recorded.URL, r.path)
+	}
+	r.used[match] = true
+
+	response := r.cassette.Interactions[match].Response
+	header := http.Header{}
+	for name, value := range response.Headers {
+		header.Set(name, value)
+	}
+
+	return &http.Response{
+		StatusCode:    response.StatusCode,
+		Status:        fmt.Sprintf("%d %s", response.StatusCode, http.StatusText(response.StatusCode)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(response.Body))),
+		ContentLength: int64(len(response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// Save writes the recorded interactions to the cassette file. It is a no-op in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != RecorderModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// redactBody replaces the value of a top-level JSON field, leaving other bodies unchanged
+func redactBody(body []byte, field string) string {
+	var object map[string]interface{}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return string(body)
+	}
+
+	if _, ok := object[field]; !ok {
+		return string(body)
+	}
+	object[field] = redactedValue
+
+	redacted, err := json.Marshal(object)
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/auth/vendor":
+			_ = json.NewEncoder(w).Encode(AuthResponse{Token: "live-token", ExpiresIn: 3600})
+		case "/applications/resources/applications/v1/app-1":
+			_ = json.NewEncoder(w).Encode(Application{ID: "app-1", Name: "Recorded App"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cassette := filepath.Join(t.TempDir(), "cassettes", "test.json")
+
+	recorder, err := NewRecorder(cassette, RecorderModeRecord, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	c := NewClient(server.URL, "client", "live-secret")
+	c.SetTransport(recorder)
+	if _, err := c.GetApplicationByID(context.Background(), "app-1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatalf("expected cassette to be written, got %v", err)
+	}
+	if strings.Contains(string(data), "live-secret") || strings.Contains(string(data), "live-token") {
+		t.Errorf("expected credentials to be redacted, got %s", data)
+	}
+
+	// Replay against an unreachable base URL with different credentials
+	replayer, err := NewRecorder(cassette, RecorderModeReplay, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	c = NewClient("https://unreachable.invalid", "client", "other-secret")
+	c.SetTransport(replayer)
+
+	app, err := c.GetApplicationByID(context.Background(), "app-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if app == nil || app.Name != "Recorded App" {
+		t.Errorf("expected recorded application, got %+v", app)
+	}
+
+	// Each interaction is replayed once
+	if _, err := c.GetApplicationByID(context.Background(), "app-1"); err == nil {
+		t.Error("expected error when the cassette has no remaining interaction")
+	}
+}
+
+func TestRecorder_LeavesRequestUnmodified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	recorder, err := NewRecorder(filepath.Join(t.TempDir(), "test.json"), RecorderModeRecord, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/upload", strings.NewReader(`{"name":"app"}`))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	body := req.Body
+
+	resp, err := recorder.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if req.Body != body {
+		t.Error("expected the request body not to be replaced")
+	}
+	replay, err := req.GetBody()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if data, _ := io.ReadAll(replay); string(data) != `{"name":"app"}` {
+		t.Errorf("expected GetBody to return the complete body, got %q", data)
+	}
+}
+
+func TestNewRecorder_ReplayMissingCassette(t *testing.T) {
+	if _, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), RecorderModeReplay, nil); err == nil {
+		t.Error("expected error for missing cassette")
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	if got := redactBody([]byte(`{"clientId":"id","secret":"s"}`), "secret"); got != `{"clientId":"id","secret":"REDACTED"}` {
+		t.Errorf("unexpected redacted body %s", got)
+	}
+	if got := redactBody([]byte("not json"), "secret"); got != "not json" {
+		t.Errorf("expected non-JSON body to be unchanged, got %s", got)
+	}
+}
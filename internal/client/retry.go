@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultMaxRetries is how many times a request rejected with a transient error is retried
+const DefaultMaxRetries = 3
+
+// DefaultRetryWaitMax caps the delay between retries of a request rejected with a transient error
+const DefaultRetryWaitMax = 30 * time.Second
+
+// SetMaxRetries sets how many times requests rejected with a transient error, such as 429 or
+// 502, are retried. 0 disables the retries. POSTs are not retried on 502 and 504.
+func (c *Client) SetMaxRetries(retries int) {
+	c.maxRetries = retries
+}
+
+// SetRetryWaitMax sets the maximum delay between retries, including delays requested by the API
+// through Retry-After
+func (c *Client) SetRetryWaitMax(wait time.Duration) {
+	c.retryWaitMax = wait
+}
+
+// isRetryableResponse reports whether resp is a transient error worth retrying: rate limiting and
+// gateway errors. Other server errors are returned as is, since they usually fail again. A gateway
+// error on a POST may come after the API created the object, so POSTs are only retried when the
+// request was rejected before reaching it: 429, or 503 with Retry-After.
+func isRetryableResponse(method string, resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable:
+		return method != http.MethodPost || resp.Header.Get("Retry-After") != ""
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return method != http.MethodPost
+	}
+	return false
+}
+
+// retryDelay returns how long to wait before the given retry. Retry-After is honoured when the API
+// sends it, as seconds or an HTTP date; otherwise the delay doubles per retry from minDelay, with
+// jitter so concurrent requests do not retry in lockstep. The result never exceeds maxDelay.
+func retryDelay(resp *http.Response, retry int, minDelay, maxDelay time.Duration) time.Duration {
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, maxDelay)
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return min(max(time.Until(date), 0), maxDelay)
+		}
+	}
+
+	delay := maxDelay
+	if shift := retry - 1; shift < 32 {
+		delay = min(minDelay<<shift, maxDelay)
+	}
+	// Jitter over the upper half of the backoff keeps at least half of the delay
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// waitForRetry waits before the next attempt of a request rejected with a transient error. It
+// returns false when ctx is cancelled, in which case the response should be returned as is.
+func (c *Client) waitForRetry(ctx context.Context, resp *http.Response, operation string, retry int) bool {
+	wait := retryDelay(resp, retry, c.retryWaitMin, c.retryWaitMax)
+	tflog.Warn(ctx, "Transient Frontegg API error, retrying", map[string]interface{}{
+		"operation":   operation,
+		"status_code": resp.StatusCode,
+		"retry":       retry,
+		"max_retries": c.maxRetries,
+		"delay":       wait.String(),
+	})
+
+	select {
+	case <-time.After(wait):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func retryTestClient(t *testing.T, failures int, status int) (*Client, *int) {
+	t.Helper()
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		if r.Method == http.MethodPost && string(body) != `{"name":"app"}` {
+			t.Errorf("expected the complete body on every attempt, got %s", body)
+		}
+		if calls <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"app-1"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient(server.URL, "client", "secret")
+	c.accessToken = "token"
+	c.tokenExpiry = time.Now().Add(time.Hour)
+	c.retryWaitMin = time.Millisecond
+
+	return c, &calls
+}
+
+func TestSend_RetriesTransientErrors(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		c, calls := retryTestClient(t, 2, status)
+
+		resp, err := c.DoRequest(context.Background(), http.MethodPut, "/applications/resources/applications/v1/app-1", map[string]string{"name": "app"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		_ = resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK || *calls != 3 {
+			t.Errorf("expected success after 3 attempts for %d, got %d after %d", status, resp.StatusCode, *calls)
+		}
+	}
+}
+
+func TestSend_RetriesPostOnlyWhenRejected(t *testing.T) {
+	tests := []struct {
+		status     int
+		retryAfter string
+		calls      int
+	}{
+		{status: http.StatusTooManyRequests, calls: 3},
+		{status: http.StatusServiceUnavailable, retryAfter: "0", calls: 3},
+		{status: http.StatusServiceUnavailable, calls: 1},
+		{status: http.StatusBadGateway, calls: 1},
+		{status: http.StatusGatewayTimeout, calls: 1},
+	}
+
+	for _, tt := range tests {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls <= 2 {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":"app-1"}`))
+		}))
+
+		c := NewClient(server.URL, "client", "secret")
+		c.accessToken = "token"
+		c.tokenExpiry = time.Now().Add(time.Hour)
+		c.retryWaitMin = time.Millisecond
+
+		resp, err := c.DoRequest(context.Background(), http.MethodPost, "/applications/resources/applications/v1", map[string]string{"name": "app"})
+		server.Close()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		_ = resp.Body.Close()
+
+		if calls != tt.calls {
+			t.Errorf("expected %d attempts of a POST rejected with %d (Retry-After %q), got %d", tt.calls, tt.status, tt.retryAfter, calls)
+		}
+	}
+}
+
+func TestSend_MaxRetries(t *testing.T) {
+	c, calls := retryTestClient(t, 100, http.StatusTooManyRequests)
+	c.SetMaxRetries(2)
+
+	resp, err := c.DoRequest(context.Background(), http.MethodGet, "/applications/resources/applications/v1/app-1", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests || *calls != 3 {
+		t.Errorf("expected the rate limit response after 3 attempts, got %d after %d", resp.StatusCode, *calls)
+	}
+}
+
+func TestSend_DoesNotRetryOtherErrors(t *testing.T) {
+	c, calls := retryTestClient(t, 1, http.StatusInternalServerError)
+
+	resp, err := c.DoRequest(context.Background(), http.MethodGet, "/applications/resources/applications/v1/app-1", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError || *calls != 1 {
+		t.Errorf("expected a single attempt, got %d after %d", resp.StatusCode, *calls)
+	}
+}
+
+func TestImportSchemaRetriesTransientErrors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if err := r.ParseMultipartForm(1 << 20); err != nil || r.FormValue("appId") != "app-1" {
+			t.Errorf("expected the complete multipart form on every attempt, got %v", err)
+		}
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":"tool-1","name":"getUser"}]`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	c.accessToken = "token"
+	c.tokenExpiry = time.Now().Add(time.Hour)
+	c.retryWaitMin = time.Millisecond
+
+	tools, err := c.ImportOpenAPISchema(context.Background(), "app-1", []byte(`{"openapi":"3.0.0"}`), "openapi.json")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(tools) != 1 || calls != 2 {
+		t.Errorf("expected 1 tool after 2 attempts, got %d after %d", len(tools), calls)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	for retry := 1; retry <= 3; retry++ {
+		backoff := time.Second << (retry - 1)
+		if got := retryDelay(resp, retry, time.Second, time.Minute); got < backoff/2 || got > backoff {
+			t.Errorf("expected a jittered delay between %s and %s for retry %d, got %s", backoff/2, backoff, retry, got)
+		}
+	}
+	if got := retryDelay(resp, 100, time.Second, time.Minute); got < 30*time.Second || got > time.Minute {
+		t.Errorf("expected the delay to be capped, got %s", got)
+	}
+
+	resp.Header.Set("Retry-After", "20")
+	if got := retryDelay(resp, 1, time.Second, time.Minute); got != 20*time.Second {
+		t.Errorf("expected Retry-After to be honoured, got %s", got)
+	}
+	if got := retryDelay(resp, 1, time.Second, 5*time.Second); got != 5*time.Second {
+		t.Errorf("expected Retry-After to be capped, got %s", got)
+	}
+
+	resp.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	if got := retryDelay(resp, 1, time.Second, time.Minute); got != 0 {
+		t.Errorf("expected a past Retry-After date to retry immediately, got %s", got)
+	}
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the spans of the client to the OpenTelemetry tracer provider
+const tracerName = "github.com/frontegg/terraform-provider-agentlink/internal/client"
+
+// traceContext propagates the W3C traceparent and tracestate headers. It is used instead of the
+// global propagator, which does nothing unless the process configures one.
+var traceContext = propagation.TraceContext{}
+
+// startSpan starts a client span around an API request. When no tracer provider is configured,
+// the returned context still carries the incoming trace, so it is propagated to the API.
+func startSpan(ctx context.Context, method, path string) (context.Context, trace.Span) {
+	ctx = withEnvironmentTraceContext(ctx)
+
+	path, _, _ = strings.Cut(path, "?")
+	return otel.Tracer(tracerName).Start(ctx, method+" "+apiResource(path),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", method),
+			attribute.String("url.path", path),
+		),
+	)
+}
+
+// endSpan records the outcome of an API request on its span and ends it
+func endSpan(span trace.Span, resp *http.Response, err error) {
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case resp != nil:
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= 400 {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+	span.End()
+}
+
+// withEnvironmentTraceContext continues the trace of the TRACEPARENT and TRACESTATE environment
+// variables when ctx carries no trace, so runs started by traced CI pipelines are correlated
+func withEnvironmentTraceContext(ctx context.Context) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+
+	traceparent := os.Getenv("TRACEPARENT")
+	if traceparent == "" {
+		return ctx
+	}
+	return traceContext.Extract(ctx, propagation.MapCarrier{
+		"traceparent": traceparent,
+		"tracestate":  os.Getenv("TRACESTATE"),
+	})
+}
+
+// injectTraceContext sets the traceparent and tracestate headers of the trace in ctx
+func injectTraceContext(ctx context.Context, header http.Header) {
+	traceContext.Inject(ctx, propagation.HeaderCarrier(header))
+}
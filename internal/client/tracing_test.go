@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func tracedRequest(t *testing.T, ctx context.Context) string {
+	t.Helper()
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "client", "secret")
+	c.accessToken = "token"
+	c.tokenExpiry = time.Now().Add(time.Hour)
+
+	resp, err := c.DoRequest(ctx, http.MethodGet, "/applications/resources/applications/v1", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	_ = resp.Body.Close()
+
+	return traceparent
+}
+
+func TestTraceContextPropagation(t *testing.T) {
+	t.Setenv("TRACEPARENT", "")
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	traceparent := tracedRequest(t, ctx)
+	if !strings.HasPrefix(traceparent, "00-4bf92f3577b34da6a3ce929d0e0e4736-") {
+		t.Errorf("expected the trace of the context to be propagated, got %q", traceparent)
+	}
+
+	if traceparent := tracedRequest(t, context.Background()); traceparent != "" {
+		t.Errorf("expected no traceparent without a trace, got %q", traceparent)
+	}
+}
+
+func TestTraceContextPropagation_Environment(t *testing.T) {
+	t.Setenv("TRACEPARENT", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+
+	traceparent := tracedRequest(t, context.Background())
+	if !strings.HasPrefix(traceparent, "00-0af7651916cd43dd8448eb211c80319c-") {
+		t.Errorf("expected the trace of TRACEPARENT to be propagated, got %q", traceparent)
+	}
+}
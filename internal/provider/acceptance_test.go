@@ -2,21 +2,25 @@ package provider
 
 import (
 	"fmt"
+	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
-// providerConfig returns the provider configuration for acceptance tests
+// providerConfig returns the provider configuration for acceptance tests.
+// Placeholder credentials are used when replaying a cassette.
 func providerConfig() string {
 	return `
 provider "agentlink" {
-  client_id = "` + os.Getenv("FRONTEGG_CLIENT_ID") + `"
-  secret    = "` + os.Getenv("FRONTEGG_SECRET") + `"
+  client_id = "` + getEnvOrDefault("FRONTEGG_CLIENT_ID", "replay-client-id") + `"
+  secret    = "` + getEnvOrDefault("FRONTEGG_SECRET", "replay-secret") + `"
   region    = "` + getEnvOrDefault("FRONTEGG_REGION", "stg") + `"
 }
 `
@@ -29,14 +33,64 @@ func getEnvOrDefault(key, defaultValue string) string {
 	return defaultValue
 }
 
-// testAccProtoV6ProviderFactoriesAcc are used to instantiate a provider during acceptance testing
-var testAccProtoV6ProviderFactoriesAcc = map[string]func() (tfprotov6.ProviderServer, error){
-	"agentlink": providerserver.NewProtocol6WithError(New("test")()),
+// testAccCassettePath returns the cassette file recorded for a test
+func testAccCassettePath(t *testing.T) string {
+	return filepath.Join("testdata", "cassettes", t.Name()+".json")
+}
+
+// testAccCassetteMode returns the recorder mode for a test, or an empty mode to run against the live API.
+// Set AGENTLINK_CASSETTE_MODE=record to (re-)record a cassette with real credentials. Without credentials,
+// tests replay their cassette when one exists.
+func testAccCassetteMode(t *testing.T) client.RecorderMode {
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		return ""
+	}
+
+	switch mode := client.RecorderMode(os.Getenv("AGENTLINK_CASSETTE_MODE")); mode {
+	case client.RecorderModeRecord, client.RecorderModeReplay:
+		return mode
+	case "":
+	default:
+		t.Fatalf("AGENTLINK_CASSETTE_MODE must be %q or %q, got %q", client.RecorderModeRecord, client.RecorderModeReplay, mode)
+	}
+
+	if os.Getenv("FRONTEGG_CLIENT_ID") == "" || os.Getenv("FRONTEGG_SECRET") == "" {
+		if _, err := os.Stat(testAccCassettePath(t)); err == nil {
+			return client.RecorderModeReplay
+		}
+	}
+	return ""
+}
+
+// testAccProtoV6ProviderFactories are used to instantiate a provider during acceptance testing.
+// All provider instances of a test share one recorder, so the cassette covers every step.
+func testAccProtoV6ProviderFactories(t *testing.T) map[string]func() (tfprotov6.ProviderServer, error) {
+	var transport http.RoundTripper
+
+	if mode := testAccCassetteMode(t); mode != "" {
+		recorder, err := client.NewRecorder(testAccCassettePath(t), mode, nil)
+		if err != nil {
+			t.Fatalf("Unable to create recorder: %v", err)
+		}
+		t.Cleanup(func() {
+			if err := recorder.Save(); err != nil {
+				t.Errorf("Unable to save cassette: %v", err)
+			}
+		})
+		transport = recorder
+	}
+
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"agentlink": providerserver.NewProtocol6WithError(&FronteggProvider{version: "test", transport: transport}),
+	}
 }
 
 func testAccPreCheck(t *testing.T) {
+	if testAccCassetteMode(t) == client.RecorderModeReplay {
+		return
+	}
 	if os.Getenv("FRONTEGG_CLIENT_ID") == "" {
-		t.Fatal("FRONTEGG_CLIENT_ID must be set for acceptance tests")
+		t.Fatal("FRONTEGG_CLIENT_ID must be set for acceptance tests, or a cassette must exist in " + testAccCassettePath(t))
 	}
 	if os.Getenv("FRONTEGG_SECRET") == "" {
 		t.Fatal("FRONTEGG_SECRET must be set for acceptance tests")
@@ -47,7 +101,7 @@ func testAccPreCheck(t *testing.T) {
 func TestAccApplicationResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesAcc,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
@@ -102,7 +156,7 @@ resource "agentlink_application" "test" {
 func TestAccMcpConfigurationResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesAcc,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: providerConfig() + `
@@ -154,7 +208,7 @@ resource "agentlink_mcp_configuration" "test" {
 func TestAccSourceResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesAcc,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: providerConfig() + `
@@ -238,7 +292,7 @@ func sourceImportStateIdFunc(appResourceName, sourceResourceName string) resourc
 func TestAccRbacPolicyResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesAcc,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: providerConfig() + `
@@ -271,7 +325,7 @@ resource "agentlink_source" "test" {
 func TestAccMaskingPolicyResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesAcc,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: providerConfig() + `
@@ -294,7 +348,7 @@ resource "agentlink_application" "test" {
 func TestAccFullStack(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesAcc,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: providerConfig() + `
@@ -350,7 +404,7 @@ output "source_id" {
 func TestAccAllowedOriginsResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesAcc,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
@@ -396,7 +450,7 @@ resource "agentlink_allowed_origins" "test" {
 func TestAccIdentityConfigurationResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesAcc,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// addClientError reports a failed client call. Errors returned by the Frontegg API are reported
+// with a summary and hint for their status, the messages of the response and the request and
+// trace ID, so the failure can be acted upon or reported to Frontegg support.
+func addClientError(diags *diag.Diagnostics, action string, err error) {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		diags.AddError("Client Error", action+": "+err.Error())
+		return
+	}
+
+	summary, hint := apiErrorGuidance(apiErr.StatusCode)
+
+	message := apiErr.Message()
+	if message == "" {
+		message = http.StatusText(apiErr.StatusCode)
+	}
+	detail := fmt.Sprintf("%s: %s\n\nThe Frontegg API responded with status %d", action, message, apiErr.StatusCode)
+	if apiErr.Path != "" {
+		detail += " to " + apiErr.Method + " " + apiErr.Path
+	}
+	detail += "."
+	if apiErr.TraceID != "" {
+		detail += " Trace ID: " + apiErr.TraceID + "."
+	}
+	if hint != "" {
+		detail += "\n\n" + hint
+	}
+
+	diags.AddError(summary, detail)
+}
+
+// apiErrorGuidance returns the diagnostic summary and a hint on resolving an API error status
+func apiErrorGuidance(statusCode int) (string, string) {
+	switch {
+	case statusCode == http.StatusBadRequest || statusCode == http.StatusUnprocessableEntity:
+		return "Invalid Request", "Frontegg rejected the request. Check the configured values against the messages above."
+	case statusCode == http.StatusUnauthorized:
+		return "Authentication Failed", "Check the client_id and secret of the provider, and that they belong to the environment selected by region or base_url."
+	case statusCode == http.StatusForbidden:
+		return "Permission Denied", "The vendor credentials of the provider are not allowed to perform this operation."
+	case statusCode == http.StatusNotFound:
+		return "Not Found", "The object may have been deleted outside Terraform, or belong to another environment."
+	case statusCode == http.StatusConflict:
+		return "Conflict", "The object already exists or was changed concurrently. Import existing objects instead of creating them, and run terraform plan again to review concurrent changes."
+	case statusCode == http.StatusTooManyRequests:
+		return "Rate Limited", "Frontegg kept rate limiting the request after retrying. Lower max_concurrent_requests, or raise max_retries in the provider configuration."
+	case statusCode >= http.StatusInternalServerError:
+		return "Frontegg API Error", "This is usually a temporary problem on the Frontegg side. Try again later, and include the trace ID when contacting Frontegg support."
+	default:
+		return "Client Error", ""
+	}
+}
+
+// watchMaintenance returns a context recording the Frontegg maintenance windows its requests wait
+// out, and a function reporting them as a warning. Resources defer the function in their CRUD
+// methods, so a paused apply shows which operations waited rather than only logging it.
+func watchMaintenance(ctx context.Context) (context.Context, func(diags *diag.Diagnostics)) {
+	ctx, waits := client.WithMaintenanceWaits(ctx)
+	return ctx, func(diags *diag.Diagnostics) {
+		recorded := waits.Waits()
+		if len(recorded) == 0 {
+			return
+		}
+
+		lines := make([]string, 0, len(recorded))
+		for _, wait := range recorded {
+			lines = append(lines, fmt.Sprintf("- %s waited %s", wait.Operation, wait.Duration))
+		}
+		diags.AddWarning(
+			"Frontegg Maintenance Window",
+			"The Frontegg API reported a maintenance window, so requests were retried until it ended or maintenance_max_wait was used up:\n\n"+strings.Join(lines, "\n"),
+		)
+	}
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestAddClientError(t *testing.T) {
+	var diags diag.Diagnostics
+	addClientError(&diags, "Unable to create source", &client.APIError{
+		Action:     "failed to create source",
+		StatusCode: http.StatusUnprocessableEntity,
+		Body:       `{"message":["name should not be empty"]}`,
+		Messages:   []string{"name should not be empty"},
+		Method:     http.MethodPost,
+		Path:       "/app-integrations/resources/app-mcp-configuration-sources/v1",
+		TraceID:    "trace-abc",
+	})
+
+	if summary := diags.Errors()[0].Summary(); summary != "Invalid Request" {
+		t.Errorf("expected 'Invalid Request', got %q", summary)
+	}
+	detail := diags.Errors()[0].Detail()
+	for _, expected := range []string{
+		"Unable to create source: name should not be empty",
+		"status 422 to POST /app-integrations/resources/app-mcp-configuration-sources/v1",
+		"Trace ID: trace-abc.",
+		"Check the configured values",
+	} {
+		if !strings.Contains(detail, expected) {
+			t.Errorf("expected detail to contain %q, got %q", expected, detail)
+		}
+	}
+
+	// Errors that did not come from the API keep the generic summary
+	diags = diag.Diagnostics{}
+	addClientError(&diags, "Unable to create source", errors.New("connection refused"))
+	if diags.Errors()[0].Summary() != "Client Error" || diags.Errors()[0].Detail() != "Unable to create source: connection refused" {
+		t.Errorf("unexpected diagnostic: %v", diags)
+	}
+}
+
+func TestAPIErrorGuidance(t *testing.T) {
+	tests := map[int]string{
+		http.StatusBadRequest:            "Invalid Request",
+		http.StatusUnauthorized:          "Authentication Failed",
+		http.StatusForbidden:             "Permission Denied",
+		http.StatusNotFound:              "Not Found",
+		http.StatusConflict:              "Conflict",
+		http.StatusTooManyRequests:       "Rate Limited",
+		http.StatusBadGateway:            "Frontegg API Error",
+		http.StatusRequestEntityTooLarge: "Client Error",
+	}
+
+	for status, expected := range tests {
+		if summary, _ := apiErrorGuidance(status); summary != expected {
+			t.Errorf("apiErrorGuidance(%d) = %q, expected %q", status, summary, expected)
+		}
+	}
+}
+
+func TestWatchMaintenance(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/vendor" {
+			_ = json.NewEncoder(w).Encode(client.AuthResponse{Token: "token", ExpiresIn: 3600})
+			return
+		}
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"message":"Scheduled maintenance in progress"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := client.NewClient(server.URL, "client", "secret")
+	c.SetMaintenanceMaxWait(time.Millisecond)
+
+	ctx, maintenance := watchMaintenance(context.Background())
+	resp, err := c.DoRequest(ctx, http.MethodGet, "/applications/resources/applications/v1", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	_ = resp.Body.Close()
+
+	var diags diag.Diagnostics
+	maintenance(&diags)
+	if len(diags) != 1 || diags.WarningsCount() != 1 {
+		t.Fatalf("expected a single maintenance warning, got %v", diags)
+	}
+	if detail := diags[0].Detail(); !strings.Contains(detail, "GET /applications/resources/applications/v1 waited 1ms") {
+		t.Errorf("expected the waiting operation in the warning, got %q", detail)
+	}
+
+	// Requests outside a maintenance window add no warning
+	ctx, maintenance = watchMaintenance(context.Background())
+	resp, err = c.DoRequest(ctx, http.MethodGet, "/applications/resources/applications/v1", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	_ = resp.Body.Close()
+
+	diags = nil
+	maintenance(&diags)
+	if len(diags) != 0 {
+		t.Errorf("expected no warning without maintenance, got %v", diags)
+	}
+}
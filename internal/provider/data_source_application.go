@@ -2,8 +2,11 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 
 	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -23,8 +26,21 @@ type ApplicationDataSource struct {
 
 // ApplicationDataSourceModel describes the data source data model.
 type ApplicationDataSourceModel struct {
-	ID   types.String `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	AppURL        types.String `tfsdk:"app_url"`
+	LoginURL      types.String `tfsdk:"login_url"`
+	Type          types.String `tfsdk:"type"`
+	AccessType    types.String `tfsdk:"access_type"`
+	AllowDcr      types.Bool   `tfsdk:"allow_dcr"`
+	Description   types.String `tfsdk:"description"`
+	IsActive      types.Bool   `tfsdk:"is_active"`
+	IsDefault     types.Bool   `tfsdk:"is_default"`
+	LogoURL       types.String `tfsdk:"logo_url"`
+	FrontendStack types.String `tfsdk:"frontend_stack"`
+	VendorID      types.String `tfsdk:"vendor_id"`
+	AppHost       types.String `tfsdk:"app_host"`
+	Metadata      types.Map    `tfsdk:"metadata"`
 }
 
 func (d *ApplicationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -43,6 +59,59 @@ func (d *ApplicationDataSource) Schema(ctx context.Context, req datasource.Schem
 				Description: "The application name.",
 				Computed:    true,
 			},
+			"app_url": schema.StringAttribute{
+				Description: "The application URL.",
+				Computed:    true,
+			},
+			"login_url": schema.StringAttribute{
+				Description: "The login/OAuth URL.",
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "The application type.",
+				Computed:    true,
+			},
+			"access_type": schema.StringAttribute{
+				Description: "The access type.",
+				Computed:    true,
+			},
+			"allow_dcr": schema.BoolAttribute{
+				Description: "Whether Dynamic Client Registration is enabled.",
+				Computed:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The application description.",
+				Computed:    true,
+			},
+			"is_active": schema.BoolAttribute{
+				Description: "Whether the application is active.",
+				Computed:    true,
+			},
+			"is_default": schema.BoolAttribute{
+				Description: "Whether this is the default application.",
+				Computed:    true,
+			},
+			"logo_url": schema.StringAttribute{
+				Description: "The application logo URL.",
+				Computed:    true,
+			},
+			"frontend_stack": schema.StringAttribute{
+				Description: "The frontend framework.",
+				Computed:    true,
+			},
+			"vendor_id": schema.StringAttribute{
+				Description: "The vendor ID.",
+				Computed:    true,
+			},
+			"app_host": schema.StringAttribute{
+				Description: "The application host, e.g. to build callback URLs.",
+				Computed:    true,
+			},
+			"metadata": schema.MapAttribute{
+				Description: "The application metadata. Values that are not strings are JSON encoded.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
 		},
 	}
 }
@@ -72,19 +141,74 @@ func (d *ApplicationDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	// Return the application ID from the client
-	if d.client.ApplicationID != "" {
-		data.ID = types.StringValue(d.client.ApplicationID)
-	} else {
-		data.ID = types.StringNull()
+	if d.client.ApplicationID == "" {
+		flattenApplicationDataSource(nil, &data)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
 	}
 
-	// Return the application name from the client
-	if d.client.ApplicationName != "" {
-		data.Name = types.StringValue(d.client.ApplicationName)
-	} else {
-		data.Name = types.StringNull()
+	app, err := d.client.GetApplicationByID(ctx, d.client.ApplicationID)
+	if errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError("Not Found", "Application "+d.client.ApplicationID+" not found")
+		return
 	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read application", err)
+		return
+	}
+
+	flattenApplicationDataSource(app, &data)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// flattenApplicationDataSource maps the application onto the data source model. Without an
+// application, e.g. when the provider has not resolved one, all attributes are null.
+func flattenApplicationDataSource(app *client.Application, data *ApplicationDataSourceModel) {
+	if app == nil {
+		*data = ApplicationDataSourceModel{
+			ID:            types.StringNull(),
+			Name:          types.StringNull(),
+			AppURL:        types.StringNull(),
+			LoginURL:      types.StringNull(),
+			Type:          types.StringNull(),
+			AccessType:    types.StringNull(),
+			AllowDcr:      types.BoolNull(),
+			Description:   types.StringNull(),
+			IsActive:      types.BoolNull(),
+			IsDefault:     types.BoolNull(),
+			LogoURL:       types.StringNull(),
+			FrontendStack: types.StringNull(),
+			VendorID:      types.StringNull(),
+			AppHost:       types.StringNull(),
+			Metadata:      types.MapNull(types.StringType),
+		}
+		return
+	}
+
+	data.ID = types.StringValue(app.ID)
+	data.Name = types.StringValue(app.Name)
+	data.AppURL = types.StringValue(app.AppURL)
+	data.LoginURL = types.StringValue(app.LoginURL)
+	data.Type = types.StringValue(app.Type)
+	data.AccessType = types.StringValue(app.AccessType)
+	data.AllowDcr = types.BoolValue(app.AllowDcr)
+	data.Description = types.StringValue(app.Description)
+	data.IsActive = types.BoolValue(app.IsActive)
+	data.IsDefault = types.BoolValue(app.IsDefault)
+	data.LogoURL = types.StringValue(app.LogoURL)
+	data.FrontendStack = types.StringValue(app.FrontendStack)
+	data.VendorID = types.StringValue(app.VendorID)
+	data.AppHost = types.StringValue(app.AppHost)
+
+	metadata := make(map[string]attr.Value, len(app.Metadata))
+	for key, value := range app.Metadata {
+		if str, ok := value.(string); ok {
+			metadata[key] = types.StringValue(str)
+			continue
+		}
+		encoded, _ := json.Marshal(value)
+		metadata[key] = types.StringValue(string(encoded))
+	}
+	data.Metadata = types.MapValueMust(types.StringType, metadata)
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ApplicationByHostDataSource{}
+
+func NewApplicationByHostDataSource() datasource.DataSource {
+	return &ApplicationByHostDataSource{}
+}
+
+// ApplicationByHostDataSource defines the data source implementation. It shares the model of
+// the application data source, with app_host as its input.
+type ApplicationByHostDataSource struct {
+	client *client.Client
+}
+
+func (d *ApplicationByHostDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_by_host"
+}
+
+func (d *ApplicationByHostDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	applicationSchema := &datasource.SchemaResponse{}
+	(&ApplicationDataSource{}).Schema(ctx, req, applicationSchema)
+
+	attributes := make(map[string]schema.Attribute, len(applicationSchema.Schema.Attributes))
+	for name, attribute := range applicationSchema.Schema.Attributes {
+		attributes[name] = attribute
+	}
+	attributes["app_host"] = schema.StringAttribute{
+		Description: "The host of the application to look up, e.g. the public hostname of its MCP server. A URL is accepted too; its scheme and path are ignored. The host is compared case insensitively.",
+		Required:    true,
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Looks up a Frontegg application by its app host, e.g. when only the public MCP hostname of an environment is known.",
+		Attributes:  attributes,
+	}
+}
+
+func (d *ApplicationByHostDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ApplicationByHostDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ApplicationDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	host := data.AppHost.ValueString()
+	app, err := d.client.FindApplicationByHost(ctx, host)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read applications", err)
+		return
+	}
+	if app == nil {
+		resp.Diagnostics.AddError("Not Found", "No application with app host "+host+" exists.")
+		return
+	}
+
+	flattenApplicationDataSource(app, &data)
+	// Keep the configured host, which may differ in case or be a URL
+	data.AppHost = types.StringValue(host)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
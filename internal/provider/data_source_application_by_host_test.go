@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+func TestApplicationByHostDataSourceMetadata(t *testing.T) {
+	d := NewApplicationByHostDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	if resp.TypeName != "agentlink_application_by_host" {
+		t.Errorf("expected type name 'agentlink_application_by_host', got '%s'", resp.TypeName)
+	}
+}
+
+func TestApplicationByHostDataSourceHasExpectedSchema(t *testing.T) {
+	d := NewApplicationByHostDataSource()
+
+	resp := &datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	if host, ok := resp.Schema.Attributes["app_host"].(schema.StringAttribute); !ok || !host.Required {
+		t.Errorf("expected a required app_host attribute, got %+v", resp.Schema.Attributes["app_host"])
+	}
+	for _, attr := range []string{"id", "name", "app_url", "access_type", "metadata"} {
+		if a, ok := resp.Schema.Attributes[attr]; !ok || !a.IsComputed() {
+			t.Errorf("expected computed attribute '%s' in schema", attr)
+		}
+	}
+
+	// The application data source schema is copied, not modified
+	applicationResp := &datasource.SchemaResponse{}
+	NewApplicationDataSource().Schema(context.Background(), datasource.SchemaRequest{}, applicationResp)
+	if applicationResp.Schema.Attributes["app_host"].IsRequired() {
+		t.Errorf("expected app_host to stay computed in the application data source")
+	}
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ApplicationCredentialsDataSource{}
+
+func NewApplicationCredentialsDataSource() datasource.DataSource {
+	return &ApplicationCredentialsDataSource{}
+}
+
+// ApplicationCredentialsDataSource defines the data source implementation.
+type ApplicationCredentialsDataSource struct {
+	client *client.Client
+}
+
+// ApplicationCredentialsDataSourceModel describes the data source data model.
+type ApplicationCredentialsDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	ApplicationID types.String `tfsdk:"application_id"`
+	ClientID      types.String `tfsdk:"client_id"`
+	ClientSecret  types.String `tfsdk:"client_secret"`
+	SharedSecret  types.String `tfsdk:"shared_secret"`
+}
+
+func (d *ApplicationCredentialsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_credentials"
+}
+
+func (d *ApplicationCredentialsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the OAuth client credentials of an application, so other stacks can consume them without owning the application resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The application ID.",
+				Computed:    true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "The ID of the application to fetch the credentials of.",
+				Required:    true,
+			},
+			"client_id": schema.StringAttribute{
+				Description: "The OAuth client ID of the application, which is the application ID.",
+				Computed:    true,
+			},
+			"client_secret": schema.StringAttribute{
+				Description: "The OAuth client secret of the application.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"shared_secret": schema.StringAttribute{
+				Description: "The secret shared between the application and Frontegg, e.g. to verify tokens issued for the application.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (d *ApplicationCredentialsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ApplicationCredentialsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ApplicationCredentialsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID := data.ApplicationID.ValueString()
+	credentials, err := d.client.GetApplicationCredentials(ctx, appID)
+	if errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Application Not Found",
+			fmt.Sprintf("No credentials were found for application %q.", appID),
+		)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read application credentials", err)
+		return
+	}
+
+	data.ID = types.StringValue(appID)
+	data.ClientID = types.StringValue(appID)
+	data.ClientSecret = types.StringValue(credentials.ClientSecret)
+	data.SharedSecret = types.StringValue(credentials.SharedSecret)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+func TestApplicationCredentialsDataSourceHasExpectedSchema(t *testing.T) {
+	d := NewApplicationCredentialsDataSource()
+
+	resp := &datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	attrs := []string{"id", "application_id", "client_id"}
+	for _, attr := range attrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in schema", attr)
+		}
+	}
+
+	for _, attr := range []string{"client_secret", "shared_secret"} {
+		secret, ok := resp.Schema.Attributes[attr].(schema.StringAttribute)
+		if !ok {
+			t.Fatalf("expected '%s' to be a StringAttribute", attr)
+		}
+		if !secret.Sensitive {
+			t.Errorf("expected '%s' to be sensitive", attr)
+		}
+	}
+}
+
+func TestApplicationCredentialsDataSourceMetadata(t *testing.T) {
+	d := NewApplicationCredentialsDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_application_credentials"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
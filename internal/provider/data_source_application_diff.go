@@ -0,0 +1,287 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sort"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ApplicationDiffDataSource{}
+
+func NewApplicationDiffDataSource() datasource.DataSource {
+	return &ApplicationDiffDataSource{}
+}
+
+// ApplicationDiffDataSource defines the data source implementation.
+type ApplicationDiffDataSource struct {
+	client *client.Client
+}
+
+// ApplicationDiffDataSourceModel describes the data source data model.
+type ApplicationDiffDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	FromApplicationID types.String `tfsdk:"from_application_id"`
+	ToApplicationID   types.String `tfsdk:"to_application_id"`
+	MissingSources    types.List   `tfsdk:"missing_sources"`
+	ExtraSources      types.List   `tfsdk:"extra_sources"`
+	ModifiedSources   types.List   `tfsdk:"modified_sources"`
+	MissingTools      types.List   `tfsdk:"missing_tools"`
+	ExtraTools        types.List   `tfsdk:"extra_tools"`
+	ModifiedTools     types.List   `tfsdk:"modified_tools"`
+	MissingPolicies   types.List   `tfsdk:"missing_policies"`
+	ExtraPolicies     types.List   `tfsdk:"extra_policies"`
+	ModifiedPolicies  types.List   `tfsdk:"modified_policies"`
+	HasDifferences    types.Bool   `tfsdk:"has_differences"`
+}
+
+// applicationContents holds the comparable fingerprints of the sources, tools and policies of an
+// application, keyed by name. Tools are keyed by "source_name/tool_name".
+type applicationContents struct {
+	Sources  map[string]string
+	Tools    map[string]string
+	Policies map[string]string
+}
+
+func (d *ApplicationDiffDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_diff"
+}
+
+func (d *ApplicationDiffDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Compares the sources, tools and policies of two applications, e.g. staging and production, so promotion pipelines can check for unexpected differences before applying.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier in the format from_application_id:to_application_id.",
+				Computed:    true,
+			},
+			"from_application_id": schema.StringAttribute{
+				Description: "The ID of the application being promoted, e.g. staging.",
+				Required:    true,
+			},
+			"to_application_id": schema.StringAttribute{
+				Description: "The ID of the application being promoted to, e.g. production.",
+				Required:    true,
+			},
+			"missing_sources": schema.ListAttribute{
+				Description: "Names of sources of the from application that the to application does not have.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"extra_sources": schema.ListAttribute{
+				Description: "Names of sources of the to application that the from application does not have.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"modified_sources": schema.ListAttribute{
+				Description: "Names of sources whose type, URL, timeout or enabled state differ.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"missing_tools": schema.ListAttribute{
+				Description: "Tools of the from application that the to application does not have, as source_name/tool_name.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"extra_tools": schema.ListAttribute{
+				Description: "Tools of the to application that the from application does not have, as source_name/tool_name.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"modified_tools": schema.ListAttribute{
+				Description: "Tools whose description, method, path, input schema, tags or active state differ, as source_name/tool_name.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"missing_policies": schema.ListAttribute{
+				Description: "Names of policies applying to the from application but not to the to application.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"extra_policies": schema.ListAttribute{
+				Description: "Names of policies applying to the to application but not to the from application.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"modified_policies": schema.ListAttribute{
+				Description: "Names of policies applying to each application as separate policies whose settings differ. Policies shared by both applications are never modified.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"has_differences": schema.BoolAttribute{
+				Description: "Whether any source, tool or policy is missing, extra or modified.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *ApplicationDiffDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ApplicationDiffDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ApplicationDiffDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fromAppID := data.FromApplicationID.ValueString()
+	toAppID := data.ToApplicationID.ValueString()
+
+	// Policies are listed once and split by application
+	policies, err := d.client.ListPolicies(ctx, client.PolicyFilters{})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list policies", err)
+		return
+	}
+
+	from := d.readApplicationContents(ctx, fromAppID, policies, &resp.Diagnostics)
+	to := d.readApplicationContents(ctx, toAppID, policies, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sources := diffFingerprints(from.Sources, to.Sources)
+	tools := diffFingerprints(from.Tools, to.Tools)
+	policyDrift := diffFingerprints(from.Policies, to.Policies)
+
+	data.ID = types.StringValue(fromAppID + ":" + toAppID)
+	data.MissingSources = stringList(ctx, sources.Missing, &resp.Diagnostics)
+	data.ExtraSources = stringList(ctx, sources.Extra, &resp.Diagnostics)
+	data.ModifiedSources = stringList(ctx, sources.Modified, &resp.Diagnostics)
+	data.MissingTools = stringList(ctx, tools.Missing, &resp.Diagnostics)
+	data.ExtraTools = stringList(ctx, tools.Extra, &resp.Diagnostics)
+	data.ModifiedTools = stringList(ctx, tools.Modified, &resp.Diagnostics)
+	data.MissingPolicies = stringList(ctx, policyDrift.Missing, &resp.Diagnostics)
+	data.ExtraPolicies = stringList(ctx, policyDrift.Extra, &resp.Diagnostics)
+	data.ModifiedPolicies = stringList(ctx, policyDrift.Modified, &resp.Diagnostics)
+
+	count := 0
+	for _, drift := range []toolsDrift{sources, tools, policyDrift} {
+		count += len(drift.Missing) + len(drift.Extra) + len(drift.Modified)
+	}
+	data.HasDifferences = types.BoolValue(count > 0)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// readApplicationContents reads the sources and tools of an application and fingerprints them
+// together with the policies applying to it
+func (d *ApplicationDiffDataSource) readApplicationContents(ctx context.Context, appID string, policies []client.Policy, diags *diag.Diagnostics) applicationContents {
+	contents := applicationContents{Sources: map[string]string{}, Tools: map[string]string{}, Policies: map[string]string{}}
+
+	sources, err := d.client.GetSources(ctx, appID)
+	if err != nil {
+		addClientError(diags, "Unable to read sources of application "+appID, err)
+		return contents
+	}
+
+	for _, src := range sources {
+		contents.Sources[src.Name] = fingerprint(map[string]interface{}{
+			"type":       src.Type,
+			"sourceUrl":  src.SourceURL,
+			"apiTimeout": src.APITimeout,
+			"enabled":    src.Enabled,
+		})
+
+		tools, err := d.client.GetToolsBySource(ctx, appID, src.ID)
+		if err != nil {
+			addClientError(diags, "Unable to read tools of source "+src.Name, err)
+			return contents
+		}
+		for _, tool := range tools {
+			tags := append([]string{}, tool.Tags...)
+			sort.Strings(tags)
+			contents.Tools[src.Name+"/"+tool.Name] = fingerprint(map[string]interface{}{
+				"description":    tool.Description,
+				"originalMethod": tool.OriginalMethod,
+				"originalPath":   tool.OriginalPath,
+				"schema":         tool.Schema,
+				"tags":           tags,
+				"isActive":       tool.IsActive,
+			})
+		}
+	}
+
+	for _, policy := range policies {
+		if !slices.Contains(policy.AppIDs, appID) {
+			continue
+		}
+		// Application and tool IDs differ between applications by design, so they are not compared
+		policy.ID = ""
+		policy.VendorID = ""
+		policy.AppIDs = nil
+		policy.InternalToolIDs = nil
+		policy.CreatedAt = ""
+		policy.UpdatedAt = ""
+		contents.Policies[policy.Name] = fingerprint(policy)
+	}
+
+	return contents
+}
+
+// diffFingerprints compares two sets of fingerprints by name. Missing names are only in from,
+// extra names only in to, and modified names are in both with different fingerprints.
+func diffFingerprints(from, to map[string]string) toolsDrift {
+	drift := toolsDrift{Missing: []string{}, Extra: []string{}, Modified: []string{}}
+	for name, value := range from {
+		other, ok := to[name]
+		switch {
+		case !ok:
+			drift.Missing = append(drift.Missing, name)
+		case other != value:
+			drift.Modified = append(drift.Modified, name)
+		}
+	}
+	for name := range to {
+		if _, ok := from[name]; !ok {
+			drift.Extra = append(drift.Extra, name)
+		}
+	}
+
+	sort.Strings(drift.Missing)
+	sort.Strings(drift.Extra)
+	sort.Strings(drift.Modified)
+
+	return drift
+}
+
+// fingerprint returns a canonical JSON encoding of value for comparison. Map keys are sorted by
+// encoding/json, so equal values always have equal fingerprints.
+func fingerprint(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
+}
+
+// stringList converts values into a list attribute value
+func stringList(ctx context.Context, values []string, diags *diag.Diagnostics) types.List {
+	list, d := types.ListValueFrom(ctx, types.StringType, values)
+	diags.Append(d...)
+	return list
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestApplicationDiffDataSourceHasExpectedSchema(t *testing.T) {
+	d := NewApplicationDiffDataSource()
+
+	resp := &datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	attrs := []string{
+		"id", "from_application_id", "to_application_id",
+		"missing_sources", "extra_sources", "modified_sources",
+		"missing_tools", "extra_tools", "modified_tools",
+		"missing_policies", "extra_policies", "modified_policies", "has_differences",
+	}
+	for _, attr := range attrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in schema", attr)
+		}
+	}
+}
+
+func TestApplicationDiffDataSourceMetadata(t *testing.T) {
+	d := NewApplicationDiffDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_application_diff"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func TestDiffFingerprints(t *testing.T) {
+	from := map[string]string{
+		"orders/list_orders": fingerprint(map[string]interface{}{"description": "List orders", "isActive": true}),
+		"orders/get_order":   fingerprint(map[string]interface{}{"description": "Get an order", "isActive": true}),
+		"orders/cancel":      fingerprint(map[string]interface{}{"description": "Cancel", "isActive": true}),
+	}
+	to := map[string]string{
+		"orders/list_orders": fingerprint(map[string]interface{}{"isActive": true, "description": "List orders"}),
+		"orders/get_order":   fingerprint(map[string]interface{}{"description": "Get an order", "isActive": false}),
+		"orders/refund":      fingerprint(map[string]interface{}{"description": "Refund", "isActive": true}),
+	}
+
+	drift := diffFingerprints(from, to)
+
+	if !reflect.DeepEqual(drift.Missing, []string{"orders/cancel"}) {
+		t.Errorf("unexpected missing: %v", drift.Missing)
+	}
+	if !reflect.DeepEqual(drift.Extra, []string{"orders/refund"}) {
+		t.Errorf("unexpected extra: %v", drift.Extra)
+	}
+	if !reflect.DeepEqual(drift.Modified, []string{"orders/get_order"}) {
+		t.Errorf("unexpected modified: %v", drift.Modified)
+	}
+
+	empty := diffFingerprints(map[string]string{}, map[string]string{})
+	if empty.Missing == nil || empty.Extra == nil || empty.Modified == nil {
+		t.Errorf("expected empty lists, got %+v", empty)
+	}
+}
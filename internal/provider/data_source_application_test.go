@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestApplicationDataSourceHasExpectedSchema(t *testing.T) {
+	d := NewApplicationDataSource()
+
+	resp := &datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	for _, attr := range []string{"id", "name", "access_type", "allow_dcr", "app_host", "metadata"} {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in schema", attr)
+		}
+	}
+}
+
+func TestFlattenApplicationDataSource(t *testing.T) {
+	app := &client.Application{
+		ID:         "app-1",
+		Name:       "My Agent",
+		AccessType: "MANAGED_ACCESS",
+		AllowDcr:   true,
+		AppHost:    "my-agent.frontegg.com",
+		Metadata: map[string]interface{}{
+			"team":  "support",
+			"tiers": []interface{}{"gold", "silver"},
+		},
+	}
+
+	var data ApplicationDataSourceModel
+	flattenApplicationDataSource(app, &data)
+
+	if data.AppHost.ValueString() != "my-agent.frontegg.com" {
+		t.Errorf("expected app_host 'my-agent.frontegg.com', got '%s'", data.AppHost.ValueString())
+	}
+	if data.AccessType.ValueString() != "MANAGED_ACCESS" || !data.AllowDcr.ValueBool() {
+		t.Errorf("expected access_type and allow_dcr to be set, got %v and %v", data.AccessType, data.AllowDcr)
+	}
+
+	metadata := data.Metadata.Elements()
+	if metadata["team"] != types.StringValue("support") {
+		t.Errorf("expected metadata team 'support', got %v", metadata["team"])
+	}
+	if metadata["tiers"] != types.StringValue(`["gold","silver"]`) {
+		t.Errorf("expected JSON encoded metadata tiers, got %v", metadata["tiers"])
+	}
+
+	flattenApplicationDataSource(nil, &data)
+	if !data.ID.IsNull() || !data.AppHost.IsNull() || !data.Metadata.IsNull() {
+		t.Errorf("expected null attributes without an application, got %+v", data)
+	}
+}
@@ -0,0 +1,295 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ExportDataSource{}
+
+func NewExportDataSource() datasource.DataSource {
+	return &ExportDataSource{}
+}
+
+// ExportDataSource defines the data source implementation.
+type ExportDataSource struct {
+	client *client.Client
+}
+
+// ExportDataSourceModel describes the data source data model.
+type ExportDataSourceModel struct {
+	ID            types.String            `tfsdk:"id"`
+	ResourceTypes types.Set               `tfsdk:"resource_types"`
+	Resources     []ExportedResourceModel `tfsdk:"resources"`
+	ImportBlocks  types.String            `tfsdk:"import_blocks"`
+}
+
+// ExportedResourceModel describes an existing object that can be imported.
+type ExportedResourceModel struct {
+	Type     types.String `tfsdk:"type"`
+	Name     types.String `tfsdk:"name"`
+	Address  types.String `tfsdk:"address"`
+	ImportID types.String `tfsdk:"import_id"`
+}
+
+func (d *ExportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_export"
+}
+
+func (d *ExportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Enumerates the vendor settings, applications, sources, MCP configurations and policies of the vendor and emits their resource addresses and import IDs, to bootstrap managing an existing vendor with Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The client ID the export was generated with.",
+				Computed:    true,
+			},
+			"resource_types": schema.SetAttribute{
+				Description: "Only export resources of these types, e.g. agentlink_source. Defaults to every exported type.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"resources": schema.ListNestedAttribute{
+				Description: "The existing objects, in a stable order.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "The resource type, e.g. agentlink_application.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "A resource name derived from the object name, unique per resource type.",
+							Computed:    true,
+						},
+						"address": schema.StringAttribute{
+							Description: "The resource address (type.name).",
+							Computed:    true,
+						},
+						"import_id": schema.StringAttribute{
+							Description: "The ID to import the object with.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"import_blocks": schema.StringAttribute{
+				Description: "Terraform import blocks for all resources. Write them to a file and run terraform plan -generate-config-out to generate the matching configuration.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *ExportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ExportDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exporter := newResourceExporter(expandStringSet(ctx, data.ResourceTypes, &resp.Diagnostics))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vendor, err := d.client.GetVendorConfig(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read vendor config", err)
+		return
+	}
+	exporter.add("agentlink_vendor_settings", "this", vendor.ID)
+	exporter.add("agentlink_allowed_origins", "this", vendor.ID)
+
+	identity, err := d.client.GetIdentityConfiguration(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read identity configuration", err)
+		return
+	}
+	exporter.add("agentlink_jwt_signing_configuration", "this", identity.ID)
+
+	_, err = d.client.GetSmsConfiguration(ctx)
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		addClientError(&resp.Diagnostics, "Unable to read SMS configuration", err)
+		return
+	}
+	if err == nil {
+		exporter.add("agentlink_sms_provider", "this", d.client.ClientID())
+	}
+
+	applications, err := d.client.GetApplications(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list applications", err)
+		return
+	}
+	sort.Slice(applications, func(i, j int) bool {
+		return applications[i].Name+applications[i].ID < applications[j].Name+applications[j].ID
+	})
+
+	for _, app := range applications {
+		appName := exporter.add("agentlink_application", app.Name, app.ID)
+
+		_, err = d.client.GetMcpConfiguration(ctx, app.ID)
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			addClientError(&resp.Diagnostics, "Unable to read MCP configuration", err)
+			return
+		}
+		if err == nil {
+			exporter.add("agentlink_mcp_configuration", appName, app.ID)
+		}
+
+		sources, err := d.client.GetSources(ctx, app.ID)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "Unable to list sources", err)
+			return
+		}
+		sort.Slice(sources, func(i, j int) bool {
+			return sources[i].Name+sources[i].ID < sources[j].Name+sources[j].ID
+		})
+		for _, source := range sources {
+			exporter.add("agentlink_source", appName+"_"+source.Name, app.ID+":"+source.ID)
+		}
+	}
+
+	policyLists := []struct {
+		resourceType string
+		list         func(context.Context) ([]client.Policy, error)
+	}{
+		{"agentlink_conditional_policy", d.client.GetConditionalPolicies},
+		{"agentlink_rbac_policy", d.client.GetRbacPolicies},
+		{"agentlink_masking_policy", d.client.GetMaskingPolicies},
+	}
+
+	seenPolicies := map[string]bool{}
+	for _, policyList := range policyLists {
+		policies, err := policyList.list(ctx)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "Unable to list policies", err)
+			return
+		}
+		sort.Slice(policies, func(i, j int) bool {
+			return policies[i].Name+policies[i].ID < policies[j].Name+policies[j].ID
+		})
+		for _, policy := range policies {
+			if seenPolicies[policy.ID] {
+				continue
+			}
+			seenPolicies[policy.ID] = true
+			exporter.add(policyList.resourceType, policy.Name, policy.ID)
+		}
+	}
+
+	data.ID = types.StringValue(d.client.ClientID())
+	data.Resources = exporter.resources
+	data.ImportBlocks = types.StringValue(exporter.importBlocks())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// resourceExporter collects exported resources and assigns them unique names
+type resourceExporter struct {
+	resources []ExportedResourceModel
+	used      map[string]bool
+	types     map[string]bool
+}
+
+// newResourceExporter returns an exporter that keeps resources of the given types, or of every
+// type when none are given
+func newResourceExporter(resourceTypes []string) *resourceExporter {
+	e := &resourceExporter{
+		resources: []ExportedResourceModel{},
+		used:      map[string]bool{},
+	}
+	if len(resourceTypes) > 0 {
+		e.types = map[string]bool{}
+		for _, resourceType := range resourceTypes {
+			e.types[resourceType] = true
+		}
+	}
+	return e
+}
+
+// add records a resource and returns the name assigned to it. Names are assigned to filtered out
+// resources too, so the names of dependent resources do not depend on the filter.
+func (e *resourceExporter) add(resourceType, name, importID string) string {
+	base := exportResourceName(name)
+	unique := base
+	for i := 2; e.used[resourceType+"."+unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", base, i)
+	}
+	e.used[resourceType+"."+unique] = true
+
+	if e.types != nil && !e.types[resourceType] {
+		return unique
+	}
+
+	e.resources = append(e.resources, ExportedResourceModel{
+		Type:     types.StringValue(resourceType),
+		Name:     types.StringValue(unique),
+		Address:  types.StringValue(resourceType + "." + unique),
+		ImportID: types.StringValue(importID),
+	})
+	return unique
+}
+
+// importBlocks renders an import block for every exported resource
+func (e *resourceExporter) importBlocks() string {
+	blocks := make([]string, 0, len(e.resources))
+	for _, r := range e.resources {
+		blocks = append(blocks, fmt.Sprintf("import {\n  to = %s\n  id = %q\n}\n", r.Address.ValueString(), r.ImportID.ValueString()))
+	}
+	return strings.Join(blocks, "\n")
+}
+
+// exportResourceName converts an object name into a valid Terraform resource name
+func exportResourceName(name string) string {
+	var b strings.Builder
+	pendingSeparator := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pendingSeparator && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			pendingSeparator = false
+			b.WriteRune(r)
+			continue
+		}
+		pendingSeparator = true
+	}
+
+	result := b.String()
+	if result == "" {
+		return "unnamed"
+	}
+	if result[0] >= '0' && result[0] <= '9' {
+		return "r_" + result
+	}
+	return result
+}
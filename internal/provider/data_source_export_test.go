@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestExportDataSourceHasExpectedSchema(t *testing.T) {
+	d := NewExportDataSource()
+
+	resp := &datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	for _, attr := range []string{"id", "resource_types", "resources", "import_blocks"} {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected '%s' attribute in schema", attr)
+		}
+	}
+}
+
+func TestExportDataSourceMetadata(t *testing.T) {
+	d := NewExportDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_export"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func TestExportResourceName(t *testing.T) {
+	tests := map[string]string{
+		"My AI Agent":    "my_ai_agent",
+		"  REST -- API ": "rest_api",
+		"2024 Policy":    "r_2024_policy",
+		"!!!":            "unnamed",
+	}
+
+	for name, expected := range tests {
+		if got := exportResourceName(name); got != expected {
+			t.Errorf("exportResourceName(%q) = %q, expected %q", name, got, expected)
+		}
+	}
+}
+
+func TestResourceExporter(t *testing.T) {
+	e := newResourceExporter(nil)
+
+	first := e.add("agentlink_application", "My App", "app-1")
+	second := e.add("agentlink_application", "my-app", "app-2")
+	config := e.add("agentlink_mcp_configuration", first, "app-1")
+
+	if first != "my_app" || second != "my_app_2" {
+		t.Errorf("expected unique names per type, got %q and %q", first, second)
+	}
+	if config != "my_app" {
+		t.Errorf("expected names to be unique per type only, got %q", config)
+	}
+
+	expected := `import {
+  to = agentlink_application.my_app
+  id = "app-1"
+}
+
+import {
+  to = agentlink_application.my_app_2
+  id = "app-2"
+}
+
+import {
+  to = agentlink_mcp_configuration.my_app
+  id = "app-1"
+}
+`
+	if got := e.importBlocks(); got != expected {
+		t.Errorf("unexpected import blocks:\n%s", got)
+	}
+}
+
+func TestResourceExporterFiltersResourceTypes(t *testing.T) {
+	e := newResourceExporter([]string{"agentlink_source"})
+
+	app := e.add("agentlink_application", "My App", "app-1")
+	source := e.add("agentlink_source", app+"_orders", "app-1:src-1")
+	e.add("agentlink_rbac_policy", "Admins", "policy-1")
+
+	if app != "my_app" || source != "my_app_orders" {
+		t.Errorf("expected names to be assigned to filtered resources, got %q and %q", app, source)
+	}
+	if len(e.resources) != 1 || e.resources[0].ImportID.ValueString() != "app-1:src-1" {
+		t.Errorf("expected only the source to be exported, got %+v", e.resources)
+	}
+}
@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &InternalToolsDataSource{}
+
+func NewInternalToolsDataSource() datasource.DataSource {
+	return &InternalToolsDataSource{}
+}
+
+// InternalToolsDataSource defines the data source implementation.
+type InternalToolsDataSource struct {
+	client *client.Client
+}
+
+// InternalToolsDataSourceModel describes the data source data model.
+type InternalToolsDataSourceModel struct {
+	ID            types.String           `tfsdk:"id"`
+	ApplicationID types.String           `tfsdk:"application_id"`
+	SourceType    types.String           `tfsdk:"source_type"`
+	ToolCount     types.Int64            `tfsdk:"tool_count"`
+	Sources       []ToolSourceGroupModel `tfsdk:"sources"`
+}
+
+// ToolSourceGroupModel describes the tools of a single source returned by the data source.
+type ToolSourceGroupModel struct {
+	SourceID        types.String             `tfsdk:"source_id"`
+	SourceName      types.String             `tfsdk:"source_name"`
+	SourceType      types.String             `tfsdk:"source_type"`
+	ToolCount       types.Int64              `tfsdk:"tool_count"`
+	ActiveToolCount types.Int64              `tfsdk:"active_tool_count"`
+	Tools           []InternalToolEntryModel `tfsdk:"tools"`
+}
+
+// InternalToolEntryModel describes a single tool returned by the data source.
+type InternalToolEntryModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Method      types.String `tfsdk:"method"`
+	Path        types.String `tfsdk:"path"`
+	IsActive    types.Bool   `tfsdk:"is_active"`
+	Tags        types.List   `tfsdk:"tags"`
+}
+
+func (d *InternalToolsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_internal_tools"
+}
+
+func (d *InternalToolsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the tools of an application grouped by source, with per-source counts, e.g. for dashboards or preconditions such as every REST source exposing at least one tool.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The application ID.",
+				Computed:    true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "The ID of the application to list the tools of.",
+				Required:    true,
+			},
+			"source_type": schema.StringAttribute{
+				Description: "Only return the tools of sources of this type, e.g. REST or GRAPHQL.",
+				Optional:    true,
+			},
+			"tool_count": schema.Int64Attribute{
+				Description: "The total number of tools across the returned sources.",
+				Computed:    true,
+			},
+			"sources": schema.ListNestedAttribute{
+				Description: "The sources with their tools, sorted by source name. Sources without tools are included with a count of 0.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source_id": schema.StringAttribute{
+							Description: "The source ID.",
+							Computed:    true,
+						},
+						"source_name": schema.StringAttribute{
+							Description: "The source name.",
+							Computed:    true,
+						},
+						"source_type": schema.StringAttribute{
+							Description: "The source type.",
+							Computed:    true,
+						},
+						"tool_count": schema.Int64Attribute{
+							Description: "The number of tools imported from the source.",
+							Computed:    true,
+						},
+						"active_tool_count": schema.Int64Attribute{
+							Description: "The number of active tools imported from the source.",
+							Computed:    true,
+						},
+						"tools": schema.ListNestedAttribute{
+							Description: "The tools imported from the source, sorted by name.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										Description: "The internal tool ID.",
+										Computed:    true,
+									},
+									"name": schema.StringAttribute{
+										Description: "The tool name.",
+										Computed:    true,
+									},
+									"description": schema.StringAttribute{
+										Description: "The tool description.",
+										Computed:    true,
+									},
+									"method": schema.StringAttribute{
+										Description: "The HTTP method of the operation the tool was imported from.",
+										Computed:    true,
+									},
+									"path": schema.StringAttribute{
+										Description: "The path of the operation the tool was imported from.",
+										Computed:    true,
+									},
+									"is_active": schema.BoolAttribute{
+										Description: "Whether the tool is active.",
+										Computed:    true,
+									},
+									"tags": schema.ListAttribute{
+										Description: "The tool tags.",
+										Computed:    true,
+										ElementType: types.StringType,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *InternalToolsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *InternalToolsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data InternalToolsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID := data.ApplicationID.ValueString()
+	sources, err := d.client.GetSources(ctx, appID)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read sources", err)
+		return
+	}
+
+	data.ID = types.StringValue(appID)
+	data.Sources = []ToolSourceGroupModel{}
+	total := 0
+	for _, src := range filterSources(sources, sourceFilter{Type: data.SourceType.ValueString()}) {
+		tools, err := d.client.GetToolsBySource(ctx, appID, src.ID)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "Unable to read tools of source "+src.Name, err)
+			return
+		}
+
+		data.Sources = append(data.Sources, flattenToolSourceGroup(ctx, src, tools, &resp.Diagnostics))
+		total += len(tools)
+	}
+	data.ToolCount = types.Int64Value(int64(total))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// flattenToolSourceGroup maps a source and its tools onto the data source model, sorting the
+// tools by name
+func flattenToolSourceGroup(ctx context.Context, src client.Source, tools []client.InternalTool, diags *diag.Diagnostics) ToolSourceGroupModel {
+	sorted := append([]client.InternalTool{}, tools...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	group := ToolSourceGroupModel{
+		SourceID:   types.StringValue(src.ID),
+		SourceName: types.StringValue(src.Name),
+		SourceType: types.StringValue(src.Type),
+		ToolCount:  types.Int64Value(int64(len(sorted))),
+		Tools:      []InternalToolEntryModel{},
+	}
+
+	active := 0
+	for _, tool := range sorted {
+		if tool.IsActive {
+			active++
+		}
+
+		tags := tool.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		group.Tools = append(group.Tools, InternalToolEntryModel{
+			ID:          types.StringValue(tool.ID),
+			Name:        types.StringValue(tool.Name),
+			Description: types.StringValue(tool.Description),
+			Method:      types.StringValue(tool.OriginalMethod),
+			Path:        types.StringValue(tool.OriginalPath),
+			IsActive:    types.BoolValue(tool.IsActive),
+			Tags:        stringList(ctx, tags, diags),
+		})
+	}
+	group.ActiveToolCount = types.Int64Value(int64(active))
+
+	return group
+}
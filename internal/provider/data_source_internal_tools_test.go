@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestInternalToolsDataSourceHasExpectedSchema(t *testing.T) {
+	d := NewInternalToolsDataSource()
+
+	resp := &datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	for _, attr := range []string{"id", "application_id", "source_type", "tool_count", "sources"} {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in schema", attr)
+		}
+	}
+}
+
+func TestInternalToolsDataSourceMetadata(t *testing.T) {
+	d := NewInternalToolsDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_internal_tools"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func TestFlattenToolSourceGroup(t *testing.T) {
+	var diags diag.Diagnostics
+	group := flattenToolSourceGroup(context.Background(), client.Source{ID: "src-1", Name: "orders-api", Type: "REST"}, []client.InternalTool{
+		{ID: "tool-2", Name: "list_orders", OriginalMethod: "GET", OriginalPath: "/orders", IsActive: true, Tags: []string{"orders"}},
+		{ID: "tool-1", Name: "cancel_order", OriginalMethod: "POST", OriginalPath: "/orders/{id}/cancel"},
+	}, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if group.SourceName.ValueString() != "orders-api" || group.ToolCount.ValueInt64() != 2 || group.ActiveToolCount.ValueInt64() != 1 {
+		t.Errorf("unexpected counts: %+v", group)
+	}
+	if len(group.Tools) != 2 || group.Tools[0].Name.ValueString() != "cancel_order" {
+		t.Errorf("expected tools sorted by name, got %+v", group.Tools)
+	}
+	if len(group.Tools[0].Tags.Elements()) != 0 || len(group.Tools[1].Tags.Elements()) != 1 {
+		t.Errorf("unexpected tags: %s, %s", group.Tools[0].Tags, group.Tools[1].Tags)
+	}
+
+	empty := flattenToolSourceGroup(context.Background(), client.Source{ID: "src-2", Name: "empty-api"}, nil, &diags)
+	if empty.ToolCount.ValueInt64() != 0 || len(empty.Tools) != 0 {
+		t.Errorf("expected an empty group, got %+v", empty)
+	}
+}
@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &JwksDataSource{}
+
+func NewJwksDataSource() datasource.DataSource {
+	return &JwksDataSource{}
+}
+
+// JwksDataSource defines the data source implementation.
+type JwksDataSource struct {
+	client *client.Client
+}
+
+// JwksDataSourceModel describes the data source data model.
+type JwksDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	ApplicationID types.String `tfsdk:"application_id"`
+	Issuer        types.String `tfsdk:"issuer"`
+	Audience      types.String `tfsdk:"audience"`
+	JwksURI       types.String `tfsdk:"jwks_uri"`
+	Keys          []JwkModel   `tfsdk:"keys"`
+	JSON          types.String `tfsdk:"json"`
+}
+
+// JwkModel describes a single JSON Web Key.
+type JwkModel struct {
+	Kid types.String `tfsdk:"kid"`
+	Kty types.String `tfsdk:"kty"`
+	Alg types.String `tfsdk:"alg"`
+	Use types.String `tfsdk:"use"`
+	N   types.String `tfsdk:"n"`
+	E   types.String `tfsdk:"e"`
+}
+
+// jwk is the JSON representation of a JSON Web Key
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+func (d *JwksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jwks"
+}
+
+func (d *JwksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exposes the token verification material of the vendor (JWKS, issuer and audience) for relying-party services.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The JWKS URI.",
+				Computed:    true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "The application whose tokens are verified. Sets the audience to the application ID instead of the vendor client ID.",
+				Optional:    true,
+			},
+			"issuer": schema.StringAttribute{
+				Description: "The token issuer (iss claim).",
+				Computed:    true,
+			},
+			"audience": schema.StringAttribute{
+				Description: "The token audience (aud claim).",
+				Computed:    true,
+			},
+			"jwks_uri": schema.StringAttribute{
+				Description: "The URL of the published JWKS document.",
+				Computed:    true,
+			},
+			"keys": schema.ListNestedAttribute{
+				Description: "The public signing keys. Empty when tokens are signed with HS256.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"kid": schema.StringAttribute{
+							Description: "The key ID (RFC 7638 JWK thumbprint).",
+							Computed:    true,
+						},
+						"kty": schema.StringAttribute{
+							Description: "The key type.",
+							Computed:    true,
+						},
+						"alg": schema.StringAttribute{
+							Description: "The signing algorithm.",
+							Computed:    true,
+						},
+						"use": schema.StringAttribute{
+							Description: "The key usage.",
+							Computed:    true,
+						},
+						"n": schema.StringAttribute{
+							Description: "The RSA modulus (base64url).",
+							Computed:    true,
+						},
+						"e": schema.StringAttribute{
+							Description: "The RSA public exponent (base64url).",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"json": schema.StringAttribute{
+				Description: "The JWKS document as JSON.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *JwksDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *JwksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwksDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vendor, err := d.client.GetVendorConfig(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read vendor config", err)
+		return
+	}
+
+	identity, err := d.client.GetIdentityConfiguration(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read identity configuration", err)
+		return
+	}
+
+	// Tokens are issued by the verified custom domain when there is one
+	host := vendor.Host
+	if vendor.CustomDomain != "" && vendor.CustomDomainVerified {
+		host = vendor.CustomDomain
+	}
+	if host == "" {
+		resp.Diagnostics.AddError("Vendor Host Unavailable", "The vendor has no host assigned, so the token issuer cannot be determined.")
+		return
+	}
+	issuer := hostBaseURL(host)
+
+	audience := d.client.ClientID()
+	if !data.ApplicationID.IsNull() && data.ApplicationID.ValueString() != "" {
+		audience = data.ApplicationID.ValueString()
+	}
+
+	keys := []jwk{}
+	if identity.JwtAlgorithm != "HS256" && identity.PublicKey != "" {
+		key, err := publicKeyJwk(identity.PublicKey, identity.JwtAlgorithm)
+		if err != nil {
+			resp.Diagnostics.AddError("Signing Key Error", "Unable to convert the public key to a JWK: "+err.Error())
+			return
+		}
+		keys = append(keys, key)
+	}
+
+	document, err := json.Marshal(map[string][]jwk{"keys": keys})
+	if err != nil {
+		resp.Diagnostics.AddError("Signing Key Error", "Unable to encode JWKS: "+err.Error())
+		return
+	}
+
+	data.JwksURI = types.StringValue(issuer + "/.well-known/jwks.json")
+	data.ID = data.JwksURI
+	data.Issuer = types.StringValue(issuer)
+	data.Audience = types.StringValue(audience)
+	data.JSON = types.StringValue(string(document))
+	data.Keys = make([]JwkModel, 0, len(keys))
+	for _, key := range keys {
+		data.Keys = append(data.Keys, JwkModel{
+			Kid: types.StringValue(key.Kid),
+			Kty: types.StringValue(key.Kty),
+			Alg: types.StringValue(key.Alg),
+			Use: types.StringValue(key.Use),
+			N:   types.StringValue(key.N),
+			E:   types.StringValue(key.E),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// publicKeyJwk converts an RSA public key into its JWK representation
+func publicKeyJwk(publicKey, algorithm string) (jwk, error) {
+	key, err := parseRSAPublicKey(publicKey)
+	if err != nil {
+		return jwk{}, err
+	}
+
+	kid, err := signingKeyID(publicKey)
+	if err != nil {
+		return jwk{}, err
+	}
+
+	if algorithm == "" {
+		algorithm = "RS256"
+	}
+
+	return jwk{
+		Kid: kid,
+		Kty: "RSA",
+		Alg: algorithm,
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}, nil
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestJwksDataSourceHasExpectedSchema(t *testing.T) {
+	d := NewJwksDataSource()
+
+	resp := &datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	// Check optional attribute
+	if _, ok := resp.Schema.Attributes["application_id"]; !ok {
+		t.Error("expected attribute 'application_id' in schema")
+	}
+
+	// Check computed attributes
+	computedAttrs := []string{"id", "issuer", "audience", "jwks_uri", "keys", "json"}
+	for _, attr := range computedAttrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected computed attribute '%s' in schema", attr)
+		}
+	}
+}
+
+func TestJwksDataSourceMetadata(t *testing.T) {
+	d := NewJwksDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_jwks"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func TestPublicKeyJwk(t *testing.T) {
+	modulus, err := base64.RawURLEncoding.DecodeString(rfc7638ExampleModulus)
+	if err != nil {
+		t.Fatalf("failed to decode modulus: %v", err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: 65537})
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+
+	key, err := publicKeyJwk(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if key.Kid != "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs" {
+		t.Errorf("unexpected kid '%s'", key.Kid)
+	}
+	if key.Kty != "RSA" || key.Alg != "RS256" || key.Use != "sig" {
+		t.Errorf("unexpected key metadata: %+v", key)
+	}
+	if key.N != rfc7638ExampleModulus {
+		t.Errorf("unexpected modulus '%s'", key.N)
+	}
+	if key.E != "AQAB" {
+		t.Errorf("expected exponent 'AQAB', got '%s'", key.E)
+	}
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// MCP gateway endpoint paths, relative to the application host
+const (
+	mcpStreamableHTTPPath = "/mcp"
+	mcpSSEPath            = "/sse"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &McpServerDataSource{}
+
+func NewMcpServerDataSource() datasource.DataSource {
+	return &McpServerDataSource{}
+}
+
+// McpServerDataSource defines the data source implementation.
+type McpServerDataSource struct {
+	client *client.Client
+}
+
+// McpServerDataSourceModel describes the data source data model.
+type McpServerDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	ApplicationID types.String `tfsdk:"application_id"`
+	AppHost       types.String `tfsdk:"app_host"`
+	URL           types.String `tfsdk:"url"`
+	Transport     types.String `tfsdk:"transport"`
+	SSEURL        types.String `tfsdk:"sse_url"`
+	AllowDcr      types.Bool   `tfsdk:"allow_dcr"`
+}
+
+func (d *McpServerDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mcp_server"
+}
+
+func (d *McpServerDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exposes the externally reachable MCP server endpoint of an application, for use in agent runtime configuration and client manifests.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The application ID.",
+				Computed:    true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "The application ID to expose the MCP server for.",
+				Required:    true,
+			},
+			"app_host": schema.StringAttribute{
+				Description: "The application host assigned by Frontegg.",
+				Computed:    true,
+			},
+			"url": schema.StringAttribute{
+				Description: "The MCP server URL for the streamable HTTP transport.",
+				Computed:    true,
+			},
+			"transport": schema.StringAttribute{
+				Description: "The recommended MCP transport for url. Always streamable-http.",
+				Computed:    true,
+			},
+			"sse_url": schema.StringAttribute{
+				Description: "The MCP server URL for clients that only support the legacy SSE transport.",
+				Computed:    true,
+			},
+			"allow_dcr": schema.BoolAttribute{
+				Description: "Whether MCP clients can register themselves through Dynamic Client Registration.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *McpServerDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *McpServerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data McpServerDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	app, err := d.client.GetApplicationByID(ctx, data.ApplicationID.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError("Not Found", "Application "+data.ApplicationID.ValueString()+" not found")
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read application", err)
+		return
+	}
+
+	if app.AppHost == "" {
+		resp.Diagnostics.AddError(
+			"MCP Server Unavailable",
+			"Application "+app.ID+" has no host assigned yet, so its MCP server is not reachable.",
+		)
+		return
+	}
+
+	baseURL := hostBaseURL(app.AppHost)
+
+	data.ID = types.StringValue(app.ID)
+	data.AppHost = types.StringValue(app.AppHost)
+	data.URL = types.StringValue(baseURL + mcpStreamableHTTPPath)
+	data.Transport = types.StringValue("streamable-http")
+	data.SSEURL = types.StringValue(baseURL + mcpSSEPath)
+	data.AllowDcr = types.BoolValue(app.AllowDcr)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// hostBaseURL returns the HTTPS base URL for a Frontegg host, which may or may not include a scheme
+func hostBaseURL(host string) string {
+	host = strings.TrimSuffix(host, "/")
+	if strings.HasPrefix(host, "https://") || strings.HasPrefix(host, "http://") {
+		return host
+	}
+	return "https://" + host
+}
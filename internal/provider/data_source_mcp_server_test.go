@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestMcpServerDataSourceHasExpectedSchema(t *testing.T) {
+	d := NewMcpServerDataSource()
+
+	resp := &datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	// Check required attributes
+	if _, ok := resp.Schema.Attributes["application_id"]; !ok {
+		t.Error("expected attribute 'application_id' in schema")
+	}
+
+	// Check computed attributes
+	computedAttrs := []string{"id", "app_host", "url", "transport", "sse_url", "allow_dcr"}
+	for _, attr := range computedAttrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected computed attribute '%s' in schema", attr)
+		}
+	}
+}
+
+func TestMcpServerDataSourceMetadata(t *testing.T) {
+	d := NewMcpServerDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_mcp_server"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func TestHostBaseURL(t *testing.T) {
+	tests := map[string]string{
+		"my-app.frontegg.com":          "https://my-app.frontegg.com",
+		"https://my-app.frontegg.com/": "https://my-app.frontegg.com",
+		"http://localhost:3000":        "http://localhost:3000",
+	}
+
+	for host, expected := range tests {
+		if got := hostBaseURL(host); got != expected {
+			t.Errorf("hostBaseURL(%q): expected '%s', got '%s'", host, expected, got)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PolicyDataSource{}
+
+func NewConditionalPolicyDataSource() datasource.DataSource {
+	return &PolicyDataSource{
+		typeName: "conditional_policy",
+		kind:     "conditional policy",
+		list:     (*client.Client).GetConditionalPolicies,
+		// The conditional policy endpoint lists every policy of the vendor
+		match: func(policy client.Policy) bool {
+			return !isRbacPolicyType(policy.Type) && policy.PolicyConfiguration == nil
+		},
+	}
+}
+
+func NewRbacPolicyDataSource() datasource.DataSource {
+	return &PolicyDataSource{
+		typeName: "rbac_policy",
+		kind:     "RBAC policy",
+		list:     (*client.Client).GetRbacPolicies,
+		match: func(policy client.Policy) bool {
+			return isRbacPolicyType(policy.Type)
+		},
+	}
+}
+
+func NewMaskingPolicyDataSource() datasource.DataSource {
+	return &PolicyDataSource{
+		typeName: "masking_policy",
+		kind:     "masking policy",
+		list:     (*client.Client).GetMaskingPolicies,
+		match: func(policy client.Policy) bool {
+			return !isRbacPolicyType(policy.Type)
+		},
+	}
+}
+
+// PolicyDataSource looks up a single policy of one kind by name.
+type PolicyDataSource struct {
+	client   *client.Client
+	typeName string
+	kind     string
+	list     func(*client.Client, context.Context) ([]client.Policy, error)
+	match    func(client.Policy) bool
+}
+
+// PolicyDataSourceModel describes the data source data model.
+type PolicyDataSourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	Type            types.String `tfsdk:"type"`
+	Enabled         types.Bool   `tfsdk:"enabled"`
+	AppIDs          []string     `tfsdk:"app_ids"`
+	TenantIDs       []string     `tfsdk:"tenant_ids"`
+	InternalToolIDs []string     `tfsdk:"internal_tool_ids"`
+	ToolTags        []string     `tfsdk:"tool_tags"`
+	Keys            []string     `tfsdk:"keys"`
+}
+
+func (d *PolicyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + d.typeName
+}
+
+func (d *PolicyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: fmt.Sprintf("Looks up a %s by name, so other configurations can reference it without copying its ID.", d.kind),
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The policy ID.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the policy. Exactly one policy of this kind must have the name.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The policy description.",
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "The policy type, e.g. RBAC_ROLES or RBAC_PERMISSIONS for RBAC policies.",
+				Computed:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the policy is enabled.",
+				Computed:    true,
+			},
+			"app_ids": schema.ListAttribute{
+				Description: "The application IDs the policy applies to.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"tenant_ids": schema.ListAttribute{
+				Description: "The tenant IDs the policy applies to.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"internal_tool_ids": schema.ListAttribute{
+				Description: "The internal tool IDs the policy applies to.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"tool_tags": schema.ListAttribute{
+				Description: "The tool tags the policy applies to.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"keys": schema.ListAttribute{
+				Description: "The role or permission keys of an RBAC policy. Empty for other policies.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *PolicyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PolicyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PolicyDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policies, err := d.list(d.client, ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list policies", err)
+		return
+	}
+
+	name := data.Name.ValueString()
+	matches := findPoliciesByName(policies, name, d.match)
+	switch len(matches) {
+	case 0:
+		resp.Diagnostics.AddError("Policy Not Found", fmt.Sprintf("No %s named %q exists.", d.kind, name))
+		return
+	case 1:
+	default:
+		ids := make([]string, len(matches))
+		for i, policy := range matches {
+			ids[i] = policy.ID
+		}
+		resp.Diagnostics.AddError(
+			"Multiple Policies Found",
+			fmt.Sprintf("More than one %s is named %q (%s). Rename them so the name is unique.", d.kind, name, strings.Join(ids, ", ")),
+		)
+		return
+	}
+
+	flattenPolicyDataSource(&matches[0], &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findPoliciesByName returns the policies with the given name that match the kind, sorted by ID
+func findPoliciesByName(policies []client.Policy, name string, match func(client.Policy) bool) []client.Policy {
+	result := []client.Policy{}
+	for _, policy := range policies {
+		if policy.Name == name && match(policy) {
+			result = append(result, policy)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
+
+// flattenPolicyDataSource maps a policy onto the data source model
+func flattenPolicyDataSource(policy *client.Policy, data *PolicyDataSourceModel) {
+	data.ID = types.StringValue(policy.ID)
+	data.Name = types.StringValue(policy.Name)
+	data.Description = types.StringValue(policy.Description)
+	data.Type = types.StringValue(policy.Type)
+	data.Enabled = types.BoolValue(policy.Enabled)
+	data.AppIDs = nonNilStrings(policy.AppIDs)
+	data.TenantIDs = nonNilStrings(policy.TenantIDs)
+	if len(policy.TenantIDs) == 0 && policy.TenantID != "" {
+		data.TenantIDs = []string{policy.TenantID}
+	}
+	data.InternalToolIDs = nonNilStrings(policy.InternalToolIDs)
+	data.ToolTags = nonNilStrings(policy.ToolTags)
+	data.Keys = nonNilStrings(policy.Keys)
+}
+
+// isRbacPolicyType reports whether a policy type is one of the RBAC policy types
+func isRbacPolicyType(policyType string) bool {
+	return policyType == "RBAC_ROLES" || policyType == "RBAC_PERMISSIONS"
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestPolicyDataSourcesHaveExpectedSchema(t *testing.T) {
+	constructors := []func() datasource.DataSource{
+		NewConditionalPolicyDataSource,
+		NewRbacPolicyDataSource,
+		NewMaskingPolicyDataSource,
+	}
+
+	attrs := []string{"id", "name", "description", "type", "enabled", "app_ids", "tenant_ids", "internal_tool_ids", "tool_tags", "keys"}
+	for _, constructor := range constructors {
+		resp := &datasource.SchemaResponse{}
+		constructor().Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+		for _, attr := range attrs {
+			if _, ok := resp.Schema.Attributes[attr]; !ok {
+				t.Errorf("expected attribute '%s' in schema", attr)
+			}
+		}
+		if !resp.Schema.Attributes["name"].IsRequired() {
+			t.Errorf("expected name to be required")
+		}
+	}
+}
+
+func TestPolicyDataSourcesMetadata(t *testing.T) {
+	tests := map[string]func() datasource.DataSource{
+		"agentlink_conditional_policy": NewConditionalPolicyDataSource,
+		"agentlink_rbac_policy":        NewRbacPolicyDataSource,
+		"agentlink_masking_policy":     NewMaskingPolicyDataSource,
+	}
+
+	for expected, constructor := range tests {
+		resp := &datasource.MetadataResponse{}
+		constructor().Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+		if resp.TypeName != expected {
+			t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+		}
+	}
+}
+
+func TestFindPoliciesByName(t *testing.T) {
+	policies := []client.Policy{
+		{ID: "policy-3", Name: "admins", Type: "RBAC_ROLES"},
+		{ID: "policy-2", Name: "admins"},
+		{ID: "policy-1", Name: "admins", PolicyConfiguration: &client.MaskingPolicyConfiguration{}},
+		{ID: "policy-4", Name: "business-hours"},
+	}
+
+	conditional := NewConditionalPolicyDataSource().(*PolicyDataSource)
+	if matches := findPoliciesByName(policies, "admins", conditional.match); len(matches) != 1 || matches[0].ID != "policy-2" {
+		t.Errorf("expected only the conditional policy, got %+v", matches)
+	}
+
+	rbac := NewRbacPolicyDataSource().(*PolicyDataSource)
+	if matches := findPoliciesByName(policies, "admins", rbac.match); len(matches) != 1 || matches[0].ID != "policy-3" {
+		t.Errorf("expected only the RBAC policy, got %+v", matches)
+	}
+
+	masking := NewMaskingPolicyDataSource().(*PolicyDataSource)
+	if matches := findPoliciesByName(policies, "admins", masking.match); len(matches) != 2 || matches[0].ID != "policy-1" {
+		t.Errorf("expected the non-RBAC policies sorted by ID, got %+v", matches)
+	}
+
+	if matches := findPoliciesByName(policies, "missing", conditional.match); len(matches) != 0 {
+		t.Errorf("expected no policies, got %+v", matches)
+	}
+}
+
+func TestFlattenPolicyDataSource(t *testing.T) {
+	var data PolicyDataSourceModel
+	flattenPolicyDataSource(&client.Policy{
+		ID:       "policy-1",
+		Name:     "admins",
+		Type:     "RBAC_ROLES",
+		Enabled:  true,
+		TenantID: "tenant-1",
+		Keys:     []string{"admin"},
+	}, &data)
+
+	if data.ID.ValueString() != "policy-1" || data.Type.ValueString() != "RBAC_ROLES" || !data.Enabled.ValueBool() {
+		t.Errorf("unexpected policy attributes: %+v", data)
+	}
+	if len(data.TenantIDs) != 1 || data.TenantIDs[0] != "tenant-1" {
+		t.Errorf("expected the single tenant ID in tenant_ids, got %v", data.TenantIDs)
+	}
+	if data.AppIDs == nil || len(data.AppIDs) != 0 {
+		t.Errorf("expected empty app_ids, got %v", data.AppIDs)
+	}
+	if len(data.Keys) != 1 || data.Keys[0] != "admin" {
+		t.Errorf("expected keys, got %v", data.Keys)
+	}
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ProviderConfigDataSource{}
+
+func NewProviderConfigDataSource() datasource.DataSource {
+	return &ProviderConfigDataSource{}
+}
+
+// ProviderConfigDataSource defines the data source implementation.
+type ProviderConfigDataSource struct {
+	client *client.Client
+}
+
+// ProviderConfigDataSourceModel describes the data source data model.
+type ProviderConfigDataSourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	BaseURL  types.String `tfsdk:"base_url"`
+	Region   types.String `tfsdk:"region"`
+	VendorID types.String `tfsdk:"vendor_id"`
+}
+
+func (d *ProviderConfigDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_provider_config"
+}
+
+func (d *ProviderConfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exposes the environment the provider is configured for, so modules can check they target the intended environment before applying.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The vendor ID.",
+				Computed:    true,
+			},
+			"base_url": schema.StringAttribute{
+				Description: "The effective base URL of the Frontegg API, after applying region, base_url and their environment variables.",
+				Computed:    true,
+			},
+			"region": schema.StringAttribute{
+				Description: "The region the base URL belongs to. Null when base_url points to a URL that is not a known region, e.g. a private environment.",
+				Computed:    true,
+			},
+			"vendor_id": schema.StringAttribute{
+				Description: "The ID of the vendor the provider credentials belong to.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *ProviderConfigDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ProviderConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ProviderConfigDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vendor, err := d.client.GetVendorConfig(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read vendor config", err)
+		return
+	}
+
+	data.ID = types.StringValue(vendor.ID)
+	data.VendorID = types.StringValue(vendor.ID)
+	data.BaseURL = types.StringValue(d.client.BaseURL())
+	data.Region = types.StringNull()
+	if region := regionForBaseURL(d.client.BaseURL()); region != "" {
+		data.Region = types.StringValue(region)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// regionForBaseURL returns the region whose API base URL is baseURL, or "" for other URLs
+func regionForBaseURL(baseURL string) string {
+	for region, url := range regionURLs {
+		if url == baseURL {
+			return region
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestProviderConfigDataSourceHasExpectedSchema(t *testing.T) {
+	d := NewProviderConfigDataSource()
+
+	resp := &datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	for _, attr := range []string{"id", "base_url", "region", "vendor_id"} {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in schema", attr)
+		}
+	}
+}
+
+func TestProviderConfigDataSourceMetadata(t *testing.T) {
+	d := NewProviderConfigDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_provider_config"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func TestRegionForBaseURL(t *testing.T) {
+	tests := map[string]string{
+		"https://api.frontegg.com":     "eu",
+		"https://api.stg.frontegg.com": "stg",
+		"https://api.us.frontegg.com":  "us",
+		"https://frontegg.example.com": "",
+	}
+	for baseURL, expected := range tests {
+		if got := regionForBaseURL(baseURL); got != expected {
+			t.Errorf("regionForBaseURL(%q) = %q, expected %q", baseURL, got, expected)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SourceOpenAPIDataSource{}
+
+// openAPIPathParamPattern matches the {name} templates of an OpenAPI path
+var openAPIPathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+func NewSourceOpenAPIDataSource() datasource.DataSource {
+	return &SourceOpenAPIDataSource{}
+}
+
+// SourceOpenAPIDataSource defines the data source implementation.
+type SourceOpenAPIDataSource struct {
+	client *client.Client
+}
+
+// SourceOpenAPIDataSourceModel describes the data source data model.
+type SourceOpenAPIDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	ApplicationID types.String `tfsdk:"application_id"`
+	SourceID      types.String `tfsdk:"source_id"`
+	APIVersion    types.String `tfsdk:"api_version"`
+	Document      types.String `tfsdk:"document"`
+	ToolsCount    types.Int64  `tfsdk:"tools_count"`
+}
+
+func (d *SourceOpenAPIDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_openapi"
+}
+
+func (d *SourceOpenAPIDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Renders the tools of a REST source as an OpenAPI 3.0 document, e.g. to feed documentation or API gateways from the deployed tools.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier in the format app_id:source_id.",
+				Computed:    true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "The ID of the application.",
+				Required:    true,
+			},
+			"source_id": schema.StringAttribute{
+				Description: "The ID of the source to render.",
+				Required:    true,
+			},
+			"api_version": schema.StringAttribute{
+				Description: "The info.version of the rendered document. Defaults to '1.0.0'.",
+				Optional:    true,
+			},
+			"document": schema.StringAttribute{
+				Description: "The OpenAPI 3.0 document as JSON.",
+				Computed:    true,
+			},
+			"tools_count": schema.Int64Attribute{
+				Description: "The number of tools rendered as operations.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *SourceOpenAPIDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SourceOpenAPIDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SourceOpenAPIDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID := data.ApplicationID.ValueString()
+	sourceID := data.SourceID.ValueString()
+
+	source, err := d.client.GetSourceByID(ctx, appID, sourceID)
+	if errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError("Source Not Found", "No source with ID "+sourceID+" exists in application "+appID)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read source", err)
+		return
+	}
+
+	tools, err := d.client.GetToolsBySource(ctx, appID, sourceID)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read tools", err)
+		return
+	}
+
+	version := "1.0.0"
+	if !data.APIVersion.IsNull() && !data.APIVersion.IsUnknown() {
+		version = data.APIVersion.ValueString()
+	}
+
+	document, count := renderOpenAPIDocument(*source, tools, version)
+	content, err := json.Marshal(document)
+	if err != nil {
+		resp.Diagnostics.AddError("Render Error", "Unable to encode OpenAPI document: "+err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(appID + ":" + sourceID)
+	data.Document = types.StringValue(string(content))
+	data.ToolsCount = types.Int64Value(int64(count))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// renderOpenAPIDocument builds an OpenAPI 3.0 document with one operation per tool and returns it
+// with the number of operations. Tools without a method and path, such as GraphQL tools, are
+// skipped. Input schema properties named after path templates become path parameters; the other
+// properties become query parameters for GET, HEAD and DELETE and a JSON request body otherwise.
+func renderOpenAPIDocument(source client.Source, tools []client.InternalTool, version string) (map[string]interface{}, int) {
+	sorted := make([]client.InternalTool, len(tools))
+	copy(sorted, tools)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	paths := map[string]interface{}{}
+	schemas := map[string]interface{}{}
+	count := 0
+	for _, tool := range sorted {
+		if tool.OriginalMethod == "" || tool.OriginalPath == "" {
+			continue
+		}
+		method := strings.ToLower(tool.OriginalMethod)
+
+		operation := map[string]interface{}{
+			"operationId": tool.Name,
+			"responses": map[string]interface{}{
+				"default": map[string]interface{}{"description": "Response from " + source.Name},
+			},
+		}
+		if tool.Description != "" {
+			operation["description"] = tool.Description
+		}
+		if len(tool.Tags) > 0 {
+			operation["tags"] = tool.Tags
+		}
+
+		pathParams := map[string]bool{}
+		for _, match := range openAPIPathParamPattern.FindAllStringSubmatch(tool.OriginalPath, -1) {
+			pathParams[match[1]] = true
+		}
+
+		properties, _ := tool.Schema["properties"].(map[string]interface{})
+		required := map[string]bool{}
+		if names, ok := tool.Schema["required"].([]interface{}); ok {
+			for _, name := range names {
+				if s, ok := name.(string); ok {
+					required[s] = true
+				}
+			}
+		}
+
+		parameters := []interface{}{}
+		body := map[string]interface{}{}
+		bodyRequired := []interface{}{}
+		inQuery := method == "get" || method == "head" || method == "delete"
+		for _, name := range sortedKeys(properties) {
+			switch {
+			case pathParams[name]:
+				parameters = append(parameters, map[string]interface{}{
+					"name": name, "in": "path", "required": true, "schema": properties[name],
+				})
+			case inQuery:
+				parameters = append(parameters, map[string]interface{}{
+					"name": name, "in": "query", "required": required[name], "schema": properties[name],
+				})
+			default:
+				body[name] = properties[name]
+				if required[name] {
+					bodyRequired = append(bodyRequired, name)
+				}
+			}
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+		if len(body) > 0 {
+			schemaName := tool.Name + "Request"
+			bodySchema := map[string]interface{}{"type": "object", "properties": body}
+			if len(bodyRequired) > 0 {
+				bodySchema["required"] = bodyRequired
+			}
+			schemas[schemaName] = bodySchema
+			operation["requestBody"] = map[string]interface{}{
+				"required": len(bodyRequired) > 0,
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{
+						"schema": map[string]interface{}{"$ref": "#/components/schemas/" + escapeJSONPointer(schemaName)},
+					},
+				},
+			}
+		}
+
+		item, ok := paths[tool.OriginalPath].(map[string]interface{})
+		if !ok {
+			item = map[string]interface{}{}
+			paths[tool.OriginalPath] = item
+		}
+		item[method] = operation
+		count++
+	}
+
+	document := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   source.Name,
+			"version": version,
+		},
+		"paths": paths,
+	}
+	if source.SourceURL != "" {
+		document["servers"] = []interface{}{map[string]interface{}{"url": source.SourceURL}}
+	}
+	if len(schemas) > 0 {
+		document["components"] = map[string]interface{}{"schemas": schemas}
+	}
+
+	return document, count
+}
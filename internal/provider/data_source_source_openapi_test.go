@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestSourceOpenAPIDataSourceHasExpectedSchema(t *testing.T) {
+	d := NewSourceOpenAPIDataSource()
+
+	resp := &datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	attrs := []string{"id", "application_id", "source_id", "api_version", "document", "tools_count"}
+	for _, attr := range attrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in schema", attr)
+		}
+	}
+}
+
+func TestSourceOpenAPIDataSourceMetadata(t *testing.T) {
+	d := NewSourceOpenAPIDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_source_openapi"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func TestRenderOpenAPIDocument(t *testing.T) {
+	source := client.Source{Name: "orders-api", SourceURL: "https://api.example.com"}
+	tools := []client.InternalTool{
+		{
+			Name:           "getOrder",
+			Description:    "Get an order",
+			OriginalMethod: "GET",
+			OriginalPath:   "/orders/{id}",
+			Tags:           []string{"orders"},
+			Schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"id":     map[string]interface{}{"type": "string"},
+					"expand": map[string]interface{}{"type": "boolean"},
+				},
+				"required": []interface{}{"id"},
+			},
+		},
+		{
+			Name:           "createOrder",
+			OriginalMethod: "POST",
+			OriginalPath:   "/orders",
+			Schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"item": map[string]interface{}{"type": "string"},
+				},
+				"required": []interface{}{"item"},
+			},
+		},
+		{Name: "searchGraph"},
+	}
+
+	document, count := renderOpenAPIDocument(source, tools, "2.0.0")
+	if count != 2 {
+		t.Errorf("expected 2 operations, got %d", count)
+	}
+
+	content, err := json.Marshal(document)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if errs := validateOpenAPI(content); len(errs) != 0 {
+		t.Errorf("expected a valid OpenAPI document, got %+v", errs)
+	}
+
+	info := document["info"].(map[string]interface{})
+	if info["title"] != "orders-api" || info["version"] != "2.0.0" {
+		t.Errorf("unexpected info: %+v", info)
+	}
+
+	paths := document["paths"].(map[string]interface{})
+	get := paths["/orders/{id}"].(map[string]interface{})["get"].(map[string]interface{})
+	expectedParams := []interface{}{
+		map[string]interface{}{"name": "expand", "in": "query", "required": false, "schema": map[string]interface{}{"type": "boolean"}},
+		map[string]interface{}{"name": "id", "in": "path", "required": true, "schema": map[string]interface{}{"type": "string"}},
+	}
+	if !reflect.DeepEqual(get["parameters"], expectedParams) {
+		t.Errorf("unexpected parameters: %+v", get["parameters"])
+	}
+
+	post := paths["/orders"].(map[string]interface{})["post"].(map[string]interface{})
+	if _, ok := post["requestBody"]; !ok {
+		t.Errorf("expected a request body for createOrder")
+	}
+	schemas := document["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	if _, ok := schemas["createOrderRequest"]; !ok {
+		t.Errorf("expected a createOrderRequest component schema, got %+v", schemas)
+	}
+}
@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"sort"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SourcesDataSource{}
+
+func NewSourcesDataSource() datasource.DataSource {
+	return &SourcesDataSource{}
+}
+
+// SourcesDataSource defines the data source implementation.
+type SourcesDataSource struct {
+	client *client.Client
+}
+
+// SourcesDataSourceModel describes the data source data model.
+type SourcesDataSourceModel struct {
+	ID            types.String       `tfsdk:"id"`
+	ApplicationID types.String       `tfsdk:"application_id"`
+	Type          types.String       `tfsdk:"type"`
+	Enabled       types.Bool         `tfsdk:"enabled"`
+	NameRegex     types.String       `tfsdk:"name_regex"`
+	Sources       []SourceEntryModel `tfsdk:"sources"`
+}
+
+// SourceEntryModel describes a single source returned by the data source.
+type SourceEntryModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Type       types.String `tfsdk:"type"`
+	SourceURL  types.String `tfsdk:"source_url"`
+	APITimeout types.Int64  `tfsdk:"api_timeout"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	ToolCount  types.Int64  `tfsdk:"tool_count"`
+}
+
+// sourceFilter selects the sources returned by the data source
+type sourceFilter struct {
+	Type      string
+	Enabled   *bool
+	NameRegex *regexp.Regexp
+}
+
+func (d *SourcesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sources"
+}
+
+func (d *SourcesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the sources of an application, optionally filtered, with the number of tools imported from each, e.g. to create one policy per source in a module.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The application ID.",
+				Computed:    true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "The ID of the application to list the sources of.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Only return sources of this type, e.g. REST or GRAPHQL.",
+				Optional:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Only return sources with this enabled state.",
+				Optional:    true,
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Only return sources whose name matches this regular expression.",
+				Optional:    true,
+			},
+			"sources": schema.ListNestedAttribute{
+				Description: "The matching sources, sorted by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The source ID.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The source name.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The source type.",
+							Computed:    true,
+						},
+						"source_url": schema.StringAttribute{
+							Description: "The source URL.",
+							Computed:    true,
+						},
+						"api_timeout": schema.Int64Attribute{
+							Description: "The API timeout in milliseconds.",
+							Computed:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the source is enabled.",
+							Computed:    true,
+						},
+						"tool_count": schema.Int64Attribute{
+							Description: "The number of tools imported from the source.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SourcesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SourcesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SourcesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := sourceFilter{Type: data.Type.ValueString()}
+	if !data.Enabled.IsNull() && !data.Enabled.IsUnknown() {
+		enabled := data.Enabled.ValueBool()
+		filter.Enabled = &enabled
+	}
+	if !data.NameRegex.IsNull() && !data.NameRegex.IsUnknown() {
+		re, err := regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Name Regex",
+				"Unable to compile name_regex: "+err.Error(),
+			)
+			return
+		}
+		filter.NameRegex = re
+	}
+
+	appID := data.ApplicationID.ValueString()
+	sources, err := d.client.GetSources(ctx, appID)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read sources", err)
+		return
+	}
+
+	data.ID = types.StringValue(appID)
+	data.Sources = []SourceEntryModel{}
+	for _, src := range filterSources(sources, filter) {
+		tools, err := d.client.GetToolsBySource(ctx, appID, src.ID)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "Unable to read tools of source "+src.Name, err)
+			return
+		}
+
+		data.Sources = append(data.Sources, SourceEntryModel{
+			ID:         types.StringValue(src.ID),
+			Name:       types.StringValue(src.Name),
+			Type:       types.StringValue(src.Type),
+			SourceURL:  types.StringValue(src.SourceURL),
+			APITimeout: types.Int64Value(int64(src.APITimeout)),
+			Enabled:    types.BoolValue(src.Enabled),
+			ToolCount:  types.Int64Value(int64(len(tools))),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filterSources returns the sources matching every set filter, sorted by name
+func filterSources(sources []client.Source, filter sourceFilter) []client.Source {
+	result := []client.Source{}
+	for _, src := range sources {
+		if filter.Type != "" && src.Type != filter.Type {
+			continue
+		}
+		if filter.Enabled != nil && src.Enabled != *filter.Enabled {
+			continue
+		}
+		if filter.NameRegex != nil && !filter.NameRegex.MatchString(src.Name) {
+			continue
+		}
+		result = append(result, src)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
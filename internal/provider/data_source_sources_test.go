@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestSourcesDataSourceHasExpectedSchema(t *testing.T) {
+	d := NewSourcesDataSource()
+
+	resp := &datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	attrs := []string{"id", "application_id", "type", "enabled", "name_regex", "sources"}
+	for _, attr := range attrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in schema", attr)
+		}
+	}
+}
+
+func TestSourcesDataSourceMetadata(t *testing.T) {
+	d := NewSourcesDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_sources"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func TestFilterSources(t *testing.T) {
+	sources := []client.Source{
+		{ID: "src-3", Name: "orders-api", Type: "REST", Enabled: true},
+		{ID: "src-1", Name: "billing-api", Type: "REST", Enabled: false},
+		{ID: "src-2", Name: "catalog-graph", Type: "GRAPHQL", Enabled: true},
+	}
+
+	all := filterSources(sources, sourceFilter{})
+	if len(all) != 3 || all[0].Name != "billing-api" || all[2].Name != "orders-api" {
+		t.Errorf("expected every source sorted by name, got %+v", all)
+	}
+
+	rest := filterSources(sources, sourceFilter{Type: "REST"})
+	if len(rest) != 2 {
+		t.Errorf("expected 2 REST sources, got %+v", rest)
+	}
+
+	enabled := true
+	enabledRest := filterSources(sources, sourceFilter{Type: "REST", Enabled: &enabled})
+	if len(enabledRest) != 1 || enabledRest[0].ID != "src-3" {
+		t.Errorf("expected only the enabled REST source, got %+v", enabledRest)
+	}
+
+	apis := filterSources(sources, sourceFilter{NameRegex: regexp.MustCompile(`-api$`)})
+	if len(apis) != 2 || apis[0].ID != "src-1" || apis[1].ID != "src-3" {
+		t.Errorf("expected sources matching the name regex, got %+v", apis)
+	}
+}
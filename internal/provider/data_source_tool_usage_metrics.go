@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// usageIntervals are the time windows supported by the analytics API
+var usageIntervals = []string{client.UsageIntervalToday, client.UsageIntervalLast7Days, client.UsageIntervalLast30Days}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ToolUsageMetricsDataSource{}
+
+func NewToolUsageMetricsDataSource() datasource.DataSource {
+	return &ToolUsageMetricsDataSource{}
+}
+
+// ToolUsageMetricsDataSource defines the data source implementation.
+type ToolUsageMetricsDataSource struct {
+	client *client.Client
+}
+
+// ToolUsageMetricsDataSourceModel describes the data source data model.
+type ToolUsageMetricsDataSourceModel struct {
+	ID               types.String     `tfsdk:"id"`
+	Interval         types.String     `tfsdk:"interval"`
+	ToolNames        types.Set        `tfsdk:"tool_names"`
+	Tools            []ToolUsageModel `tfsdk:"tools"`
+	TotalInvocations types.Int64      `tfsdk:"total_invocations"`
+	UnusedToolNames  types.Set        `tfsdk:"unused_tool_names"`
+	TenantID         types.String     `tfsdk:"tenant_id"`
+}
+
+// ToolUsageModel describes the usage of a single tool.
+type ToolUsageModel struct {
+	Name            types.String `tfsdk:"name"`
+	InvocationCount types.Int64  `tfsdk:"invocation_count"`
+}
+
+func (d *ToolUsageMetricsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tool_usage_metrics"
+}
+
+func (d *ToolUsageMetricsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exposes per-tool invocation counts over a time window, e.g. to find tools that are no longer used.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The interval the metrics cover.",
+				Computed:    true,
+			},
+			"interval": schema.StringAttribute{
+				Description: "The time window. Valid values: today, last-7-days, last-30-days. Defaults to last-30-days.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"tool_names": schema.SetAttribute{
+				Description: "Names of the tools to report on. Tools that were not invoked during the interval are reported with a count of 0. When unset, only invoked tools are reported.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"tools": schema.ListNestedAttribute{
+				Description: "The usage of each tool, most invoked first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The tool name.",
+							Computed:    true,
+						},
+						"invocation_count": schema.Int64Attribute{
+							Description: "The number of invocations during the interval.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"total_invocations": schema.Int64Attribute{
+				Description: "The number of invocations of the reported tools during the interval.",
+				Computed:    true,
+			},
+			"unused_tool_names": schema.SetAttribute{
+				Description: "Names from tool_names that were not invoked during the interval.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"tenant_id": schema.StringAttribute{
+				Description: "Only count invocations made by users of this tenant. When unset, invocations across all tenants are counted.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (d *ToolUsageMetricsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ToolUsageMetricsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ToolUsageMetricsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	interval := client.UsageIntervalLast30Days
+	if !data.Interval.IsNull() && !data.Interval.IsUnknown() {
+		interval = data.Interval.ValueString()
+	}
+	if !isValidUsageInterval(interval) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("interval"),
+			"Invalid Interval",
+			fmt.Sprintf("Unsupported interval %q. Valid values: %v", interval, usageIntervals),
+		)
+		return
+	}
+
+	toolNames := expandStringSet(ctx, data.ToolNames, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = client.WithTenantID(ctx, data.TenantID.ValueString())
+	usage, err := d.client.GetToolUsage(ctx, interval)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read tool usage", err)
+		return
+	}
+
+	tools, unused := toolUsageMetrics(usage, toolNames)
+
+	data.ID = types.StringValue(interval)
+	data.Interval = types.StringValue(interval)
+	data.Tools = make([]ToolUsageModel, 0, len(tools))
+	var total int64
+	for _, tool := range tools {
+		data.Tools = append(data.Tools, ToolUsageModel{
+			Name:            types.StringValue(tool.ToolName),
+			InvocationCount: types.Int64Value(tool.Count),
+		})
+		total += tool.Count
+	}
+	data.TotalInvocations = types.Int64Value(total)
+
+	unusedSet, diags := types.SetValueFrom(ctx, types.StringType, unused)
+	resp.Diagnostics.Append(diags...)
+	data.UnusedToolNames = unusedSet
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// toolUsageMetrics returns the usage of the tools, most invoked first, and the names of the
+// tools that were not invoked. When toolNames is empty every invoked tool is returned.
+func toolUsageMetrics(usage []client.ToolUsage, toolNames []string) ([]client.ToolUsage, []string) {
+	counts := make(map[string]int64, len(usage))
+	for _, tool := range usage {
+		counts[tool.ToolName] += tool.Count
+	}
+
+	if len(toolNames) == 0 {
+		toolNames = make([]string, 0, len(counts))
+		for name := range counts {
+			toolNames = append(toolNames, name)
+		}
+	}
+
+	tools := make([]client.ToolUsage, 0, len(toolNames))
+	unused := []string{}
+	for _, name := range toolNames {
+		tools = append(tools, client.ToolUsage{ToolName: name, Count: counts[name]})
+		if counts[name] == 0 {
+			unused = append(unused, name)
+		}
+	}
+
+	sort.Slice(tools, func(i, j int) bool {
+		if tools[i].Count != tools[j].Count {
+			return tools[i].Count > tools[j].Count
+		}
+		return tools[i].ToolName < tools[j].ToolName
+	})
+	sort.Strings(unused)
+
+	return tools, unused
+}
+
+// isValidUsageInterval reports whether interval is supported by the analytics API
+func isValidUsageInterval(interval string) bool {
+	for _, valid := range usageIntervals {
+		if interval == valid {
+			return true
+		}
+	}
+	return false
+}
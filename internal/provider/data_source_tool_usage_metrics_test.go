@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestToolUsageMetricsDataSourceHasExpectedSchema(t *testing.T) {
+	d := NewToolUsageMetricsDataSource()
+
+	resp := &datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	attrs := []string{"id", "interval", "tool_names", "tools", "total_invocations", "unused_tool_names", "tenant_id"}
+	for _, attr := range attrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in schema", attr)
+		}
+	}
+}
+
+func TestToolUsageMetricsDataSourceMetadata(t *testing.T) {
+	d := NewToolUsageMetricsDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_tool_usage_metrics"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func TestToolUsageMetrics(t *testing.T) {
+	usage := []client.ToolUsage{
+		{ToolName: "list_orders", Count: 3},
+		{ToolName: "get_user", Count: 150},
+	}
+
+	tools, unused := toolUsageMetrics(usage, nil)
+	if len(tools) != 2 || tools[0].ToolName != "get_user" || tools[1].ToolName != "list_orders" {
+		t.Errorf("expected invoked tools, most invoked first, got %+v", tools)
+	}
+	if len(unused) != 0 {
+		t.Errorf("expected no unused tools, got %v", unused)
+	}
+
+	tools, unused = toolUsageMetrics(usage, []string{"get_user", "delete_user", "archive_user"})
+	if len(tools) != 3 || tools[0].ToolName != "get_user" || tools[1].Count != 0 {
+		t.Errorf("unexpected tools: %+v", tools)
+	}
+	if len(unused) != 2 || unused[0] != "archive_user" || unused[1] != "delete_user" {
+		t.Errorf("expected sorted unused tools, got %v", unused)
+	}
+}
+
+func TestIsValidUsageInterval(t *testing.T) {
+	if !isValidUsageInterval("last-7-days") {
+		t.Error("expected last-7-days to be valid")
+	}
+	if isValidUsageInterval("last-90-days") {
+		t.Error("expected last-90-days to be invalid")
+	}
+}
@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ToolsDriftDataSource{}
+
+func NewToolsDriftDataSource() datasource.DataSource {
+	return &ToolsDriftDataSource{}
+}
+
+// ToolsDriftDataSource defines the data source implementation.
+type ToolsDriftDataSource struct {
+	client *client.Client
+}
+
+// ToolsDriftDataSourceModel describes the data source data model.
+type ToolsDriftDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	ApplicationID  types.String `tfsdk:"application_id"`
+	SourceID       types.String `tfsdk:"source_id"`
+	SchemaFile     types.String `tfsdk:"schema_file"`
+	SchemaType     types.String `tfsdk:"schema_type"`
+	NamingStrategy types.String `tfsdk:"naming_strategy"`
+	NamePrefix     types.String `tfsdk:"name_prefix"`
+	NameSuffix     types.String `tfsdk:"name_suffix"`
+	MissingTools   types.List   `tfsdk:"missing_tools"`
+	ExtraTools     types.List   `tfsdk:"extra_tools"`
+	ModifiedTools  types.List   `tfsdk:"modified_tools"`
+	HasDrift       types.Bool   `tfsdk:"has_drift"`
+}
+
+// toolsDrift lists the tool names that differ between a schema and a source
+type toolsDrift struct {
+	Missing  []string
+	Extra    []string
+	Modified []string
+}
+
+func (d *ToolsDriftDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tools_drift"
+}
+
+func (d *ToolsDriftDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Compares the tools a schema file would import with the tools deployed on a source, without importing anything.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier in the format app_id:source_id.",
+				Computed:    true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "The ID of the application.",
+				Required:    true,
+			},
+			"source_id": schema.StringAttribute{
+				Description: "The ID of the source to compare against.",
+				Required:    true,
+			},
+			"schema_file": schema.StringAttribute{
+				Description: "Path to the schema file (OpenAPI JSON/YAML or GraphQL SDL).",
+				Required:    true,
+			},
+			"schema_type": schema.StringAttribute{
+				Description: "The type of schema: 'openapi' or 'graphql'.",
+				Required:    true,
+			},
+			"naming_strategy": schema.StringAttribute{
+				Description: "How tool names are derived from the schema, as in agentlink_tools_import. Defaults to 'operation_id'.",
+				Optional:    true,
+			},
+			"name_prefix": schema.StringAttribute{
+				Description: "Prefix prepended to every tool name, as in agentlink_tools_import.",
+				Optional:    true,
+			},
+			"name_suffix": schema.StringAttribute{
+				Description: "Suffix appended to every tool name, as in agentlink_tools_import.",
+				Optional:    true,
+			},
+			"missing_tools": schema.ListAttribute{
+				Description: "Names of tools defined by the schema that are not deployed on the source.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"extra_tools": schema.ListAttribute{
+				Description: "Names of tools deployed on the source that the schema does not define.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"modified_tools": schema.ListAttribute{
+				Description: "Names of tools whose method, path or input schema differ from the schema.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"has_drift": schema.BoolAttribute{
+				Description: "Whether any tool is missing, extra or modified.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *ToolsDriftDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ToolsDriftDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ToolsDriftDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var sourceType string
+	switch data.SchemaType.ValueString() {
+	case "openapi":
+		sourceType = "REST"
+	case "graphql":
+		sourceType = "GRAPHQL"
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("schema_type"),
+			"Invalid Schema Type",
+			"schema_type must be 'openapi' or 'graphql'",
+		)
+		return
+	}
+
+	opts := client.ImportOptions{
+		NamingStrategy: data.NamingStrategy.ValueString(),
+		NamePrefix:     data.NamePrefix.ValueString(),
+		NameSuffix:     data.NameSuffix.ValueString(),
+	}
+	switch opts.NamingStrategy {
+	case "":
+		opts.NamingStrategy = client.NamingStrategyOperationID
+	case client.NamingStrategyOperationID, client.NamingStrategyMethodPath, client.NamingStrategySummarySlug:
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("naming_strategy"),
+			"Invalid Naming Strategy",
+			"naming_strategy must be 'operation_id', 'method_path' or 'summary_slug'",
+		)
+		return
+	}
+
+	schemaContent, err := os.ReadFile(data.SchemaFile.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("File Error", "Unable to read schema file: "+err.Error())
+		return
+	}
+
+	appID := data.ApplicationID.ValueString()
+	sourceID := data.SourceID.ValueString()
+
+	expected, err := d.client.BuildSchemaTools(ctx, appID, sourceID, sourceType, schemaContent, filepath.Base(data.SchemaFile.ValueString()), opts)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to parse schema", err)
+		return
+	}
+
+	actual, err := d.client.GetToolsBySource(ctx, appID, sourceID)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read tools", err)
+		return
+	}
+
+	drift := diffTools(expected, actual)
+
+	data.ID = types.StringValue(appID + ":" + sourceID)
+	data.HasDrift = types.BoolValue(len(drift.Missing)+len(drift.Extra)+len(drift.Modified) > 0)
+
+	missing, diags := types.ListValueFrom(ctx, types.StringType, drift.Missing)
+	resp.Diagnostics.Append(diags...)
+	data.MissingTools = missing
+
+	extra, diags := types.ListValueFrom(ctx, types.StringType, drift.Extra)
+	resp.Diagnostics.Append(diags...)
+	data.ExtraTools = extra
+
+	modified, diags := types.ListValueFrom(ctx, types.StringType, drift.Modified)
+	resp.Diagnostics.Append(diags...)
+	data.ModifiedTools = modified
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// diffTools compares the tools built from a schema with the deployed tools by name. Descriptions
+// and tags are not compared, since they depend on import options the API does not return.
+func diffTools(expected, actual []client.InternalTool) toolsDrift {
+	deployed := make(map[string]client.InternalTool, len(actual))
+	for _, tool := range actual {
+		deployed[tool.Name] = tool
+	}
+
+	drift := toolsDrift{Missing: []string{}, Extra: []string{}, Modified: []string{}}
+	defined := make(map[string]bool, len(expected))
+	for _, tool := range expected {
+		defined[tool.Name] = true
+
+		existing, ok := deployed[tool.Name]
+		if !ok {
+			drift.Missing = append(drift.Missing, tool.Name)
+			continue
+		}
+		if existing.OriginalMethod != tool.OriginalMethod ||
+			existing.OriginalPath != tool.OriginalPath ||
+			!reflect.DeepEqual(existing.Schema, tool.Schema) {
+			drift.Modified = append(drift.Modified, tool.Name)
+		}
+	}
+
+	for _, tool := range actual {
+		if !defined[tool.Name] {
+			drift.Extra = append(drift.Extra, tool.Name)
+		}
+	}
+
+	sort.Strings(drift.Missing)
+	sort.Strings(drift.Extra)
+	sort.Strings(drift.Modified)
+
+	return drift
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestToolsDriftDataSourceHasExpectedSchema(t *testing.T) {
+	d := NewToolsDriftDataSource()
+
+	resp := &datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	attrs := []string{
+		"id", "application_id", "source_id", "schema_file", "schema_type",
+		"naming_strategy", "name_prefix", "name_suffix",
+		"missing_tools", "extra_tools", "modified_tools", "has_drift",
+	}
+	for _, attr := range attrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in schema", attr)
+		}
+	}
+}
+
+func TestToolsDriftDataSourceMetadata(t *testing.T) {
+	d := NewToolsDriftDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_tools_drift"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func TestDiffTools(t *testing.T) {
+	bodySchema := map[string]interface{}{"type": "object"}
+	expected := []client.InternalTool{
+		{Name: "listOrders", OriginalMethod: "GET", OriginalPath: "/orders", Schema: bodySchema},
+		{Name: "createOrder", OriginalMethod: "POST", OriginalPath: "/orders", Schema: bodySchema},
+		{Name: "getOrder", OriginalMethod: "GET", OriginalPath: "/orders/{id}"},
+		{Name: "deleteOrder", OriginalMethod: "DELETE", OriginalPath: "/orders/{id}"},
+	}
+	actual := []client.InternalTool{
+		{Name: "listOrders", OriginalMethod: "GET", OriginalPath: "/orders", Schema: map[string]interface{}{"type": "object"}, Description: "changed"},
+		{Name: "createOrder", OriginalMethod: "POST", OriginalPath: "/orders", Schema: map[string]interface{}{"type": "array"}},
+		{Name: "getOrder", OriginalMethod: "GET", OriginalPath: "/order/{id}"},
+		{Name: "legacySearch", OriginalMethod: "GET", OriginalPath: "/search"},
+	}
+
+	drift := diffTools(expected, actual)
+
+	if !reflect.DeepEqual(drift.Missing, []string{"deleteOrder"}) {
+		t.Errorf("expected deleteOrder to be missing, got %v", drift.Missing)
+	}
+	if !reflect.DeepEqual(drift.Extra, []string{"legacySearch"}) {
+		t.Errorf("expected legacySearch to be extra, got %v", drift.Extra)
+	}
+	if !reflect.DeepEqual(drift.Modified, []string{"createOrder", "getOrder"}) {
+		t.Errorf("expected createOrder and getOrder to be modified, got %v", drift.Modified)
+	}
+
+	none := diffTools(expected, expected)
+	if len(none.Missing)+len(none.Extra)+len(none.Modified) != 0 {
+		t.Errorf("expected no drift for identical tools, got %+v", none)
+	}
+}
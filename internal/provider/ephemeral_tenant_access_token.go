@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &TenantAccessTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &TenantAccessTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &TenantAccessTokenEphemeralResource{}
+
+// tenantAccessTokenPrivateKey is the private data key holding the token to delete on close.
+const tenantAccessTokenPrivateKey = "token"
+
+func NewTenantAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &TenantAccessTokenEphemeralResource{}
+}
+
+// TenantAccessTokenEphemeralResource defines the ephemeral resource implementation.
+type TenantAccessTokenEphemeralResource struct {
+	client *client.Client
+}
+
+// TenantAccessTokenEphemeralResourceModel describes the ephemeral resource data model.
+type TenantAccessTokenEphemeralResourceModel struct {
+	TenantID         types.String `tfsdk:"tenant_id"`
+	Description      types.String `tfsdk:"description"`
+	ExpiresInMinutes types.Int64  `tfsdk:"expires_in_minutes"`
+	RoleIDs          types.Set    `tfsdk:"role_ids"`
+	ID               types.String `tfsdk:"id"`
+	Secret           types.String `tfsdk:"secret"`
+	ExpiresAt        types.String `tfsdk:"expires_at"`
+}
+
+// tenantAccessTokenPrivate identifies the token created on open so close can delete it.
+type tenantAccessTokenPrivate struct {
+	TenantID string `json:"tenant_id"`
+	ID       string `json:"id"`
+}
+
+func (e *TenantAccessTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tenant_access_token"
+}
+
+func (e *TenantAccessTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates an access token scoped to a single tenant and deletes it when Terraform no longer needs it. The token is never stored in the plan or state.",
+		Attributes: map[string]schema.Attribute{
+			"tenant_id": schema.StringAttribute{
+				Description: "The ID of the tenant the token is scoped to.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the token.",
+				Optional:    true,
+			},
+			"expires_in_minutes": schema.Int64Attribute{
+				Description: "The number of minutes until the token expires, at least 1. The token does not expire when not set, but it is still deleted when Terraform no longer needs it.",
+				Optional:    true,
+			},
+			"role_ids": schema.SetAttribute{
+				Description: "The IDs of the tenant roles granted to the token.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The token ID.",
+				Computed:    true,
+			},
+			"secret": schema.StringAttribute{
+				Description: "The token secret.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "When the token expires, in RFC 3339 format. Empty when the token does not expire.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (e *TenantAccessTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	e.client = client
+}
+
+func (e *TenantAccessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data TenantAccessTokenEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.ExpiresInMinutes.IsNull() && data.ExpiresInMinutes.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expires_in_minutes"),
+			"Invalid Token Expiration",
+			"expires_in_minutes must be at least 1.",
+		)
+		return
+	}
+
+	createReq := client.CreateTenantAccessTokenRequest{
+		Description:      data.Description.ValueString(),
+		ExpiresInMinutes: int(data.ExpiresInMinutes.ValueInt64()),
+		RoleIDs:          expandStringSet(ctx, data.RoleIDs, &resp.Diagnostics),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := e.client.CreateTenantAccessToken(ctx, data.TenantID.ValueString(), createReq)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to create tenant access token", err)
+		return
+	}
+
+	private, err := json.Marshal(tenantAccessTokenPrivate{TenantID: data.TenantID.ValueString(), ID: token.ID})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to store tenant access token", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, tenantAccessTokenPrivateKey, private)...)
+
+	data.ID = types.StringValue(token.ID)
+	data.Secret = types.StringValue(token.Secret)
+	data.ExpiresAt = types.StringValue(token.Expires)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (e *TenantAccessTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	raw, diags := req.Private.GetKey(ctx, tenantAccessTokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(raw) == 0 {
+		return
+	}
+
+	var token tenantAccessTokenPrivate
+	if err := json.Unmarshal(raw, &token); err != nil {
+		resp.Diagnostics.AddError("Unable to read tenant access token", err.Error())
+		return
+	}
+
+	if err := e.client.DeleteTenantAccessToken(ctx, token.TenantID, token.ID); err != nil {
+		addClientError(&resp.Diagnostics, "Unable to delete tenant access token", err)
+	}
+}
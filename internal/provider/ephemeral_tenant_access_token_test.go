@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestTenantAccessTokenEphemeralResourceHasExpectedSchema(t *testing.T) {
+	e := NewTenantAccessTokenEphemeralResource()
+
+	resp := &ephemeral.SchemaResponse{}
+	e.Schema(context.Background(), ephemeral.SchemaRequest{}, resp)
+
+	attrs := []string{"tenant_id", "description", "expires_in_minutes", "role_ids", "id", "secret", "expires_at"}
+	for _, attr := range attrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in schema", attr)
+		}
+	}
+
+	secret, ok := resp.Schema.Attributes["secret"].(schema.StringAttribute)
+	if !ok {
+		t.Fatal("expected 'secret' to be a StringAttribute")
+	}
+	if !secret.Sensitive {
+		t.Error("expected 'secret' to be sensitive")
+	}
+}
+
+func TestTenantAccessTokenEphemeralResourceMetadata(t *testing.T) {
+	e := NewTenantAccessTokenEphemeralResource()
+
+	resp := &ephemeral.MetadataResponse{}
+	e.Metadata(context.Background(), ephemeral.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_tenant_access_token"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func TestTenantAccessTokenEphemeralResourceOpenAndClose(t *testing.T) {
+	ctx := context.Background()
+	deleted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/vendor" {
+			_ = json.NewEncoder(w).Encode(client.AuthResponse{Token: "vendor-token", ExpiresIn: 3600})
+			return
+		}
+		if r.Header.Get("frontegg-tenant-id") != "tenant-1" {
+			t.Errorf("expected the tenant header, got %q", r.Header.Get("frontegg-tenant-id"))
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/identity/resources/tenants/access-tokens/v1":
+			var req client.CreateTenantAccessTokenRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.Description != "deploy" || req.ExpiresInMinutes != 30 || !reflect.DeepEqual(req.RoleIDs, []string{"role-1"}) {
+				t.Errorf("unexpected request: %+v", req)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(client.TenantAccessToken{ID: "token-1", Secret: "token-secret", Expires: "2026-01-01T00:30:00Z"})
+		case r.Method == http.MethodDelete && r.URL.Path == "/identity/resources/tenants/access-tokens/v1/token-1":
+			deleted = true
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	e := &TenantAccessTokenEphemeralResource{client: client.NewClient(server.URL, "client", "secret")}
+	schemaResp := &ephemeral.SchemaResponse{}
+	e.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+	config := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"tenant_id":          tftypes.NewValue(tftypes.String, "tenant-1"),
+		"description":        tftypes.NewValue(tftypes.String, "deploy"),
+		"expires_in_minutes": tftypes.NewValue(tftypes.Number, 30),
+		"role_ids":           tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "role-1")}),
+		"id":                 tftypes.NewValue(tftypes.String, nil),
+		"secret":             tftypes.NewValue(tftypes.String, nil),
+		"expires_at":         tftypes.NewValue(tftypes.String, nil),
+	})
+
+	resp := &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: config}}
+	// The framework initializes the private data before calling Open.
+	reflect.ValueOf(&resp.Private).Elem().Set(reflect.New(reflect.TypeOf(resp.Private).Elem()))
+	e.Open(ctx, ephemeral.OpenRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data TenantAccessTokenEphemeralResourceModel
+	resp.Diagnostics.Append(resp.Result.Get(ctx, &data)...)
+	if data.ID.ValueString() != "token-1" || data.Secret.ValueString() != "token-secret" || data.ExpiresAt.ValueString() != "2026-01-01T00:30:00Z" {
+		t.Errorf("unexpected result: %+v", data)
+	}
+
+	closeResp := &ephemeral.CloseResponse{}
+	e.Close(ctx, ephemeral.CloseRequest{Private: resp.Private}, closeResp)
+	if closeResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", closeResp.Diagnostics)
+	}
+	if !deleted {
+		t.Error("expected the token to be deleted on close")
+	}
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// policyConditionAttrTypes describes a targeting condition as accepted by the conditions list of policy resources
+var policyConditionAttrTypes = map[string]attr.Type{
+	"attribute": types.StringType,
+	"negate":    types.BoolType,
+	"op":        types.StringType,
+	"value":     types.MapType{ElemType: types.StringType},
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ConditionEqFunction{}
+var _ function.Function = &ConditionInFunction{}
+
+func NewConditionEqFunction() function.Function {
+	return &ConditionEqFunction{}
+}
+
+func NewConditionInFunction() function.Function {
+	return &ConditionInFunction{}
+}
+
+// ConditionEqFunction defines the condition_eq function implementation.
+type ConditionEqFunction struct{}
+
+// ConditionInFunction defines the condition_in function implementation.
+type ConditionInFunction struct{}
+
+func (f *ConditionEqFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "condition_eq"
+}
+
+func (f *ConditionEqFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Builds a targeting condition matching a single value.",
+		Description: "Returns a policy targeting condition that matches when the attribute equals the value, for use in the targeting.if.conditions list of policy resources.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "attribute",
+				Description: "The attribute to evaluate, e.g. tool.method.",
+			},
+			function.StringParameter{
+				Name:        "value",
+				Description: "The value the attribute must equal.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: policyConditionAttrTypes,
+		},
+	}
+}
+
+func (f *ConditionEqFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var attribute, value string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &attribute, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	setConditionResult(ctx, resp, attribute, "equals", map[string]attr.Value{"string": types.StringValue(value)})
+}
+
+func (f *ConditionInFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "condition_in"
+}
+
+func (f *ConditionInFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Builds a targeting condition matching any of a list of values.",
+		Description: "Returns a policy targeting condition that matches when the attribute equals any of the values, for use in the targeting.if.conditions list of policy resources.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "attribute",
+				Description: "The attribute to evaluate, e.g. user.email.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:        "values",
+			Description: "The values the attribute may equal. Values must not contain commas.",
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: policyConditionAttrTypes,
+		},
+	}
+}
+
+func (f *ConditionInFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var attribute string
+	var values []string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &attribute, &values))
+	if resp.Error != nil {
+		return
+	}
+
+	if len(values) == 0 {
+		resp.Error = function.NewArgumentFuncError(1, "At least one value is required.")
+		return
+	}
+	for _, value := range values {
+		// The API stores list values as a single comma separated string
+		if strings.Contains(value, ",") {
+			resp.Error = function.NewArgumentFuncError(1, "Values must not contain commas, got "+value+".")
+			return
+		}
+	}
+
+	setConditionResult(ctx, resp, attribute, "in_list", map[string]attr.Value{"list": types.StringValue(strings.Join(values, ","))})
+}
+
+// setConditionResult sets the function result to a non-negated condition
+func setConditionResult(ctx context.Context, resp *function.RunResponse, attribute, op string, value map[string]attr.Value) {
+	valueMap, diags := types.MapValue(types.StringType, value)
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	condition, diags := types.ObjectValue(policyConditionAttrTypes, map[string]attr.Value{
+		"attribute": types.StringValue(attribute),
+		"negate":    types.BoolValue(false),
+		"op":        types.StringValue(op),
+		"value":     valueMap,
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, condition))
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestConditionFunctionsMetadata(t *testing.T) {
+	tests := map[string]function.Function{
+		"condition_eq": NewConditionEqFunction(),
+		"condition_in": NewConditionInFunction(),
+	}
+
+	for expected, f := range tests {
+		resp := &function.MetadataResponse{}
+		f.Metadata(context.Background(), function.MetadataRequest{}, resp)
+
+		if resp.Name != expected {
+			t.Errorf("expected function name '%s', got '%s'", expected, resp.Name)
+		}
+	}
+}
+
+// runConditionFunction runs a condition function and returns the resulting condition
+func runConditionFunction(t *testing.T, f function.Function, args ...attr.Value) (PolicyConditionModel, *function.FuncError) {
+	t.Helper()
+	ctx := context.Background()
+
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.ObjectUnknown(policyConditionAttrTypes)),
+	}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(args)}, resp)
+
+	var condition PolicyConditionModel
+	if resp.Error != nil {
+		return condition, resp.Error
+	}
+
+	result, ok := resp.Result.Value().(types.Object)
+	if !ok {
+		t.Fatalf("expected an object result, got %T", resp.Result.Value())
+	}
+	if diags := result.As(ctx, &condition, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return condition, nil
+}
+
+func TestConditionEqFunctionRun(t *testing.T) {
+	condition, err := runConditionFunction(t, NewConditionEqFunction(), types.StringValue("tool.method"), types.StringValue("DELETE"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if condition.Attribute.ValueString() != "tool.method" || condition.Op.ValueString() != "equals" || condition.Negate.ValueBool() {
+		t.Errorf("unexpected condition: %+v", condition)
+	}
+	if value := condition.Value.Elements()["string"]; value != types.StringValue("DELETE") {
+		t.Errorf("expected value string 'DELETE', got %v", value)
+	}
+}
+
+func TestConditionInFunctionRun(t *testing.T) {
+	values := types.TupleValueMust(
+		[]attr.Type{types.StringType, types.StringType},
+		[]attr.Value{types.StringValue("admin@example.com"), types.StringValue("security@example.com")},
+	)
+	condition, err := runConditionFunction(t, NewConditionInFunction(), types.StringValue("user.email"), values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if condition.Op.ValueString() != "in_list" {
+		t.Errorf("expected op 'in_list', got '%s'", condition.Op.ValueString())
+	}
+	if value := condition.Value.Elements()["list"]; value != types.StringValue("admin@example.com,security@example.com") {
+		t.Errorf("expected comma separated list value, got %v", value)
+	}
+
+	empty := types.TupleValueMust([]attr.Type{}, []attr.Value{})
+	if _, err := runConditionFunction(t, NewConditionInFunction(), types.StringValue("user.email"), empty); err == nil {
+		t.Error("expected an error without values")
+	}
+
+	comma := types.TupleValueMust([]attr.Type{types.StringType}, []attr.Value{types.StringValue("a,b")})
+	if _, err := runConditionFunction(t, NewConditionInFunction(), types.StringValue("user.email"), comma); err == nil {
+		t.Error("expected an error for a value containing a comma")
+	}
+}
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIMethods are the path item keys that hold operations
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// openAPIErrorAttrTypes describes a single validation error returned by validate_openapi
+var openAPIErrorAttrTypes = map[string]attr.Type{
+	"path":    types.StringType,
+	"message": types.StringType,
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ValidateOpenAPIFunction{}
+
+func NewValidateOpenAPIFunction() function.Function {
+	return &ValidateOpenAPIFunction{}
+}
+
+// ValidateOpenAPIFunction defines the function implementation.
+type ValidateOpenAPIFunction struct{}
+
+// openAPIError is a validation error located by a JSON pointer into the document
+type openAPIError struct {
+	Path    string
+	Message string
+}
+
+func (f *ValidateOpenAPIFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_openapi"
+}
+
+func (f *ValidateOpenAPIFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Validates an OpenAPI document before it is imported.",
+		Description: "Checks that an OpenAPI document (JSON or YAML) can be imported by agentlink_tools_import and returns the problems found, each with the JSON pointer of the offending value. An empty list means the document is valid.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "document",
+				Description: "The OpenAPI document, e.g. file(\"openapi.yaml\").",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.ObjectType{AttrTypes: openAPIErrorAttrTypes},
+		},
+	}
+}
+
+func (f *ValidateOpenAPIFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var document string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &document))
+	if resp.Error != nil {
+		return
+	}
+
+	errs := validateOpenAPI([]byte(document))
+
+	values := make([]attr.Value, 0, len(errs))
+	for _, e := range errs {
+		value, diags := types.ObjectValue(openAPIErrorAttrTypes, map[string]attr.Value{
+			"path":    types.StringValue(e.Path),
+			"message": types.StringValue(e.Message),
+		})
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+		values = append(values, value)
+	}
+	if resp.Error != nil {
+		return
+	}
+
+	result, diags := types.ListValue(types.ObjectType{AttrTypes: openAPIErrorAttrTypes}, values)
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
+
+// validateOpenAPI returns the problems that would make importing the document fail or
+// produce unusable tools, ordered by their location in the document
+func validateOpenAPI(content []byte) []openAPIError {
+	var document map[string]interface{}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return []openAPIError{{Path: "", Message: "The document is not valid JSON or YAML: " + err.Error()}}
+	}
+	if document == nil {
+		return []openAPIError{{Path: "", Message: "The document is empty."}}
+	}
+
+	errs := []openAPIError{}
+	add := func(path, format string, args ...interface{}) {
+		errs = append(errs, openAPIError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	openapi, hasOpenAPI := document["openapi"].(string)
+	swagger, hasSwagger := document["swagger"].(string)
+	switch {
+	case hasOpenAPI && !strings.HasPrefix(openapi, "3."):
+		add("/openapi", "Unsupported OpenAPI version %q; expected 3.x.", openapi)
+	case !hasOpenAPI && hasSwagger && swagger != "2.0":
+		add("/swagger", "Unsupported Swagger version %q; expected 2.0.", swagger)
+	case !hasOpenAPI && !hasSwagger:
+		add("", "The document must declare its version in an openapi or swagger field.")
+	}
+
+	info, ok := document["info"].(map[string]interface{})
+	if !ok {
+		add("/info", "The info object is required.")
+	} else {
+		for _, field := range []string{"title", "version"} {
+			if value, _ := info[field].(string); strings.TrimSpace(value) == "" {
+				add("/info/"+field, "The %s field is required.", field)
+			}
+		}
+	}
+
+	paths, ok := document["paths"].(map[string]interface{})
+	if !ok {
+		add("/paths", "The paths object is required.")
+		return errs
+	}
+
+	operationIDs := map[string]string{}
+	operations := 0
+	for _, path := range sortedKeys(paths) {
+		pathPointer := "/paths/" + escapeJSONPointer(path)
+		if !strings.HasPrefix(path, "/") {
+			add(pathPointer, "Paths must start with a slash.")
+		}
+
+		item, ok := paths[path].(map[string]interface{})
+		if !ok {
+			add(pathPointer, "The path item must be an object.")
+			continue
+		}
+
+		for _, method := range openAPIMethods {
+			raw, exists := item[method]
+			if !exists {
+				continue
+			}
+			operationPointer := pathPointer + "/" + method
+			operation, ok := raw.(map[string]interface{})
+			if !ok {
+				add(operationPointer, "The operation must be an object.")
+				continue
+			}
+			operations++
+
+			operationID, _ := operation["operationId"].(string)
+			if operationID == "" {
+				continue
+			}
+			if first, duplicate := operationIDs[operationID]; duplicate {
+				add(operationPointer+"/operationId", "The operationId %q is already used by %s; tool names would collide.", operationID, first)
+				continue
+			}
+			operationIDs[operationID] = strings.ToUpper(method) + " " + path
+		}
+	}
+
+	if operations == 0 {
+		add("/paths", "The document defines no operations, so no tools would be imported.")
+	}
+
+	return errs
+}
+
+// sortedKeys returns the keys of m in lexical order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escapeJSONPointer escapes a key for use as a JSON pointer (RFC 6901) reference token
+func escapeJSONPointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
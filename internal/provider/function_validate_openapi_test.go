@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateOpenAPIFunctionMetadata(t *testing.T) {
+	f := NewValidateOpenAPIFunction()
+
+	resp := &function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, resp)
+
+	if resp.Name != "validate_openapi" {
+		t.Errorf("expected function name 'validate_openapi', got '%s'", resp.Name)
+	}
+}
+
+func TestValidateOpenAPIFunctionRun(t *testing.T) {
+	ctx := context.Background()
+	f := NewValidateOpenAPIFunction()
+
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("openapi: 3.0.0\ninfo:\n  title: Users\n")}),
+	}
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.ListUnknown(types.ObjectType{AttrTypes: openAPIErrorAttrTypes})),
+	}
+	f.Run(ctx, req, resp)
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+
+	result, ok := resp.Result.Value().(types.List)
+	if !ok {
+		t.Fatalf("expected a list result, got %T", resp.Result.Value())
+	}
+	if len(result.Elements()) != 2 {
+		t.Errorf("expected errors for the missing version and paths, got %v", result)
+	}
+}
+
+func TestValidateOpenAPI(t *testing.T) {
+	valid := `
+openapi: 3.0.3
+info:
+  title: Users
+  version: "1.0"
+paths:
+  /users:
+    get:
+      operationId: listUsers
+    post:
+      operationId: createUser
+  /users/{id}:
+    get:
+      operationId: getUser
+`
+	if errs := validateOpenAPI([]byte(valid)); len(errs) != 0 {
+		t.Errorf("expected a valid document, got %+v", errs)
+	}
+
+	swagger := `{"swagger": "2.0", "info": {"title": "Users", "version": "1"}, "paths": {"/users": {"get": {}}}}`
+	if errs := validateOpenAPI([]byte(swagger)); len(errs) != 0 {
+		t.Errorf("expected a valid Swagger 2.0 document, got %+v", errs)
+	}
+
+	duplicates := `
+openapi: 3.1.0
+info:
+  title: Users
+  version: "1.0"
+paths:
+  /users:
+    get:
+      operationId: getUser
+  /users/{id}:
+    get:
+      operationId: getUser
+`
+	errs := validateOpenAPI([]byte(duplicates))
+	if len(errs) != 1 || errs[0].Path != "/paths/~1users~1{id}/get/operationId" {
+		t.Errorf("expected a duplicate operationId error, got %+v", errs)
+	}
+
+	errs = validateOpenAPI([]byte(`{"openapi": "4.0.0", "info": {}, "paths": {"users": "x"}}`))
+	expected := []string{"/openapi", "/info/title", "/info/version", "/paths/users", "/paths/users", "/paths"}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %+v", len(expected), errs)
+	}
+	for i, path := range expected {
+		if errs[i].Path != path {
+			t.Errorf("expected error %d at %s, got %+v", i, path, errs[i])
+		}
+	}
+
+	if errs := validateOpenAPI([]byte("openapi: [")); len(errs) != 1 || errs[0].Path != "" {
+		t.Errorf("expected a parse error, got %+v", errs)
+	}
+}
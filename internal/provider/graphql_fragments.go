@@ -0,0 +1,363 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// graphQLDefinitionKeywords are the keywords that start a type system definition
+var graphQLDefinitionKeywords = map[string]bool{
+	"schema": true, "scalar": true, "type": true, "interface": true, "union": true,
+	"enum": true, "input": true, "directive": true, "extend": true,
+}
+
+// graphQLFieldKinds are the definition kinds whose fields are merged across fragments
+var graphQLFieldKinds = map[string]bool{"type": true, "interface": true, "input": true}
+
+// sdlToken is a lexical token of a GraphQL SDL document. Kind is 'n' for names, 's' for strings
+// and 'p' for punctuators and other characters.
+type sdlToken struct {
+	kind       byte
+	text       string
+	start, end int
+}
+
+// sdlDefinition is a top-level definition of a GraphQL SDL document
+type sdlDefinition struct {
+	source      string
+	description string
+	extend      bool
+	kind        string
+	name        string
+	// header is the definition up to its field block, without description and extend keyword
+	header string
+	fields []sdlField
+	// hasFields is set for type, interface and input definitions with a field block
+	hasFields bool
+}
+
+// sdlField is a field of a type, interface or input definition
+type sdlField struct {
+	name string
+	// text is the complete field including its description
+	text string
+	// signature is the field without its description, used to detect conflicts
+	signature string
+}
+
+// mergeGraphQLFragments composes GraphQL SDL fragments, e.g. the subgraph schemas of a federated
+// graph, into a single schema. Type, interface and input definitions and their extensions are
+// merged field by field; fields defined identically in several fragments are kept once. Other
+// definitions are kept once when repeated identically. Conflicting definitions are an error.
+func mergeGraphQLFragments(fragments []string) (string, error) {
+	var order []string
+	merged := map[string]*sdlDefinition{}
+	var extensions []string
+
+	for i, fragment := range fragments {
+		definitions, err := parseSDLDefinitions(fragment)
+		if err != nil {
+			return "", fmt.Errorf("fragment %d: %w", i+1, err)
+		}
+
+		for _, def := range definitions {
+			key := def.kind + " " + def.name
+			if !def.hasFields && def.extend {
+				// Extensions of other kinds are valid on their own and kept as written
+				extensions = append(extensions, def.source)
+				continue
+			}
+
+			existing, ok := merged[key]
+			if !ok {
+				def := def
+				merged[key] = &def
+				order = append(order, key)
+				continue
+			}
+
+			if !def.hasFields || !existing.hasFields {
+				if normalizeSDL(existing.source) != normalizeSDL(def.source) {
+					return "", fmt.Errorf("fragment %d: conflicting definitions of %s", i+1, key)
+				}
+				continue
+			}
+
+			// A definition replaces the header of an extension seen first
+			if existing.extend && !def.extend {
+				existing.extend = false
+				existing.header = def.header
+				existing.description = def.description
+			}
+			for _, field := range def.fields {
+				if err := existing.addField(field); err != nil {
+					return "", fmt.Errorf("fragment %d: %w", i+1, err)
+				}
+			}
+		}
+	}
+
+	parts := make([]string, 0, len(order)+len(extensions))
+	for _, key := range order {
+		parts = append(parts, merged[key].render())
+	}
+	parts = append(parts, extensions...)
+
+	return strings.Join(parts, "\n\n") + "\n", nil
+}
+
+// addField adds a field to a merged definition, ignoring identical repeats
+func (d *sdlDefinition) addField(field sdlField) error {
+	for _, existing := range d.fields {
+		if existing.name != field.name {
+			continue
+		}
+		if normalizeSDL(existing.signature) != normalizeSDL(field.signature) {
+			return fmt.Errorf("conflicting definitions of field %s.%s", d.name, field.name)
+		}
+		return nil
+	}
+
+	d.fields = append(d.fields, field)
+	return nil
+}
+
+// render writes a merged definition back as SDL. Extensions without a definition in any fragment
+// are written as definitions, so the merged schema stands on its own.
+func (d *sdlDefinition) render() string {
+	if !d.hasFields {
+		return d.source
+	}
+
+	var b strings.Builder
+	if d.description != "" {
+		b.WriteString(d.description + "\n")
+	}
+	b.WriteString(d.header + " {\n")
+	for _, field := range d.fields {
+		b.WriteString("  " + field.text + "\n")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// parseSDLDefinitions splits a GraphQL SDL document into its top-level definitions
+func parseSDLDefinitions(src string) ([]sdlDefinition, error) {
+	tokens, err := lexSDL(src)
+	if err != nil {
+		return nil, err
+	}
+
+	var definitions []sdlDefinition
+	start, depth := 0, 0
+	hasKeyword := false
+	for i, tok := range tokens {
+		if depth == 0 && i > start {
+			isKeyword := tok.kind == 'n' && graphQLDefinitionKeywords[tok.text]
+			startsDefinition := hasKeyword && (tok.kind == 's' || isKeyword && tokens[i-1].text != "extend")
+			if startsDefinition {
+				def, err := newSDLDefinition(src, tokens[start:i])
+				if err != nil {
+					return nil, err
+				}
+				definitions = append(definitions, def)
+				start, hasKeyword = i, false
+			}
+		}
+		if depth == 0 && tok.kind == 'n' && graphQLDefinitionKeywords[tok.text] {
+			hasKeyword = true
+		}
+
+		switch tok.text {
+		case "{", "(":
+			depth++
+		case "}", ")":
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unexpected %q at offset %d", tok.text, tok.start)
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unterminated block")
+	}
+	if start < len(tokens) {
+		def, err := newSDLDefinition(src, tokens[start:])
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, def)
+	}
+
+	return definitions, nil
+}
+
+// newSDLDefinition parses the tokens of a single top-level definition
+func newSDLDefinition(src string, tokens []sdlToken) (sdlDefinition, error) {
+	def := sdlDefinition{source: src[tokens[0].start:tokens[len(tokens)-1].end]}
+
+	i := 0
+	if tokens[i].kind == 's' {
+		def.description = tokens[i].text
+		i++
+	}
+	if i < len(tokens) && tokens[i].text == "extend" {
+		def.extend = true
+		i++
+	}
+	if i >= len(tokens) || tokens[i].kind != 'n' || !graphQLDefinitionKeywords[tokens[i].text] {
+		return def, fmt.Errorf("expected a definition at offset %d", tokens[0].start)
+	}
+	def.kind = tokens[i].text
+	kindToken := tokens[i]
+	if def.kind == "directive" && i+2 < len(tokens) && tokens[i+1].text == "@" {
+		def.name = "@" + tokens[i+2].text
+	} else if def.kind != "schema" && i+1 < len(tokens) && tokens[i+1].kind == 'n' {
+		def.name = tokens[i+1].text
+	}
+
+	if !graphQLFieldKinds[def.kind] {
+		return def, nil
+	}
+
+	// The field block is the first top-level brace, after the name, interfaces and directives
+	open := -1
+	depth := 0
+	for j := i; j < len(tokens); j++ {
+		switch tokens[j].text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		case "{":
+			if depth == 0 {
+				open = j
+			}
+		}
+		if open >= 0 {
+			break
+		}
+	}
+	if open < 0 || tokens[len(tokens)-1].text != "}" {
+		return def, nil
+	}
+
+	def.hasFields = true
+	def.header = strings.TrimSpace(src[kindToken.start:tokens[open].start])
+	def.fields = parseSDLFields(src, tokens[open+1:len(tokens)-1])
+
+	return def, nil
+}
+
+// parseSDLFields splits the tokens of a field block into fields. A field starts at its
+// description or at a name followed by arguments or a colon.
+func parseSDLFields(src string, tokens []sdlToken) []sdlField {
+	var fields []sdlField
+	var current *sdlField
+	fieldStart, signatureStart := 0, 0
+	depth := 0
+
+	// finish completes the current field, which ends with the token before tokens[next]
+	finish := func(next int) {
+		if current != nil {
+			end := tokens[next-1].end
+			current.text = strings.TrimSpace(src[fieldStart:end])
+			current.signature = strings.TrimSpace(src[signatureStart:end])
+			fields = append(fields, *current)
+		}
+	}
+
+	for j, tok := range tokens {
+		if depth == 0 {
+			switch {
+			case tok.kind == 's' && (current == nil || current.name != ""):
+				finish(j)
+				current = &sdlField{}
+				fieldStart = tok.start
+			case tok.kind == 'n' && (j == 0 || tokens[j-1].text != "@") && j+1 < len(tokens) && (tokens[j+1].text == ":" || tokens[j+1].text == "("):
+				if current == nil || current.name != "" {
+					finish(j)
+					current = &sdlField{}
+					fieldStart = tok.start
+				}
+				current.name = tok.text
+				signatureStart = tok.start
+			}
+		}
+
+		switch tok.text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+		}
+	}
+	finish(len(tokens))
+
+	return fields
+}
+
+// lexSDL splits a GraphQL SDL document into tokens, skipping whitespace, commas and comments
+func lexSDL(src string) ([]sdlToken, error) {
+	var tokens []sdlToken
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], `"""`):
+			end := i + 3
+			for {
+				next := strings.Index(src[end:], `"""`)
+				if next < 0 {
+					return nil, fmt.Errorf("unterminated block string at offset %d", i)
+				}
+				end += next
+				if src[end-1] != '\\' {
+					break
+				}
+				end += 3
+			}
+			tokens = append(tokens, sdlToken{kind: 's', text: src[i : end+3], start: i, end: end + 3})
+			i = end + 3
+		case c == '"':
+			end := i + 1
+			for end < len(src) && src[end] != '"' {
+				if src[end] == '\n' {
+					return nil, fmt.Errorf("unterminated string at offset %d", i)
+				}
+				if src[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(src) {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			tokens = append(tokens, sdlToken{kind: 's', text: src[i : end+1], start: i, end: end + 1})
+			i = end + 1
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			end := i + 1
+			for end < len(src) && (src[end] == '_' || src[end] >= 'a' && src[end] <= 'z' || src[end] >= 'A' && src[end] <= 'Z' || src[end] >= '0' && src[end] <= '9') {
+				end++
+			}
+			tokens = append(tokens, sdlToken{kind: 'n', text: src[i:end], start: i, end: end})
+			i = end
+		default:
+			tokens = append(tokens, sdlToken{kind: 'p', text: src[i : i+1], start: i, end: i + 1})
+			i++
+		}
+	}
+
+	return tokens, nil
+}
+
+// normalizeSDL collapses whitespace so definitions formatted differently compare equal
+func normalizeSDL(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
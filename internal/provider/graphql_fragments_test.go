@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergeGraphQLFragments(t *testing.T) {
+	orders := `
+"""An order"""
+type Order @key(fields: "id") {
+  id: ID!
+  total(currency: String = "USD"): Float
+}
+
+type Query {
+  "Look up an order"
+  order(id: ID!): Order
+  # shared by every subgraph
+  _service: String
+}
+`
+	users := `
+extend type Query {
+  user(
+    id: ID!
+  ): User
+  _service: String
+}
+
+type User {
+  id: ID!, name: String
+}
+
+enum Role { ADMIN MEMBER }
+`
+	merged, err := mergeGraphQLFragments([]string{orders, users, "enum Role {\n  ADMIN\n  MEMBER\n}"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := `"""An order"""
+type Order @key(fields: "id") {
+  id: ID!
+  total(currency: String = "USD"): Float
+}
+
+type Query {
+  "Look up an order"
+  order(id: ID!): Order
+  _service: String
+  user(
+    id: ID!
+  ): User
+}
+
+type User {
+  id: ID!
+  name: String
+}
+
+enum Role { ADMIN MEMBER }
+`
+	if merged != expected {
+		t.Errorf("unexpected merged schema:\n%s", merged)
+	}
+}
+
+func TestMergeGraphQLFragments_ExtensionsOnly(t *testing.T) {
+	merged, err := mergeGraphQLFragments([]string{
+		"extend type Query { a: String }",
+		"extend type Query { b: Int }\nextend enum Role { GUEST }",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := "type Query {\n  a: String\n  b: Int\n}\n\nextend enum Role { GUEST }\n"
+	if merged != expected {
+		t.Errorf("unexpected merged schema:\n%s", merged)
+	}
+}
+
+func TestMergeGraphQLFragments_Conflicts(t *testing.T) {
+	for name, fragments := range map[string][]string{
+		"field":        {"type Query { a: String }", "type Query { a: Int }"},
+		"enum":         {"enum Role { ADMIN }", "enum Role { MEMBER }"},
+		"unbalanced":   {"type Query { a: String"},
+		"unterminated": {`type Query { "a: String }`},
+	} {
+		if _, err := mergeGraphQLFragments(fragments); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	_, err := mergeGraphQLFragments([]string{"type Query { a: String }", "type Query { a: Int }"})
+	if err == nil || !strings.Contains(err.Error(), "Query.a") {
+		t.Errorf("expected the conflicting field to be named, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResourceWithConfigure = &ApplicationResource{}
+
+func NewApplicationListResource() list.ListResource {
+	return &ApplicationResource{}
+}
+
+func (r *ApplicationResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the applications of the vendor.",
+	}
+}
+
+func (r *ApplicationResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	apps, err := r.client.GetApplications(ctx)
+	if err != nil {
+		var diags diag.Diagnostics
+		addClientError(&diags, "Unable to list applications", err)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i := range apps {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			app := &apps[i]
+			result := req.NewListResult(ctx)
+			result.DisplayName = app.Name
+
+			data := ApplicationResourceModel{
+				AdoptExisting:  types.BoolValue(false),
+				ModelProviders: types.SetNull(types.StringType),
+				CallbackURLs:   types.SetNull(types.StringType),
+			}
+			r.mapApplicationToModel(ctx, app, &data, &result.Diagnostics)
+
+			result.Diagnostics.Append(setIDIdentity(ctx, result.Identity, data.ID)...)
+			if req.IncludeResource {
+				result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
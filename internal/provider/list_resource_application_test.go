@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/clienttest"
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newTestListRequest builds a list request with the schemas of a resource supporting identity
+func newTestListRequest(t *testing.T, r resource.ResourceWithIdentity, includeResource bool) list.ListRequest {
+	t.Helper()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+
+	identityResp := &resource.IdentitySchemaResponse{}
+	r.IdentitySchema(context.Background(), resource.IdentitySchemaRequest{}, identityResp)
+
+	return list.ListRequest{
+		IncludeResource:        includeResource,
+		ResourceSchema:         schemaResp.Schema,
+		ResourceIdentitySchema: identityResp.IdentitySchema,
+	}
+}
+
+func TestApplicationListResource(t *testing.T) {
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	server.Put(clienttest.Applications, clienttest.Object{"id": "app-1", "name": "My Agent", "type": "agent"})
+
+	r := &ApplicationResource{client: client.NewClient(server.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)}
+	stream := &list.ListResultsStream{}
+	r.List(context.Background(), newTestListRequest(t, r, true), stream)
+
+	count := 0
+	for result := range stream.Results {
+		count++
+		if result.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+		}
+		if result.DisplayName != "My Agent" {
+			t.Errorf("expected display name 'My Agent', got '%s'", result.DisplayName)
+		}
+
+		var identity IDIdentityModel
+		result.Diagnostics.Append(result.Identity.Get(context.Background(), &identity)...)
+		if identity.ID.ValueString() != "app-1" {
+			t.Errorf("expected identity id 'app-1', got '%s'", identity.ID.ValueString())
+		}
+
+		var name types.String
+		result.Diagnostics.Append(result.Resource.GetAttribute(context.Background(), path.Root("name"), &name)...)
+		if name.ValueString() != "My Agent" {
+			t.Errorf("expected resource name 'My Agent', got '%s'", name.ValueString())
+		}
+	}
+
+	if count != 1 {
+		t.Errorf("expected 1 result, got %d", count)
+	}
+}
+
+func TestApplicationListResourceMetadata(t *testing.T) {
+	r := NewApplicationListResource()
+
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	if resp.TypeName != "agentlink_application" {
+		t.Errorf("expected type name 'agentlink_application', got '%s'", resp.TypeName)
+	}
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"iter"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResourceWithConfigure = &ConditionalPolicyResource{}
+var _ list.ListResourceWithConfigure = &RbacPolicyResource{}
+var _ list.ListResourceWithConfigure = &MaskingPolicyResource{}
+
+func NewConditionalPolicyListResource() list.ListResource {
+	return &ConditionalPolicyResource{}
+}
+
+func NewRbacPolicyListResource() list.ListResource {
+	return &RbacPolicyResource{}
+}
+
+func NewMaskingPolicyListResource() list.ListResource {
+	return &MaskingPolicyResource{}
+}
+
+func (r *ConditionalPolicyResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the conditional policies of the vendor.",
+	}
+}
+
+func (r *ConditionalPolicyResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	policies, err := r.client.GetConditionalPolicies(ctx)
+	stream.Results = policyListResults(ctx, req, policies, err, setPolicyListResource)
+}
+
+func (r *RbacPolicyResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the RBAC policies of the vendor.",
+	}
+}
+
+func (r *RbacPolicyResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	policies, err := r.client.GetRbacPolicies(ctx)
+	stream.Results = policyListResults(ctx, req, policies, err, func(ctx context.Context, resource *tfsdk.Resource, policy *client.Policy) diag.Diagnostics {
+		diags := setPolicyListResource(ctx, resource, policy)
+		diags.Append(resource.SetAttribute(ctx, path.Root("type"), policy.Type)...)
+		diags.Append(resource.SetAttribute(ctx, path.Root("keys"), nonNilStrings(policy.Keys))...)
+		return diags
+	})
+}
+
+func (r *MaskingPolicyResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the masking policies of the vendor.",
+	}
+}
+
+func (r *MaskingPolicyResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	policies, err := r.client.GetMaskingPolicies(ctx)
+	stream.Results = policyListResults(ctx, req, policies, err, setPolicyListResource)
+}
+
+// policyListResults streams a list result for each policy, identified by its ID and named after it.
+func policyListResults(
+	ctx context.Context,
+	req list.ListRequest,
+	policies []client.Policy,
+	err error,
+	setResource func(context.Context, *tfsdk.Resource, *client.Policy) diag.Diagnostics,
+) iter.Seq[list.ListResult] {
+	if err != nil {
+		var diags diag.Diagnostics
+		addClientError(&diags, "Unable to list policies", err)
+		return list.ListResultsStreamDiagnostics(diags)
+	}
+
+	return func(push func(list.ListResult) bool) {
+		for i := range policies {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			policy := &policies[i]
+			result := req.NewListResult(ctx)
+			result.DisplayName = policy.Name
+
+			result.Diagnostics.Append(setIDIdentity(ctx, result.Identity, types.StringValue(policy.ID))...)
+			if req.IncludeResource {
+				result.Diagnostics.Append(setResource(ctx, result.Resource, policy)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// setPolicyListResource sets the attributes shared by all policy resources. Type-specific
+// blocks such as targeting and masking configuration are left unset.
+func setPolicyListResource(ctx context.Context, resource *tfsdk.Resource, policy *client.Policy) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(resource.SetAttribute(ctx, path.Root("id"), policy.ID)...)
+	diags.Append(resource.SetAttribute(ctx, path.Root("name"), policy.Name)...)
+	diags.Append(resource.SetAttribute(ctx, path.Root("description"), policy.Description)...)
+	diags.Append(resource.SetAttribute(ctx, path.Root("enabled"), policy.Enabled)...)
+	diags.Append(resource.SetAttribute(ctx, path.Root("internal_tool_ids"), nonNilStrings(policy.InternalToolIDs))...)
+	diags.Append(resource.SetAttribute(ctx, path.Root("disable_on_destroy"), false)...)
+	diags.Append(resource.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+
+	if len(policy.AppIDs) > 0 {
+		diags.Append(resource.SetAttribute(ctx, path.Root("app_ids"), policy.AppIDs)...)
+	}
+
+	if len(policy.ToolTags) > 0 {
+		diags.Append(resource.SetAttribute(ctx, path.Root("tool_tags"), policy.ToolTags)...)
+	}
+
+	if len(policy.TenantIDs) > 0 {
+		diags.Append(resource.SetAttribute(ctx, path.Root("tenant_ids"), policy.TenantIDs)...)
+	} else if policy.TenantID != "" {
+		diags.Append(resource.SetAttribute(ctx, path.Root("tenant_id"), policy.TenantID)...)
+	}
+
+	return diags
+}
+
+// nonNilStrings returns an empty slice for nil, so the attribute is set to an empty list rather than null.
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
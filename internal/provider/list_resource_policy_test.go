@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/clienttest"
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRbacPolicyListResource(t *testing.T) {
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	server.Put(clienttest.Policies, clienttest.Object{"id": "policy-1", "name": "Admins", "type": "RBAC_ROLES", "keys": []interface{}{"admin"}})
+	server.Put(clienttest.Policies, clienttest.Object{"id": "policy-2", "name": "Mask PII", "type": "MASKING"})
+
+	r := &RbacPolicyResource{client: client.NewClient(server.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)}
+	stream := &list.ListResultsStream{}
+	r.List(context.Background(), newTestListRequest(t, r, true), stream)
+
+	var ids []string
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+		}
+
+		var identity IDIdentityModel
+		result.Diagnostics.Append(result.Identity.Get(context.Background(), &identity)...)
+		ids = append(ids, identity.ID.ValueString())
+
+		var keys types.List
+		result.Diagnostics.Append(result.Resource.GetAttribute(context.Background(), path.Root("keys"), &keys)...)
+		if len(keys.Elements()) != 1 {
+			t.Errorf("expected 1 key, got %v", keys)
+		}
+	}
+
+	if len(ids) != 1 || ids[0] != "policy-1" {
+		t.Errorf("expected only the RBAC policy, got %v", ids)
+	}
+}
+
+func TestPolicyListResultsLimit(t *testing.T) {
+	r := &ConditionalPolicyResource{}
+	req := newTestListRequest(t, r, false)
+	req.Limit = 1
+
+	policies := []client.Policy{{ID: "policy-1", Name: "First"}, {ID: "policy-2", Name: "Second"}}
+
+	count := 0
+	for result := range policyListResults(context.Background(), req, policies, nil, setPolicyListResource) {
+		count++
+		if result.DisplayName != "First" {
+			t.Errorf("expected display name 'First', got '%s'", result.DisplayName)
+		}
+	}
+	if count != 1 {
+		t.Errorf("expected limit to stop after 1 result, got %d", count)
+	}
+}
@@ -0,0 +1,435 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// expandPolicyTenants converts the tenant_id and tenant_ids attributes into API request values.
+// tenant_id is kept for backwards compatibility; validatePolicyTenants rejects combining it with
+// tenant_ids. Unset attributes convert to empty values, which create requests omit.
+func expandPolicyTenants(ctx context.Context, tenantID types.String, tenantIDs types.List, diags *diag.Diagnostics) (string, []string) {
+	if tenantIDs.IsNull() || tenantIDs.IsUnknown() {
+		return tenantID.ValueString(), nil
+	}
+
+	var ids []string
+	diags.Append(tenantIDs.ElementsAs(ctx, &ids, false)...)
+	return "", nonNilStrings(ids)
+}
+
+// expandPolicyTenantsUpdate converts the tenant_id and tenant_ids attributes into the values of an
+// update request. Only the configured attribute is sent; an attribute removed from the configuration
+// is sent empty so the API clears it, and an attribute that was never set is left out.
+func expandPolicyTenantsUpdate(ctx context.Context, tenantID types.String, tenantIDs types.List, state tfsdk.State, diags *diag.Diagnostics) (*string, *[]string) {
+	var priorTenantID types.String
+	var priorTenantIDs types.List
+
+	diags.Append(state.GetAttribute(ctx, path.Root("tenant_id"), &priorTenantID)...)
+	diags.Append(state.GetAttribute(ctx, path.Root("tenant_ids"), &priorTenantIDs)...)
+
+	id, ids := expandPolicyTenants(ctx, tenantID, tenantIDs, diags)
+
+	var idValue *string
+	if !tenantID.IsNull() || !priorTenantID.IsNull() {
+		idValue = &id
+	}
+	var idsValue *[]string
+	if !tenantIDs.IsNull() || !priorTenantIDs.IsNull() {
+		ids = nonNilStrings(ids)
+		idsValue = &ids
+	}
+	return idValue, idsValue
+}
+
+// validatePolicyTenants reports configurations setting both tenant_id and tenant_ids. Unknown
+// values are skipped until they are known.
+func validatePolicyTenants(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) {
+	var tenantID types.String
+	var tenantIDs types.List
+
+	diags.Append(config.GetAttribute(ctx, path.Root("tenant_id"), &tenantID)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("tenant_ids"), &tenantIDs)...)
+	if diags.HasError() || tenantID.IsNull() || tenantIDs.IsNull() {
+		return
+	}
+
+	diags.AddAttributeError(
+		path.Root("tenant_ids"),
+		"Conflicting Tenant Attributes",
+		"Only one of tenant_id or tenant_ids may be set. Use tenant_ids to scope a policy to one or more tenants.",
+	)
+}
+
+// flattenPolicyTenantIDs returns the tenant_ids state value for a policy response.
+// Policies managed through the singular tenant_id attribute keep tenant_ids unset. Tenants removed
+// outside Terraform are reported as drift; a configured empty list is kept as is.
+func flattenPolicyTenantIDs(ctx context.Context, policy *client.Policy, tenantID types.String, current types.List) types.List {
+	if !tenantID.IsNull() {
+		return types.ListNull(types.StringType)
+	}
+	if len(policy.TenantIDs) == 0 {
+		if !current.IsNull() && !current.IsUnknown() && len(current.Elements()) == 0 {
+			return current
+		}
+		return types.ListNull(types.StringType)
+	}
+
+	values, _ := types.ListValueFrom(ctx, types.StringType, policy.TenantIDs)
+	return values
+}
+
+// PolicyTargetingModel describes the targeting block shared by policy resources.
+type PolicyTargetingModel struct {
+	If   *PolicyIfModel     `tfsdk:"if"`
+	Then *PolicyResultModel `tfsdk:"then"`
+	Else *PolicyResultModel `tfsdk:"else"`
+}
+
+// PolicyIfModel describes the conditions block of a targeting rule.
+type PolicyIfModel struct {
+	Conditions []PolicyConditionModel `tfsdk:"conditions"`
+}
+
+// PolicyConditionModel describes a single targeting condition.
+type PolicyConditionModel struct {
+	Attribute types.String `tfsdk:"attribute"`
+	Negate    types.Bool   `tfsdk:"negate"`
+	Op        types.String `tfsdk:"op"`
+	Value     types.Map    `tfsdk:"value"`
+}
+
+// PolicyResultModel describes the outcome of a targeting rule.
+type PolicyResultModel struct {
+	Result         types.String `tfsdk:"result"`
+	ApprovalFlowID types.String `tfsdk:"approval_flow_id"`
+}
+
+// policyToolTagsAttribute returns the tool_tags attribute shared by policy resources.
+func policyToolTagsAttribute() schema.SetAttribute {
+	return schema.SetAttribute{
+		Description: "Tags of the tools this policy applies to, in addition to internal_tool_ids. Tools tagged after the policy is created, e.g. by agentlink_tools_import, are covered without changing the policy.",
+		Optional:    true,
+		ElementType: types.StringType,
+	}
+}
+
+// policyResultAttributes returns the attributes of a then/else result block.
+func policyResultAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"result": schema.StringAttribute{
+			Description: "The policy result. Valid values: ALLOW, DENY, APPROVAL_REQUIRED.",
+			Required:    true,
+		},
+		"approval_flow_id": schema.StringAttribute{
+			Description: "The approval flow ID (required when result is APPROVAL_REQUIRED).",
+			Optional:    true,
+		},
+	}
+}
+
+// policyConditionAttributes returns the attributes of a targeting condition.
+func policyConditionAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"attribute": schema.StringAttribute{
+			Description: "The attribute to evaluate.",
+			Required:    true,
+		},
+		"negate": schema.BoolAttribute{
+			Description: "Whether to negate the condition.",
+			Required:    true,
+		},
+		"op": schema.StringAttribute{
+			Description: "The operation to perform.",
+			Required:    true,
+		},
+		"value": schema.MapAttribute{
+			Description: "The value to compare against.",
+			Required:    true,
+			ElementType: types.StringType,
+		},
+	}
+}
+
+// policyTargetingSchema returns the targeting attribute shared by policy resources.
+func policyTargetingSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Targeting rules for when this policy applies.",
+		Optional:    true,
+		Attributes: map[string]schema.Attribute{
+			"if": schema.SingleNestedAttribute{
+				Description: "Conditions block.",
+				Required:    true,
+				Attributes: map[string]schema.Attribute{
+					"conditions": schema.ListNestedAttribute{
+						Description: "List of conditions to evaluate.",
+						Required:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: policyConditionAttributes(),
+						},
+					},
+				},
+			},
+			"then": schema.SingleNestedAttribute{
+				Description: "Result block applied when the conditions are met.",
+				Required:    true,
+				Attributes:  policyResultAttributes(),
+			},
+			"else": schema.SingleNestedAttribute{
+				Description: "Fallback result block applied when the conditions are not met. If omitted, the policy does not apply to unmatched requests.",
+				Optional:    true,
+				Attributes:  policyResultAttributes(),
+			},
+		},
+	}
+}
+
+// expandPolicyTargeting converts a targeting object into the API representation.
+func expandPolicyTargeting(ctx context.Context, targeting types.Object, diags *diag.Diagnostics) *client.PolicyTargeting {
+	if targeting.IsNull() || targeting.IsUnknown() {
+		return nil
+	}
+
+	var model PolicyTargetingModel
+	diags.Append(targeting.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil
+	}
+
+	result := &client.PolicyTargeting{
+		If: client.PolicyIfBlock{
+			Conditions: []client.PolicyCondition{},
+		},
+	}
+
+	if model.If != nil {
+		result.If.Conditions = expandPolicyConditions(ctx, model.If.Conditions, diags)
+		if diags.HasError() {
+			return nil
+		}
+	}
+
+	if model.Then != nil {
+		result.Then = expandPolicyResult(model.Then)
+	}
+
+	if model.Else != nil {
+		elseBlock := expandPolicyResult(model.Else)
+		result.Else = &elseBlock
+	}
+
+	return result
+}
+
+// flattenPolicyTargeting returns the targeting state value for a policy response. Condition values
+// are returned by the API as native JSON values, so values equal to the current state, e.g. "5.0"
+// and 5, keep their configured form to avoid perpetual drift.
+func flattenPolicyTargeting(ctx context.Context, targeting *client.PolicyTargeting, current types.Object, diags *diag.Diagnostics) types.Object {
+	objectType := policyTargetingSchema().GetType().(types.ObjectType)
+	// Policies without targeting may be returned with an empty targeting object
+	if targeting == nil || (len(targeting.If.Conditions) == 0 && targeting.Then.Result == "" && targeting.Else == nil) {
+		return types.ObjectNull(objectType.AttrTypes)
+	}
+
+	var previous PolicyTargetingModel
+	if !current.IsNull() && !current.IsUnknown() {
+		diags.Append(current.As(ctx, &previous, basetypes.ObjectAsOptions{})...)
+	}
+
+	model := PolicyTargetingModel{
+		If:   &PolicyIfModel{Conditions: []PolicyConditionModel{}},
+		Then: flattenPolicyResult(targeting.Then, previous.Then),
+	}
+	if targeting.Else != nil {
+		model.Else = flattenPolicyResult(*targeting.Else, previous.Else)
+	}
+
+	for i, condition := range targeting.If.Conditions {
+		var previousValues map[string]string
+		if previous.If != nil && i < len(previous.If.Conditions) {
+			diags.Append(previous.If.Conditions[i].Value.ElementsAs(ctx, &previousValues, false)...)
+		}
+
+		values := make(map[string]string, len(condition.Value))
+		for k, v := range condition.Value {
+			values[k] = conditionValueString(v)
+			if prev, ok := previousValues[k]; ok && sameConditionValue(prev, values[k]) {
+				values[k] = prev
+			}
+		}
+
+		value, d := types.MapValueFrom(ctx, types.StringType, values)
+		diags.Append(d...)
+		model.If.Conditions = append(model.If.Conditions, PolicyConditionModel{
+			Attribute: types.StringValue(condition.Attribute),
+			Negate:    types.BoolValue(condition.Negate),
+			Op:        types.StringValue(condition.Op),
+			Value:     value,
+		})
+	}
+
+	obj, d := types.ObjectValueFrom(ctx, objectType.AttrTypes, model)
+	diags.Append(d...)
+	return obj
+}
+
+// flattenPolicyResult converts a then/else block into its state value, keeping an unset
+// approval_flow_id unset
+func flattenPolicyResult(block client.PolicyThenBlock, current *PolicyResultModel) *PolicyResultModel {
+	result := &PolicyResultModel{
+		Result:         types.StringValue(block.Result),
+		ApprovalFlowID: types.StringValue(block.ApprovalFlowID),
+	}
+	if block.ApprovalFlowID == "" && (current == nil || current.ApprovalFlowID.IsNull()) {
+		result.ApprovalFlowID = types.StringNull()
+	}
+	return result
+}
+
+// conditionValueString converts a condition value returned by the API into its Terraform string form
+func conditionValueString(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case nil:
+		return ""
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(encoded)
+}
+
+// sameConditionValue reports whether two condition values are equal, comparing numbers by value
+func sameConditionValue(a, b string) bool {
+	if a == b {
+		return true
+	}
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	return errA == nil && errB == nil && x == y
+}
+
+// validatePolicyTargetingResults reports then/else blocks with an APPROVAL_REQUIRED result
+// but no approval_flow_id. Unknown values are skipped until they are known.
+func validatePolicyTargetingResults(ctx context.Context, targeting types.Object, diags *diag.Diagnostics) {
+	if targeting.IsNull() || targeting.IsUnknown() {
+		return
+	}
+
+	var model PolicyTargetingModel
+	diags.Append(targeting.As(ctx, &model, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})...)
+	if diags.HasError() {
+		return
+	}
+
+	blocks := map[string]*PolicyResultModel{"then": model.Then, "else": model.Else}
+	for _, name := range []string{"then", "else"} {
+		block := blocks[name]
+		if block == nil || block.Result.IsUnknown() || block.Result.ValueString() != "APPROVAL_REQUIRED" {
+			continue
+		}
+		if block.ApprovalFlowID.IsUnknown() || block.ApprovalFlowID.ValueString() != "" {
+			continue
+		}
+		diags.AddAttributeError(
+			path.Root("targeting").AtName(name).AtName("approval_flow_id"),
+			"Missing Approval Flow",
+			"An approval_flow_id is required when result is APPROVAL_REQUIRED. Reference an agentlink_approval_flow, e.g. agentlink_approval_flow.example.id.",
+		)
+	}
+}
+
+// expandPolicyConditions converts targeting conditions into the API representation.
+func expandPolicyConditions(ctx context.Context, conditions []PolicyConditionModel, diags *diag.Diagnostics) []client.PolicyCondition {
+	result := []client.PolicyCondition{}
+	for _, condition := range conditions {
+		var values map[string]string
+		diags.Append(condition.Value.ElementsAs(ctx, &values, false)...)
+		if diags.HasError() {
+			return result
+		}
+
+		value := make(map[string]interface{}, len(values))
+		for k, v := range values {
+			value[k] = v
+		}
+
+		result = append(result, client.PolicyCondition{
+			Attribute: condition.Attribute.ValueString(),
+			Negate:    condition.Negate.ValueBool(),
+			Op:        condition.Op.ValueString(),
+			Value:     value,
+		})
+	}
+	return result
+}
+
+// expandPolicyResult converts a then/else block into the API representation.
+func expandPolicyResult(model *PolicyResultModel) client.PolicyThenBlock {
+	return client.PolicyThenBlock{
+		Result:         model.Result.ValueString(),
+		ApprovalFlowID: model.ApprovalFlowID.ValueString(),
+	}
+}
+
+// policyResourceTypes maps each policy type to the resource that manages policies of that type
+var policyResourceTypes = map[string]string{
+	"CONDITIONAL":      "agentlink_conditional_policy",
+	"RBAC_ROLES":       "agentlink_rbac_policy",
+	"RBAC_PERMISSIONS": "agentlink_rbac_policy",
+	"MASKING":          "agentlink_masking_policy",
+}
+
+// importTypedPolicy imports a policy by ID or identity after looking it up through the generic policy
+// endpoint. Reads of the importing resource use a type-specific endpoint, so importing a policy of
+// another type would otherwise fail with a not found error on the first read.
+func importTypedPolicy(ctx context.Context, c *client.Client, typeName string, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var id types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := c.GetConditionalPolicy(ctx, id.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError("Policy Not Found", "No policy with ID "+id.ValueString()+" exists.")
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read policy", err)
+		return
+	}
+
+	expected, ok := policyResourceTypes[policy.Type]
+	switch {
+	case !ok:
+		resp.Diagnostics.AddError(
+			"Unsupported Policy Type",
+			fmt.Sprintf("Policy %s has type %q, which %s cannot manage.", policy.ID, policy.Type, typeName),
+		)
+	case expected != typeName:
+		resp.Diagnostics.AddError(
+			"Policy Type Mismatch",
+			fmt.Sprintf("Policy %s has type %q and cannot be imported as %s. Import it as %s instead, e.g. terraform import %s.<name> %s", policy.ID, policy.Type, typeName, expected, expected, policy.ID),
+		)
+	}
+}
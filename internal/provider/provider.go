@@ -3,10 +3,22 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/frontegg/terraform-provider-agentlink/clienttest"
 	"github.com/frontegg/terraform-provider-agentlink/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -16,6 +28,9 @@ import (
 
 // Ensure FronteggProvider satisfies various provider interfaces.
 var _ provider.Provider = &FronteggProvider{}
+var _ provider.ProviderWithListResources = &FronteggProvider{}
+var _ provider.ProviderWithFunctions = &FronteggProvider{}
+var _ provider.ProviderWithEphemeralResources = &FronteggProvider{}
 
 // regionURLs maps region identifiers to their API base URLs
 var regionURLs = map[string]string{
@@ -32,9 +47,72 @@ func validRegions() []string {
 	return []string{"stg", "eu", "us", "au", "ca", "uk"}
 }
 
+// validateBaseURL checks that a base_url override is an absolute HTTPS URL. Plain HTTP is only
+// accepted for loopback hosts, e.g. a local fake of the API.
+func validateBaseURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("the base URL %q cannot be parsed: %w", raw, err)
+	}
+	if u.Host == "" || u.Hostname() == "" {
+		return fmt.Errorf("the base URL %q has no host; expected a URL such as https://api.frontegg.com", raw)
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("the base URL %q must not contain credentials, a query or a fragment", raw)
+	}
+
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		if isLoopbackHost(u.Hostname()) {
+			return nil
+		}
+		return fmt.Errorf("the base URL %q uses http; use https so credentials are not sent in cleartext", raw)
+	default:
+		return fmt.Errorf("the base URL %q has unsupported scheme %q; expected https", raw, u.Scheme)
+	}
+}
+
+// nonProductionHostLabels are host name labels that mark pre-release Frontegg environments,
+// e.g. api.stg.frontegg.com
+var nonProductionHostLabels = []string{"stg", "staging", "dev", "preview"}
+
+// isNonProductionBaseURL reports whether a resolved base URL points at a pre-release environment
+func isNonProductionBaseURL(baseURL string) bool {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return false
+	}
+	for _, label := range strings.Split(u.Hostname(), ".") {
+		if slices.Contains(nonProductionHostLabels, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// isLoopbackHost reports whether host is localhost or a loopback IP address
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// mockServer returns the in-memory fake used in mock mode. It is shared by all provider
+// instances in the process, so objects survive between steps of in-process acceptance tests.
+var mockServer = sync.OnceValue(func() *clienttest.Server {
+	return clienttest.NewInMemoryServer()
+})
+
 // FronteggProvider defines the provider implementation.
 type FronteggProvider struct {
 	version string
+
+	// transport overrides the HTTP transport of the API client, used to record and replay acceptance tests
+	transport http.RoundTripper
 }
 
 // FronteggProviderModel describes the provider data model.
@@ -43,6 +121,15 @@ type FronteggProviderModel struct {
 	BaseURL  types.String `tfsdk:"base_url"`
 	ClientID types.String `tfsdk:"client_id"`
 	Secret   types.String `tfsdk:"secret"`
+
+	LogAPIPayloads  types.Bool `tfsdk:"log_api_payloads"`
+	MockMode        types.Bool `tfsdk:"mock_mode"`
+	StagingInsecure types.Bool `tfsdk:"staging_insecure"`
+
+	MaxConcurrentRequests types.Int64  `tfsdk:"max_concurrent_requests"`
+	MaintenanceMaxWait    types.String `tfsdk:"maintenance_max_wait"`
+	MaxRetries            types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMax          types.String `tfsdk:"retry_wait_max"`
 }
 
 // New creates a new provider factory function
@@ -68,7 +155,7 @@ func (p *FronteggProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Optional:    true,
 			},
 			"base_url": schema.StringAttribute{
-				Description: "Override the base URL for the Frontegg API. If set, this takes precedence over region. Can also be set via FRONTEGG_BASE_URL environment variable.",
+				Description: "Override the base URL for the Frontegg API. If set, this takes precedence over region. Must be an https URL; http is only accepted for loopback hosts. Can also be set via FRONTEGG_BASE_URL environment variable.",
 				Optional:    true,
 			},
 			"client_id": schema.StringAttribute{
@@ -80,6 +167,34 @@ func (p *FronteggProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"log_api_payloads": schema.BoolAttribute{
+				Description: "Log API request and response bodies at TRACE level (TF_LOG=TRACE). Secrets, tokens and PII values are redacted. Defaults to false. Can also be set via FRONTEGG_LOG_API_PAYLOADS environment variable.",
+				Optional:    true,
+			},
+			"mock_mode": schema.BoolAttribute{
+				Description: "Route all API requests to an in-memory fake of the Frontegg API instead of a real vendor, for module unit tests. No credentials are needed and nothing is persisted beyond the provider process. Defaults to false. Can also be set via FRONTEGG_MOCK_MODE environment variable.",
+				Optional:    true,
+			},
+			"staging_insecure": schema.BoolAttribute{
+				Description: "Accept API certificates that do not match the host name, e.g. temporary certificates of pre-release environments. The certificate chain is still verified. Only allowed with region stg or a non-production base_url. Defaults to false. Can also be set via FRONTEGG_STAGING_INSECURE environment variable.",
+				Optional:    true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "The maximum number of API requests, including authentication, in flight at once. A request is in flight until its response has been read. Further requests wait for a running one to finish, which keeps large configurations applied with Terraform's default parallelism below the Frontegg rate limits. Unlimited when not set. Can also be set via FRONTEGG_MAX_CONCURRENT_REQUESTS environment variable.",
+				Optional:    true,
+			},
+			"maintenance_max_wait": schema.StringAttribute{
+				Description: "How long API requests are retried while Frontegg reports a maintenance window, as a duration such as \"30m\". Retries back off up to 2 minutes apart, so applies pause rather than fail halfway, and resources whose requests waited report a warning. \"0s\" disables the retries. Defaults to \"10m\". Can also be set via FRONTEGG_MAINTENANCE_MAX_WAIT environment variable.",
+				Optional:    true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "How many times API requests rejected with a transient error (429, 502, 503 or 504) are retried, with exponential backoff and jitter. POST requests are only retried on 429, or on 503 with Retry-After, since the API may have created the object before a gateway error. 0 disables the retries. Defaults to 3. Can also be set via FRONTEGG_MAX_RETRIES environment variable.",
+				Optional:    true,
+			},
+			"retry_wait_max": schema.StringAttribute{
+				Description: "The maximum delay between retries of API requests rejected with a transient error, as a duration such as \"1m\". Also caps delays requested by the API through Retry-After. Defaults to \"30s\". Can also be set via FRONTEGG_RETRY_WAIT_MAX environment variable.",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -118,6 +233,22 @@ func (p *FronteggProvider) Configure(ctx context.Context, req provider.Configure
 	baseURL := os.Getenv("FRONTEGG_BASE_URL")
 	clientID := os.Getenv("FRONTEGG_CLIENT_ID")
 	secret := os.Getenv("FRONTEGG_SECRET")
+	logPayloads := os.Getenv("FRONTEGG_LOG_API_PAYLOADS") == "true"
+	mockMode := os.Getenv("FRONTEGG_MOCK_MODE") == "true"
+	stagingInsecure := os.Getenv("FRONTEGG_STAGING_INSECURE") == "true"
+	maxConcurrentRequests := int64(0)
+	if value := os.Getenv("FRONTEGG_MAX_CONCURRENT_REQUESTS"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid Maximum Concurrent Requests",
+				fmt.Sprintf("FRONTEGG_MAX_CONCURRENT_REQUESTS must be a whole number of at least 1, got '%s'.", value),
+			)
+			return
+		}
+		maxConcurrentRequests = parsed
+	}
 
 	// Override with config values if provided
 	if !config.Region.IsNull() {
@@ -132,9 +263,125 @@ func (p *FronteggProvider) Configure(ctx context.Context, req provider.Configure
 	if !config.Secret.IsNull() {
 		secret = config.Secret.ValueString()
 	}
+	if !config.LogAPIPayloads.IsNull() && !config.LogAPIPayloads.IsUnknown() {
+		logPayloads = config.LogAPIPayloads.ValueBool()
+	}
+	if !config.MockMode.IsNull() && !config.MockMode.IsUnknown() {
+		mockMode = config.MockMode.ValueBool()
+	}
+	if !config.StagingInsecure.IsNull() && !config.StagingInsecure.IsUnknown() {
+		stagingInsecure = config.StagingInsecure.ValueBool()
+	}
+	maintenanceMaxWait := os.Getenv("FRONTEGG_MAINTENANCE_MAX_WAIT")
+	if !config.MaintenanceMaxWait.IsNull() && !config.MaintenanceMaxWait.IsUnknown() {
+		maintenanceMaxWait = config.MaintenanceMaxWait.ValueString()
+	}
+	maintenanceWait := client.DefaultMaintenanceMaxWait
+	if maintenanceMaxWait != "" {
+		wait, err := time.ParseDuration(maintenanceMaxWait)
+		if err != nil || wait < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("maintenance_max_wait"),
+				"Invalid Maintenance Max Wait",
+				fmt.Sprintf("maintenance_max_wait must be a non-negative duration such as \"30m\", got '%s'.", maintenanceMaxWait),
+			)
+			return
+		}
+		maintenanceWait = wait
+	}
+	maxRetries := int64(client.DefaultMaxRetries)
+	if value := os.Getenv("FRONTEGG_MAX_RETRIES"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Maximum Retries",
+				fmt.Sprintf("FRONTEGG_MAX_RETRIES must be a non-negative whole number, got '%s'.", value),
+			)
+			return
+		}
+		maxRetries = parsed
+	}
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		maxRetries = config.MaxRetries.ValueInt64()
+		if maxRetries < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Maximum Retries",
+				fmt.Sprintf("max_retries must not be negative, got %d.", maxRetries),
+			)
+			return
+		}
+	}
+	retryWaitMax := os.Getenv("FRONTEGG_RETRY_WAIT_MAX")
+	if !config.RetryWaitMax.IsNull() && !config.RetryWaitMax.IsUnknown() {
+		retryWaitMax = config.RetryWaitMax.ValueString()
+	}
+	retryWait := client.DefaultRetryWaitMax
+	if retryWaitMax != "" {
+		wait, err := time.ParseDuration(retryWaitMax)
+		if err != nil || wait < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_wait_max"),
+				"Invalid Retry Wait Max",
+				fmt.Sprintf("retry_wait_max must be a non-negative duration such as \"1m\", got '%s'.", retryWaitMax),
+			)
+			return
+		}
+		retryWait = wait
+	}
+	if !config.MaxConcurrentRequests.IsNull() && !config.MaxConcurrentRequests.IsUnknown() {
+		maxConcurrentRequests = config.MaxConcurrentRequests.ValueInt64()
+		if maxConcurrentRequests < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid Maximum Concurrent Requests",
+				fmt.Sprintf("max_concurrent_requests must be at least 1, got %d.", maxConcurrentRequests),
+			)
+			return
+		}
+	}
+
+	if mockMode {
+		mock := mockServer()
+		c := client.NewClient(mock.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)
+		c.SetTransport(mock.Transport())
+		c.SetLogPayloads(logPayloads)
+
+		resp.Diagnostics.AddWarning(
+			"Frontegg Mock Mode Enabled",
+			"The provider is using an in-memory fake of the Frontegg API. No changes are made to a real vendor, "+
+				"and objects only exist for the lifetime of the provider process.",
+		)
+		resp.DataSourceData = c
+		resp.ResourceData = c
+		resp.EphemeralResourceData = c
+		return
+	}
 
 	// Resolve base URL: base_url takes precedence over region
-	if baseURL == "" {
+	if baseURL != "" {
+		if err := validateBaseURL(baseURL); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("base_url"),
+				"Invalid Frontegg Base URL",
+				"The provider cannot use the configured base_url: "+err.Error()+". "+
+					"Set base_url or the FRONTEGG_BASE_URL environment variable to the Frontegg API URL of your environment.",
+			)
+			return
+		}
+		baseURL = strings.TrimRight(baseURL, "/")
+
+		if region != "" && regionURLs[region] != baseURL {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("base_url"),
+				"Both Frontegg Region and Base URL Are Configured",
+				fmt.Sprintf("base_url takes precedence over region, so the provider will use %s and ignore the region '%s'. "+
+					"Remove region (or FRONTEGG_REGION) or base_url (or FRONTEGG_BASE_URL) to make the target environment explicit.",
+					baseURL, region),
+			)
+		}
+	} else {
 		if region == "" {
 			region = "eu" // Default region
 		}
@@ -150,6 +397,16 @@ func (p *FronteggProvider) Configure(ctx context.Context, req provider.Configure
 		baseURL = url
 	}
 
+	if stagingInsecure && !isNonProductionBaseURL(baseURL) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("staging_insecure"),
+			"Staging Insecure Not Allowed",
+			fmt.Sprintf("staging_insecure only applies to pre-release environments, but the provider targets %s. "+
+				"Use region \"stg\" or a non-production base_url, or remove staging_insecure (or FRONTEGG_STAGING_INSECURE).", baseURL),
+		)
+		return
+	}
+
 	// Validate required values
 	if clientID == "" {
 		resp.Diagnostics.AddAttributeError(
@@ -175,20 +432,28 @@ func (p *FronteggProvider) Configure(ctx context.Context, req provider.Configure
 
 	// Create client
 	c := client.NewClient(baseURL, clientID, secret)
-
-	// Verify authentication
-	if err := c.Authenticate(ctx); err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Authenticate with Frontegg API",
-			"An unexpected error occurred when authenticating with the Frontegg API. "+
-				"Error: "+err.Error(),
+	if p.transport != nil {
+		c.SetTransport(p.transport)
+	}
+	c.SetLogPayloads(logPayloads)
+	c.SetMaxConcurrentRequests(int(maxConcurrentRequests))
+	c.SetMaintenanceMaxWait(maintenanceWait)
+	c.SetMaxRetries(int(maxRetries))
+	c.SetRetryWaitMax(retryWait)
+	if stagingInsecure {
+		c.SkipTLSHostnameVerification()
+		resp.Diagnostics.AddWarning(
+			"Frontegg TLS Host Name Verification Disabled",
+			"staging_insecure is set, so API certificates are accepted for any host name. Only use it with pre-release environments.",
 		)
-		return
 	}
 
-	// Make the client available to data sources and resources
+	// Make the client available to data sources and resources. Authentication is deferred to
+	// the first API request, so Configure never calls the API and plans without refresh work
+	// without network access to Frontegg.
 	resp.DataSourceData = c
 	resp.ResourceData = c
+	resp.EphemeralResourceData = c
 }
 
 func (p *FronteggProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -196,17 +461,65 @@ func (p *FronteggProvider) Resources(ctx context.Context) []func() resource.Reso
 		NewApplicationResource,
 		NewMcpConfigurationResource,
 		NewSourceResource,
+		NewSourcesBundleResource,
 		NewToolsImportResource,
 		NewConditionalPolicyResource,
 		NewRbacPolicyResource,
 		NewMaskingPolicyResource,
 		NewAllowedOriginsResource,
 		NewIdentityConfigurationResource,
+		NewJwtSigningConfigurationResource,
+		NewVendorSettingsResource,
+		NewSmsProviderResource,
+		NewApprovalFlowResource,
+		NewApplicationPolicyAttachmentResource,
+		NewToolOverrideResource,
+		NewToolInvocationWebhookResource,
+		NewTenantApplicationVisibilityResource,
+		NewPolicyKillSwitchResource,
+	}
+}
+
+func (p *FronteggProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewApplicationListResource,
+		NewConditionalPolicyListResource,
+		NewRbacPolicyListResource,
+		NewMaskingPolicyListResource,
 	}
 }
 
 func (p *FronteggProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewApplicationDataSource,
+		NewApplicationByHostDataSource,
+		NewMcpServerDataSource,
+		NewJwksDataSource,
+		NewExportDataSource,
+		NewToolUsageMetricsDataSource,
+		NewApplicationCredentialsDataSource,
+		NewSourcesDataSource,
+		NewToolsDriftDataSource,
+		NewSourceOpenAPIDataSource,
+		NewConditionalPolicyDataSource,
+		NewRbacPolicyDataSource,
+		NewMaskingPolicyDataSource,
+		NewApplicationDiffDataSource,
+		NewProviderConfigDataSource,
+		NewInternalToolsDataSource,
+	}
+}
+
+func (p *FronteggProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewTenantAccessTokenEphemeralResource,
+	}
+}
+
+func (p *FronteggProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewValidateOpenAPIFunction,
+		NewConditionEqFunction,
+		NewConditionInFunction,
 	}
 }
@@ -7,9 +7,11 @@ import (
 	"github.com/frontegg/terraform-provider-agentlink/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // normalizeOrigin removes trailing slashes from URLs for consistent comparison
@@ -20,6 +22,8 @@ func normalizeOrigin(url string) string {
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AllowedOriginsResource{}
 var _ resource.ResourceWithImportState = &AllowedOriginsResource{}
+var _ resource.ResourceWithMoveState = &AllowedOriginsResource{}
+var _ resource.ResourceWithUpgradeState = &AllowedOriginsResource{}
 
 func NewAllowedOriginsResource() resource.Resource {
 	return &AllowedOriginsResource{}
@@ -34,6 +38,7 @@ type AllowedOriginsResource struct {
 type AllowedOriginsResourceModel struct {
 	ID             types.String `tfsdk:"id"`
 	AllowedOrigins types.Set    `tfsdk:"allowed_origins"`
+	ClearOnDestroy types.Bool   `tfsdk:"clear_on_destroy"`
 }
 
 func (r *AllowedOriginsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -43,6 +48,7 @@ func (r *AllowedOriginsResource) Metadata(ctx context.Context, req resource.Meta
 func (r *AllowedOriginsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages the allowed origins (CORS) configuration for the Frontegg vendor.",
+		Version:     0,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The vendor ID.",
@@ -56,6 +62,12 @@ func (r *AllowedOriginsResource) Schema(ctx context.Context, req resource.Schema
 				Required:    true,
 				ElementType: types.StringType,
 			},
+			"clear_on_destroy": schema.BoolAttribute{
+				Description: "Whether destroying the resource removes all allowed origins from the vendor. When false, destroying only removes the resource from state and leaves the origins untouched, since other workspaces or the Frontegg portal may rely on them. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -78,6 +90,9 @@ func (r *AllowedOriginsResource) Configure(ctx context.Context, req resource.Con
 }
 
 func (r *AllowedOriginsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data AllowedOriginsResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -94,7 +109,7 @@ func (r *AllowedOriginsResource) Create(ctx context.Context, req resource.Create
 
 	config, err := r.client.UpdateAllowedOrigins(ctx, origins)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to update allowed origins: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to update allowed origins", err)
 		return
 	}
 
@@ -106,6 +121,9 @@ func (r *AllowedOriginsResource) Create(ctx context.Context, req resource.Create
 }
 
 func (r *AllowedOriginsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data AllowedOriginsResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -115,7 +133,7 @@ func (r *AllowedOriginsResource) Read(ctx context.Context, req resource.ReadRequ
 
 	config, err := r.client.GetVendorConfig(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to read vendor config: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to read vendor config", err)
 		return
 	}
 
@@ -133,11 +151,15 @@ func (r *AllowedOriginsResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 	data.AllowedOrigins = allowedOriginsSet
+	data.ClearOnDestroy = flattenLocalBool(data.ClearOnDestroy)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *AllowedOriginsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data AllowedOriginsResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -154,7 +176,7 @@ func (r *AllowedOriginsResource) Update(ctx context.Context, req resource.Update
 
 	config, err := r.client.UpdateAllowedOrigins(ctx, origins)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to update allowed origins: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to update allowed origins", err)
 		return
 	}
 
@@ -166,11 +188,26 @@ func (r *AllowedOriginsResource) Update(ctx context.Context, req resource.Update
 }
 
 func (r *AllowedOriginsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	// On delete, we set allowed origins to an empty list
-	// This effectively removes all custom allowed origins
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data AllowedOriginsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Allowed origins are shared by the whole vendor, so only clear them when asked to
+	if !data.ClearOnDestroy.ValueBool() {
+		tflog.Info(ctx, "Leaving allowed origins untouched on destroy; set clear_on_destroy to remove them")
+		return
+	}
+
+	// Setting allowed origins to an empty list removes all custom allowed origins
 	_, err := r.client.UpdateAllowedOrigins(ctx, []string{})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to clear allowed origins: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to clear allowed origins", err)
 		return
 	}
 }
@@ -179,7 +216,7 @@ func (r *AllowedOriginsResource) ImportState(ctx context.Context, req resource.I
 	// For import, we just read the current state from the API
 	config, err := r.client.GetVendorConfig(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to read vendor config: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to read vendor config", err)
 		return
 	}
 
@@ -198,6 +235,15 @@ func (r *AllowedOriginsResource) ImportState(ctx context.Context, req resource.I
 		return
 	}
 	data.AllowedOrigins = allowedOriginsSet
+	data.ClearOnDestroy = flattenLocalBool(data.ClearOnDestroy)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+func (r *AllowedOriginsResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
+
+func (r *AllowedOriginsResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
@@ -4,8 +4,13 @@ import (
 	"context"
 	"testing"
 
+	"github.com/frontegg/terraform-provider-agentlink/clienttest"
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestAllowedOriginsResourceHasExpectedSchema(t *testing.T) {
@@ -55,3 +60,35 @@ func TestAllowedOriginsResourceImplementsResource(t *testing.T) {
 	var _ = NewAllowedOriginsResource()
 	var _ resource.ResourceWithImportState = NewAllowedOriginsResource().(*AllowedOriginsResource)
 }
+
+func TestAllowedOriginsResourceDeleteLeavesOriginsByDefault(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	r := &AllowedOriginsResource{client: client.NewClient(server.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := state.Set(ctx, &AllowedOriginsResourceModel{
+		ID:             types.StringValue("vendor-1"),
+		AllowedOrigins: types.SetValueMust(types.StringType, nil),
+		ClearOnDestroy: types.BoolValue(false),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if requests := server.Requests(); len(requests) != 0 {
+		t.Errorf("expected no API requests, got %v", requests)
+	}
+}
@@ -2,8 +2,19 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
 
 	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -12,11 +23,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ApplicationResource{}
 var _ resource.ResourceWithImportState = &ApplicationResource{}
+var _ resource.ResourceWithIdentity = &ApplicationResource{}
+var _ resource.ResourceWithMoveState = &ApplicationResource{}
+var _ resource.ResourceWithUpgradeState = &ApplicationResource{}
+var _ resource.ResourceWithValidateConfig = &ApplicationResource{}
 
 func NewApplicationResource() resource.Resource {
 	return &ApplicationResource{}
@@ -35,6 +51,8 @@ type ApplicationResourceModel struct {
 	AppURL        types.String `tfsdk:"app_url"`
 	LoginURL      types.String `tfsdk:"login_url"`
 	LogoURL       types.String `tfsdk:"logo_url"`
+	LogoFile      types.String `tfsdk:"logo_file"`
+	LogoSHA256    types.String `tfsdk:"logo_sha256"`
 	AccessType    types.String `tfsdk:"access_type"`
 	IsDefault     types.Bool   `tfsdk:"is_default"`
 	IsActive      types.Bool   `tfsdk:"is_active"`
@@ -43,6 +61,17 @@ type ApplicationResourceModel struct {
 	Description   types.String `tfsdk:"description"`
 	AllowDcr      types.Bool   `tfsdk:"allow_dcr"`
 	AppHost       types.String `tfsdk:"app_host"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
+
+	AgentFramework types.String `tfsdk:"agent_framework"`
+	ModelProviders types.Set    `tfsdk:"model_providers"`
+	CallbackURLs   types.Set    `tfsdk:"callback_urls"`
+
+	CloneFromApplicationID types.String `tfsdk:"clone_from_application_id"`
+
+	CreatedAt             types.String `tfsdk:"created_at"`
+	UpdatedAt             types.String `tfsdk:"updated_at"`
+	IntegrationFinishedAt types.String `tfsdk:"integration_finished_at"`
 }
 
 func (r *ApplicationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -52,6 +81,7 @@ func (r *ApplicationResource) Metadata(ctx context.Context, req resource.Metadat
 func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Frontegg application.",
+		Version:     0,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The application ID.",
@@ -80,10 +110,24 @@ func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaReq
 				Required:    true,
 			},
 			"logo_url": schema.StringAttribute{
-				Description: "The logo URL.",
+				Description: "The logo URL. Set automatically when logo_file is set.",
 				Optional:    true,
 				Computed:    true,
 				Default:     stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					logoURLPlanModifier{},
+				},
+			},
+			"logo_file": schema.StringAttribute{
+				Description: "Path to a logo image, or the base64 encoded image, to upload. The uploaded image is used as logo_url. Conflicts with logo_url.",
+				Optional:    true,
+			},
+			"logo_sha256": schema.StringAttribute{
+				Description: "SHA256 hash of the uploaded logo_file contents, used to detect changes.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					logoHashPlanModifier{},
+				},
 			},
 			"access_type": schema.StringAttribute{
 				Description: "The access type. Valid values: FREE_ACCESS, MANAGED_ACCESS.",
@@ -131,10 +175,95 @@ func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaReq
 				Description: "The application host (computed by Frontegg).",
 				Computed:    true,
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Whether to take over an existing application with the same name when creating, e.g. after state was lost, instead of failing. The existing application is updated to match the configuration. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"clone_from_application_id": schema.StringAttribute{
+				Description: "The ID of a template application whose sources, tools, MCP configuration and policies are copied into the application when it is created, e.g. to set up per-environment applications. Policies are copied into new policies applying only to the application; the template policies are left unchanged. Changing it forces a new application to be created.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"agent_framework": schema.StringAttribute{
+				Description: "The framework the agent is built with, e.g. langchain or openai-agents. Only valid when type is agent.",
+				Optional:    true,
+			},
+			"model_providers": schema.SetAttribute{
+				Description: "The model providers the agent uses, e.g. openai or anthropic, as hints for Frontegg. Only valid when type is agent.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"callback_urls": schema.SetAttribute{
+				Description: "The endpoints Frontegg calls back when an agent action completes asynchronously, e.g. after an approval. Only valid when type is agent.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "When the application was created, as returned by Frontegg.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description: "When the application was last updated, as returned by Frontegg.",
+				Computed:    true,
+			},
+			"integration_finished_at": schema.StringAttribute{
+				Description: "When the integration of the application was finished, as returned by Frontegg. Null until the integration is finished.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
 
+func (r *ApplicationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ApplicationResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.LogoFile.IsNull() && !data.LogoURL.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("logo_file"),
+			"Conflicting Logo Attributes",
+			"Only one of logo_url and logo_file can be set. logo_url is set to the uploaded image when logo_file is set.",
+		)
+	}
+
+	// type defaults to agent, so the agent attributes are only rejected for another configured type
+	if data.Type.IsNull() || data.Type.IsUnknown() || data.Type.ValueString() == "agent" {
+		return
+	}
+	agentAttrs := map[string]attr.Value{
+		"agent_framework": data.AgentFramework,
+		"model_providers": data.ModelProviders,
+		"callback_urls":   data.CallbackURLs,
+	}
+	for _, name := range []string{"agent_framework", "model_providers", "callback_urls"} {
+		if !agentAttrs[name].IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Invalid Agent Attribute",
+				fmt.Sprintf("%s can only be set when type is agent, got type %q.", name, data.Type.ValueString()),
+			)
+		}
+	}
+}
+
+func (r *ApplicationResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema()
+}
+
 func (r *ApplicationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -153,6 +282,9 @@ func (r *ApplicationResource) Configure(ctx context.Context, req resource.Config
 }
 
 func (r *ApplicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data ApplicationResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -160,6 +292,11 @@ func (r *ApplicationResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	r.uploadLogo(ctx, &data, types.StringNull(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Create with all planned values
 	isDefault := data.IsDefault.ValueBool()
 	isActive := data.IsActive.ValueBool()
@@ -178,20 +315,45 @@ func (r *ApplicationResource) Create(ctx context.Context, req resource.CreateReq
 		Description:   data.Description.ValueString(),
 		AllowDcr:      &allowDcr,
 	}
+	if createReq.Type == "agent" {
+		createReq.AgentFramework = data.AgentFramework.ValueString()
+		createReq.ModelProviders = expandStringSet(ctx, data.ModelProviders, &resp.Diagnostics)
+		createReq.CallbackURLs = expandStringSet(ctx, data.CallbackURLs, &resp.Diagnostics)
+	}
 
 	app, err := r.client.CreateApplication(ctx, createReq)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to create application: "+err.Error())
+	if client.IsConflict(err) {
+		app = r.adoptExistingApplication(ctx, data, err, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to create application", err)
 		return
 	}
 
 	// Map response to model
-	r.mapApplicationToModel(app, &data)
+	r.mapApplicationToModel(ctx, app, &data, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The application is tracked before cloning, so a failed clone taints it instead of leaking it
+	if fromAppID := data.CloneFromApplicationID.ValueString(); fromAppID != "" {
+		if err := r.client.CloneApplication(ctx, fromAppID, data.ID.ValueString()); err != nil {
+			addClientError(&resp.Diagnostics, "Unable to clone application "+fromAppID, err)
+			return
+		}
+	}
 }
 
 func (r *ApplicationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data ApplicationResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -200,23 +362,31 @@ func (r *ApplicationResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 
 	app, err := r.client.GetApplicationByID(ctx, data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to read application: "+err.Error())
+	if errors.Is(err, client.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
 		return
 	}
-
-	if app == nil {
-		resp.State.RemoveResource(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read application", err)
 		return
 	}
 
 	// Map response to model
-	r.mapApplicationToModel(app, &data)
+	r.mapApplicationToModel(ctx, app, &data, &resp.Diagnostics)
+
+	// adopt_existing is not stored by Frontegg; imported applications default to false
+	if data.AdoptExisting.IsNull() {
+		data.AdoptExisting = types.BoolValue(false)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID)...)
 }
 
 func (r *ApplicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data ApplicationResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -224,36 +394,30 @@ func (r *ApplicationResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	isDefault := data.IsDefault.ValueBool()
-	isActive := data.IsActive.ValueBool()
-	allowDcr := data.AllowDcr.ValueBool()
-
-	updateReq := client.UpdateApplicationRequest{
-		Name:        data.Name.ValueString(),
-		AppURL:      data.AppURL.ValueString(),
-		LoginURL:    data.LoginURL.ValueString(),
-		LogoURL:     data.LogoURL.ValueString(),
-		AccessType:  data.AccessType.ValueString(),
-		IsDefault:   &isDefault,
-		IsActive:    &isActive,
-		Type:        data.Type.ValueString(),
-		Description: data.Description.ValueString(),
-		AllowDcr:    &allowDcr,
+	var stateHash types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("logo_sha256"), &stateHash)...)
+	r.uploadLogo(ctx, &data, stateHash, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	app, err := r.client.UpdateApplication(ctx, data.ID.ValueString(), updateReq)
+	app, err := r.client.UpdateApplication(ctx, data.ID.ValueString(), expandUpdateApplicationRequest(ctx, data, &resp.Diagnostics))
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to update application: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to update application", err)
 		return
 	}
 
 	// Map response to model
-	r.mapApplicationToModel(app, &data)
+	r.mapApplicationToModel(ctx, app, &data, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID)...)
 }
 
 func (r *ApplicationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data ApplicationResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -263,17 +427,120 @@ func (r *ApplicationResource) Delete(ctx context.Context, req resource.DeleteReq
 
 	err := r.client.DeleteApplication(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to delete application: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to delete application", err)
 		return
 	}
 }
 
 func (r *ApplicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
+
+func (r *ApplicationResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
+
+func (r *ApplicationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+// adoptExistingApplication resolves a name conflict on create. With adopt_existing the existing
+// application is updated to the planned values and returned; otherwise the error points to import.
+func (r *ApplicationResource) adoptExistingApplication(ctx context.Context, data ApplicationResourceModel, createErr error, diags *diag.Diagnostics) *client.Application {
+	existing, err := r.client.FindApplicationByName(ctx, data.Name.ValueString())
+	if err != nil {
+		addClientError(diags, "Unable to look up existing application", err)
+		return nil
+	}
+	if existing == nil {
+		addClientError(diags, "Unable to create application", createErr)
+		return nil
+	}
+
+	if !data.AdoptExisting.ValueBool() {
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Application Already Exists",
+			fmt.Sprintf("An application named %q already exists with ID %s. Import it with "+
+				"`terraform import agentlink_application.<name> %s`, or set adopt_existing = true "+
+				"to manage the existing application with this resource.", existing.Name, existing.ID, existing.ID),
+		)
+		return nil
+	}
+
+	tflog.Info(ctx, "Adopting existing application", map[string]interface{}{
+		"name": existing.Name,
+		"id":   existing.ID,
+	})
+
+	app, err := r.client.UpdateApplication(ctx, existing.ID, expandUpdateApplicationRequest(ctx, data, diags))
+	if err != nil {
+		addClientError(diags, "Unable to adopt existing application", err)
+		return nil
+	}
+	return app
+}
+
+// uploadLogo uploads logo_file when its contents differ from the logo in state, and sets
+// logo_url and logo_sha256 in the model accordingly
+func (r *ApplicationResource) uploadLogo(ctx context.Context, data *ApplicationResourceModel, stateHash types.String, diags *diag.Diagnostics) {
+	if data.LogoFile.IsNull() {
+		data.LogoSHA256 = types.StringNull()
+		return
+	}
+
+	content, filename, err := readLogoFile(data.LogoFile.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("logo_file"), "Invalid Logo File", err.Error())
+		return
+	}
+
+	hash := logoHash(content)
+	data.LogoSHA256 = types.StringValue(hash)
+	if hash == stateHash.ValueString() && !data.LogoURL.IsUnknown() {
+		return
+	}
+
+	url, err := r.client.UploadApplicationLogo(ctx, content, filename)
+	if err != nil {
+		addClientError(diags, "Unable to upload logo", err)
+		return
+	}
+	data.LogoURL = types.StringValue(url)
+}
+
+// expandUpdateApplicationRequest builds the application update request from the resource model.
+// Agent settings are always sent for agent applications, so removing them clears them.
+func expandUpdateApplicationRequest(ctx context.Context, data ApplicationResourceModel, diags *diag.Diagnostics) client.UpdateApplicationRequest {
+	isDefault := data.IsDefault.ValueBool()
+	isActive := data.IsActive.ValueBool()
+	allowDcr := data.AllowDcr.ValueBool()
+
+	updateReq := client.UpdateApplicationRequest{
+		Name:        data.Name.ValueString(),
+		AppURL:      data.AppURL.ValueString(),
+		LoginURL:    data.LoginURL.ValueString(),
+		LogoURL:     data.LogoURL.ValueString(),
+		AccessType:  data.AccessType.ValueString(),
+		IsDefault:   &isDefault,
+		IsActive:    &isActive,
+		Type:        data.Type.ValueString(),
+		Description: data.Description.ValueString(),
+		AllowDcr:    &allowDcr,
+	}
+	if updateReq.Type == "agent" {
+		agentFramework := data.AgentFramework.ValueString()
+		modelProviders := expandStringSet(ctx, data.ModelProviders, diags)
+		callbackURLs := expandStringSet(ctx, data.CallbackURLs, diags)
+		updateReq.AgentFramework = &agentFramework
+		updateReq.ModelProviders = &modelProviders
+		updateReq.CallbackURLs = &callbackURLs
+	}
+	return updateReq
 }
 
 // mapApplicationToModel maps an Application response to the resource model
-func (r *ApplicationResource) mapApplicationToModel(app *client.Application, data *ApplicationResourceModel) {
+func (r *ApplicationResource) mapApplicationToModel(ctx context.Context, app *client.Application, data *ApplicationResourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(app.ID)
 	data.VendorID = types.StringValue(app.VendorID)
 	data.Name = types.StringValue(app.Name)
@@ -287,6 +554,11 @@ func (r *ApplicationResource) mapApplicationToModel(app *client.Application, dat
 	data.FrontendStack = types.StringValue(app.FrontendStack)
 	data.Description = types.StringValue(app.Description)
 	data.AllowDcr = types.BoolValue(app.AllowDcr)
+	if app.AgentFramework != "" || !data.AgentFramework.IsNull() {
+		data.AgentFramework = types.StringValue(app.AgentFramework)
+	}
+	data.ModelProviders = flattenOptionalStringSet(ctx, app.ModelProviders, data.ModelProviders, diags)
+	data.CallbackURLs = flattenOptionalStringSet(ctx, app.CallbackURLs, data.CallbackURLs, diags)
 
 	// AppHost may be empty if not set by Frontegg
 	if app.AppHost != "" {
@@ -294,4 +566,112 @@ func (r *ApplicationResource) mapApplicationToModel(app *client.Application, dat
 	} else {
 		data.AppHost = types.StringValue("")
 	}
+
+	data.CreatedAt = types.StringValue(app.CreatedAt)
+	data.UpdatedAt = types.StringValue(app.UpdatedAt)
+	if app.IntegrationFinishedAt != "" {
+		data.IntegrationFinishedAt = types.StringValue(app.IntegrationFinishedAt)
+	} else {
+		data.IntegrationFinishedAt = types.StringNull()
+	}
+}
+
+// readLogoFile returns the contents and file name of a logo_file value, which is either the
+// path to an image or the base64 encoded image
+func readLogoFile(value string) ([]byte, string, error) {
+	if info, err := os.Stat(value); err == nil && !info.IsDir() {
+		content, err := os.ReadFile(value)
+		if err != nil {
+			return nil, "", fmt.Errorf("unable to read logo file: %w", err)
+		}
+		return content, filepath.Base(value), nil
+	}
+
+	content, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, "", fmt.Errorf("logo_file must be the path to an existing file or base64 encoded content")
+	}
+
+	filename := "logo"
+	if extensions, _ := mime.ExtensionsByType(http.DetectContentType(content)); len(extensions) > 0 {
+		filename += extensions[0]
+	}
+	return content, filename, nil
+}
+
+// logoHash returns the SHA256 hash of logo contents
+func logoHash(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+// logoHashPlanModifier plans logo_sha256 from the contents of logo_file, so changing the image
+// shows up as an update in terraform plan.
+type logoHashPlanModifier struct{}
+
+func (m logoHashPlanModifier) Description(ctx context.Context) string {
+	return "Sets the hash to the hash of the logo_file contents."
+}
+
+func (m logoHashPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m logoHashPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var logoFile types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("logo_file"), &logoFile)...)
+	if resp.Diagnostics.HasError() || logoFile.IsUnknown() {
+		return
+	}
+	if logoFile.IsNull() {
+		resp.PlanValue = types.StringNull()
+		return
+	}
+
+	content, _, err := readLogoFile(logoFile.ValueString())
+	if err != nil {
+		// Apply reports the unreadable file
+		return
+	}
+	resp.PlanValue = types.StringValue(logoHash(content))
+}
+
+// logoURLPlanModifier keeps logo_url when logo_file is unchanged, and marks it as changing when
+// a new logo will be uploaded.
+type logoURLPlanModifier struct{}
+
+func (m logoURLPlanModifier) Description(ctx context.Context) string {
+	return "Marks the logo URL as changing when logo_file contents change."
+}
+
+func (m logoURLPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m logoURLPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var logoFile types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("logo_file"), &logoFile)...)
+	if resp.Diagnostics.HasError() || logoFile.IsNull() {
+		return
+	}
+
+	resp.PlanValue = types.StringUnknown()
+	if logoFile.IsUnknown() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var stateHash types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("logo_sha256"), &stateHash)...)
+	content, _, err := readLogoFile(logoFile.ValueString())
+	if err == nil && logoHash(content) == stateHash.ValueString() {
+		resp.PlanValue = req.StateValue
+	}
 }
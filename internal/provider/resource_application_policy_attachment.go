@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ApplicationPolicyAttachmentResource{}
+var _ resource.ResourceWithImportState = &ApplicationPolicyAttachmentResource{}
+var _ resource.ResourceWithMoveState = &ApplicationPolicyAttachmentResource{}
+var _ resource.ResourceWithUpgradeState = &ApplicationPolicyAttachmentResource{}
+
+func NewApplicationPolicyAttachmentResource() resource.Resource {
+	return &ApplicationPolicyAttachmentResource{}
+}
+
+// ApplicationPolicyAttachmentResource defines the resource implementation.
+type ApplicationPolicyAttachmentResource struct {
+	client *client.Client
+}
+
+// ApplicationPolicyAttachmentResourceModel describes the resource data model.
+type ApplicationPolicyAttachmentResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	PolicyID types.String `tfsdk:"policy_id"`
+	AppIDs   types.Set    `tfsdk:"app_ids"`
+}
+
+func (r *ApplicationPolicyAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_policy_attachment"
+}
+
+func (r *ApplicationPolicyAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Attaches an existing policy to additional applications without managing the policy's full app_ids list, so several workspaces can attach the same policy to their own applications.",
+		Version:     0,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The policy ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"policy_id": schema.StringAttribute{
+				Description: "The ID of the policy to attach. Any policy kind is supported. Changing this forces a new resource to be created.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"app_ids": schema.SetAttribute{
+				Description: "The application IDs to attach the policy to. Applications the policy already applies to outside this resource are left untouched.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *ApplicationPolicyAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ApplicationPolicyAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data ApplicationPolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appIDs := expandStringSet(ctx, data.AppIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyID := data.PolicyID.ValueString()
+	_, err := r.client.AttachPolicyApplications(ctx, policyID, appIDs)
+	if errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddAttributeError(path.Root("policy_id"), "Policy Not Found", "No policy with ID "+policyID+" exists.")
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to attach policy", err)
+		return
+	}
+
+	data.ID = types.StringValue(policyID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ApplicationPolicyAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data ApplicationPolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := r.client.GetConditionalPolicy(ctx, data.PolicyID.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read policy", err)
+		return
+	}
+
+	// Only track the applications this resource attached, so other attachments are not reported as drift
+	appIDs := attachedApplications(expandStringSet(ctx, data.AppIDs, &resp.Diagnostics), policy.AppIDs)
+	if len(appIDs) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	values, diags := types.SetValueFrom(ctx, types.StringType, appIDs)
+	resp.Diagnostics.Append(diags...)
+	data.AppIDs = values
+	data.ID = types.StringValue(policy.ID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ApplicationPolicyAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data, state ApplicationPolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned := expandStringSet(ctx, data.AppIDs, &resp.Diagnostics)
+	current := expandStringSet(ctx, state.AppIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyID := data.PolicyID.ValueString()
+	if removed := subtractStrings(current, planned); len(removed) > 0 {
+		if _, err := r.client.DetachPolicyApplications(ctx, policyID, removed); err != nil {
+			addClientError(&resp.Diagnostics, "Unable to detach policy", err)
+			return
+		}
+	}
+
+	_, err := r.client.AttachPolicyApplications(ctx, policyID, planned)
+	if errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddAttributeError(path.Root("policy_id"), "Policy Not Found", "No policy with ID "+policyID+" exists.")
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to attach policy", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ApplicationPolicyAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data ApplicationPolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appIDs := expandStringSet(ctx, data.AppIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A deleted policy has no applications left to detach
+	_, err := r.client.DetachPolicyApplications(ctx, data.PolicyID.ValueString(), appIDs)
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		addClientError(&resp.Diagnostics, "Unable to detach policy", err)
+		return
+	}
+}
+
+func (r *ApplicationPolicyAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: policy_id:app_id[,app_id...]
+	parts := strings.Split(req.ID, ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in the format 'policy_id:app_id[,app_id...]'",
+		)
+		return
+	}
+
+	appIDs, diags := types.SetValueFrom(ctx, types.StringType, strings.Split(parts[1], ","))
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("policy_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_ids"), appIDs)...)
+}
+
+func (r *ApplicationPolicyAttachmentResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
+
+func (r *ApplicationPolicyAttachmentResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+// attachedApplications returns the managed application IDs the policy still applies to, sorted
+func attachedApplications(managed, policyAppIDs []string) []string {
+	result := []string{}
+	for _, appID := range managed {
+		if slices.Contains(policyAppIDs, appID) {
+			result = append(result, appID)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// subtractStrings returns the values of a that are not in b
+func subtractStrings(a, b []string) []string {
+	result := []string{}
+	for _, value := range a {
+		if !slices.Contains(b, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
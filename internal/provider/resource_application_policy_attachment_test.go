@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/clienttest"
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestApplicationPolicyAttachmentResourceHasExpectedSchema(t *testing.T) {
+	r := NewApplicationPolicyAttachmentResource()
+
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	for _, attr := range []string{"id", "policy_id", "app_ids"} {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in schema", attr)
+		}
+	}
+}
+
+func TestApplicationPolicyAttachmentResourceMetadata(t *testing.T) {
+	r := NewApplicationPolicyAttachmentResource()
+
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_application_policy_attachment"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func TestAttachedApplications(t *testing.T) {
+	got := attachedApplications([]string{"app-3", "app-1", "app-2"}, []string{"app-1", "app-3", "app-4"})
+	if !reflect.DeepEqual(got, []string{"app-1", "app-3"}) {
+		t.Errorf("expected the managed applications the policy applies to, got %v", got)
+	}
+
+	if got := attachedApplications([]string{"app-1"}, nil); got == nil || len(got) != 0 {
+		t.Errorf("expected no applications, got %v", got)
+	}
+}
+
+func TestSubtractStrings(t *testing.T) {
+	got := subtractStrings([]string{"app-1", "app-2", "app-3"}, []string{"app-2"})
+	if !reflect.DeepEqual(got, []string{"app-1", "app-3"}) {
+		t.Errorf("expected app-1 and app-3, got %v", got)
+	}
+}
+
+func TestApplicationPolicyAttachmentLeavesOtherApplications(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	id := server.Put(clienttest.Policies, clienttest.Object{"type": "MASKING", "name": "PII", "appIds": []interface{}{"app-1"}})
+
+	r := &ApplicationPolicyAttachmentResource{client: client.NewClient(server.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := plan.Set(ctx, &ApplicationPolicyAttachmentResourceModel{
+		ID:       types.StringUnknown(),
+		PolicyID: types.StringValue(id),
+		AppIDs:   types.SetValueMust(types.StringType, []attr.Value{types.StringValue("app-2")}),
+	})
+	if diags.HasError() {
+		t.Fatalf("failed to build plan: %v", diags)
+	}
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: plan.Raw}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+	}
+
+	if appIDs := server.Get(clienttest.Policies, id)["appIds"]; !reflect.DeepEqual(appIDs, []interface{}{"app-1", "app-2"}) {
+		t.Errorf("expected app-2 to be attached next to app-1, got %v", appIDs)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", deleteResp.Diagnostics)
+	}
+
+	if appIDs := server.Get(clienttest.Policies, id)["appIds"]; !reflect.DeepEqual(appIDs, []interface{}{"app-1"}) {
+		t.Errorf("expected only app-2 to be detached, got %v", appIDs)
+	}
+}
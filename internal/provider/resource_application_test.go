@@ -2,9 +2,23 @@ package provider
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/frontegg/terraform-provider-agentlink/clienttest"
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestApplicationResourceHasExpectedSchema(t *testing.T) {
@@ -24,7 +38,7 @@ func TestApplicationResourceHasExpectedSchema(t *testing.T) {
 	}
 
 	// Check computed attributes
-	computedAttrs := []string{"id", "vendor_id", "app_host"}
+	computedAttrs := []string{"id", "vendor_id", "app_host", "created_at", "updated_at", "integration_finished_at"}
 	for _, attr := range computedAttrs {
 		if _, ok := resp.Schema.Attributes[attr]; !ok {
 			t.Errorf("expected computed attribute '%s' in schema", attr)
@@ -32,7 +46,7 @@ func TestApplicationResourceHasExpectedSchema(t *testing.T) {
 	}
 
 	// Check optional attributes
-	optionalAttrs := []string{"type", "access_type", "is_active", "allow_dcr", "description", "frontend_stack"}
+	optionalAttrs := []string{"type", "access_type", "is_active", "allow_dcr", "description", "frontend_stack", "adopt_existing", "agent_framework", "model_providers", "callback_urls"}
 	for _, attr := range optionalAttrs {
 		if _, ok := resp.Schema.Attributes[attr]; !ok {
 			t.Errorf("expected optional attribute '%s' in schema", attr)
@@ -62,4 +76,266 @@ func TestApplicationResourceImplementsResource(t *testing.T) {
 
 	// Verify it implements ResourceWithImportState
 	var _ resource.ResourceWithImportState = r.(*ApplicationResource)
+	var _ resource.ResourceWithIdentity = r.(*ApplicationResource)
+}
+
+func TestApplicationResourceAdoptExisting(t *testing.T) {
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	id := server.Put(clienttest.Applications, clienttest.Object{"name": "My Agent", "appURL": "https://old.example.com"})
+
+	r := &ApplicationResource{client: client.NewClient(server.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)}
+	data := ApplicationResourceModel{
+		Name:          types.StringValue("My Agent"),
+		AppURL:        types.StringValue("https://app.example.com"),
+		LoginURL:      types.StringValue("https://app.example.com/login"),
+		AdoptExisting: types.BoolValue(false),
+	}
+	conflict := errors.New("failed to create application with status 409")
+
+	// Without adopt_existing the error points to the existing application
+	var diags diag.Diagnostics
+	if app := r.adoptExistingApplication(context.Background(), data, conflict, &diags); app != nil || !diags.HasError() {
+		t.Fatalf("expected an error, got %+v", app)
+	}
+	if summary := diags.Errors()[0].Summary(); summary != "Application Already Exists" {
+		t.Errorf("expected 'Application Already Exists', got %q", summary)
+	}
+
+	// With adopt_existing the existing application is updated and returned
+	data.AdoptExisting = types.BoolValue(true)
+	diags = diag.Diagnostics{}
+	app := r.adoptExistingApplication(context.Background(), data, conflict, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if app.ID != id || app.AppURL != "https://app.example.com" {
+		t.Errorf("expected adopted application %s with updated URL, got %+v", id, app)
+	}
+}
+
+func TestApplicationResourceAdoptExistingErrors(t *testing.T) {
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	server.FailNext(http.MethodGet, "/applications/resources/applications/v1", http.StatusInternalServerError)
+
+	r := &ApplicationResource{client: client.NewClient(server.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)}
+	data := ApplicationResourceModel{Name: types.StringValue("My Agent"), AdoptExisting: types.BoolValue(true)}
+
+	var diags diag.Diagnostics
+	if app := r.adoptExistingApplication(context.Background(), data, errors.New("conflict"), &diags); app != nil || !diags.HasError() {
+		t.Fatalf("expected a lookup error, got %+v", app)
+	}
+
+	// A conflict without a same-named application reports the original error
+	diags = diag.Diagnostics{}
+	r.adoptExistingApplication(context.Background(), data, errors.New("conflict"), &diags)
+	if !diags.HasError() || diags.Errors()[0].Detail() != "Unable to create application: conflict" {
+		t.Errorf("expected the create error, got %v", diags)
+	}
+}
+
+func TestApplicationResourceMapsTimestamps(t *testing.T) {
+	r := &ApplicationResource{}
+
+	var data ApplicationResourceModel
+	r.mapApplicationToModel(context.Background(), &client.Application{
+		ID:        "app-1",
+		CreatedAt: "2024-01-02T03:04:05.000Z",
+		UpdatedAt: "2024-02-03T04:05:06.000Z",
+	}, &data, &diag.Diagnostics{})
+
+	if data.CreatedAt.ValueString() != "2024-01-02T03:04:05.000Z" || data.UpdatedAt.ValueString() != "2024-02-03T04:05:06.000Z" {
+		t.Errorf("unexpected timestamps: %s, %s", data.CreatedAt, data.UpdatedAt)
+	}
+	if !data.IntegrationFinishedAt.IsNull() {
+		t.Errorf("expected integration_finished_at to be null before the integration finished, got %s", data.IntegrationFinishedAt)
+	}
+
+	r.mapApplicationToModel(context.Background(), &client.Application{ID: "app-1", IntegrationFinishedAt: "2024-03-04T05:06:07.000Z"}, &data, &diag.Diagnostics{})
+	if data.IntegrationFinishedAt.ValueString() != "2024-03-04T05:06:07.000Z" {
+		t.Errorf("expected integration_finished_at to be set, got %s", data.IntegrationFinishedAt)
+	}
+}
+
+func TestReadLogoFile(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n0000")
+	logoFile := filepath.Join(t.TempDir(), "brand.png")
+	if err := os.WriteFile(logoFile, png, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	content, filename, err := readLogoFile(logoFile)
+	if err != nil || string(content) != string(png) || filename != "brand.png" {
+		t.Errorf("expected the file contents and name, got %q, %q, %v", content, filename, err)
+	}
+
+	content, filename, err = readLogoFile(base64.StdEncoding.EncodeToString(png))
+	if err != nil || string(content) != string(png) || filename != "logo.png" {
+		t.Errorf("expected the decoded contents with a detected extension, got %q, %q, %v", content, filename, err)
+	}
+
+	if _, _, err := readLogoFile(filepath.Join(t.TempDir(), "missing.png")); err == nil {
+		t.Errorf("expected an error for a missing file that is not base64")
+	}
+}
+
+func TestLogoPlanModifiers(t *testing.T) {
+	ctx := context.Background()
+	logoFile := filepath.Join(t.TempDir(), "logo.png")
+	if err := os.WriteFile(logoFile, []byte("v1"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	NewApplicationResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := ApplicationResourceModel{
+		ID:         types.StringValue("app-1"),
+		Name:       types.StringValue("My Agent"),
+		LogoURL:    types.StringValue("https://assets.example.com/v1.png"),
+		LogoFile:   types.StringValue(logoFile),
+		LogoSHA256: types.StringValue(logoHash([]byte("v1"))),
+
+		ModelProviders: types.SetNull(types.StringType),
+		CallbackURLs:   types.SetNull(types.StringType),
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := state.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if diags := plan.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	modify := func(modifier planmodifier.String, stateValue types.String) types.String {
+		req := planmodifier.StringRequest{Plan: plan, State: state, StateValue: stateValue, PlanValue: types.StringValue("")}
+		resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+		modifier.PlanModifyString(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		return resp.PlanValue
+	}
+
+	if planned := modify(logoURLPlanModifier{}, data.LogoURL); planned != data.LogoURL {
+		t.Errorf("expected the logo URL to be kept for an unchanged file, got %s", planned)
+	}
+
+	if err := os.WriteFile(logoFile, []byte("v2"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if planned := modify(logoURLPlanModifier{}, data.LogoURL); !planned.IsUnknown() {
+		t.Errorf("expected an unknown logo URL for a changed file, got %s", planned)
+	}
+	if planned := modify(logoHashPlanModifier{}, data.LogoSHA256); planned.ValueString() != logoHash([]byte("v2")) {
+		t.Errorf("expected the hash of the changed file, got %s", planned)
+	}
+}
+
+func TestApplicationResourceValidateAgentAttributes(t *testing.T) {
+	ctx := context.Background()
+	r := &ApplicationResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	validate := func(data ApplicationResourceModel) diag.Diagnostics {
+		config := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		if diags := config.Set(ctx, &data); diags.HasError() {
+			t.Fatalf("failed to build config: %v", diags)
+		}
+
+		resp := &resource.ValidateConfigResponse{}
+		r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, resp)
+		return resp.Diagnostics
+	}
+
+	agent := ApplicationResourceModel{
+		AgentFramework: types.StringValue("langchain"),
+		ModelProviders: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("openai")}),
+		CallbackURLs:   types.SetNull(types.StringType),
+	}
+	if diags := validate(agent); diags.HasError() {
+		t.Errorf("expected agent attributes to be valid with the default type, got %v", diags)
+	}
+
+	agent.Type = types.StringValue("agent")
+	if diags := validate(agent); diags.HasError() {
+		t.Errorf("expected agent attributes to be valid for an agent, got %v", diags)
+	}
+
+	agent.Type = types.StringValue("web")
+	diags := validate(agent)
+	if len(diags) != 2 {
+		t.Fatalf("expected an error per agent attribute, got %v", diags)
+	}
+	if !diags[0].(diag.DiagnosticWithPath).Path().Equal(path.Root("agent_framework")) {
+		t.Errorf("expected the first error on agent_framework, got %v", diags[0])
+	}
+}
+
+func TestExpandUpdateApplicationRequestAgentSettings(t *testing.T) {
+	ctx := context.Background()
+
+	var diags diag.Diagnostics
+	data := ApplicationResourceModel{
+		Type:           types.StringValue("agent"),
+		AgentFramework: types.StringNull(),
+		ModelProviders: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("anthropic")}),
+		CallbackURLs:   types.SetNull(types.StringType),
+	}
+
+	// Unset agent settings are sent empty so removing them from the configuration clears them
+	updateReq := expandUpdateApplicationRequest(ctx, data, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if updateReq.AgentFramework == nil || *updateReq.AgentFramework != "" || updateReq.CallbackURLs == nil || len(*updateReq.CallbackURLs) != 0 {
+		t.Errorf("expected cleared agent settings, got %+v", updateReq)
+	}
+	if updateReq.ModelProviders == nil || len(*updateReq.ModelProviders) != 1 || (*updateReq.ModelProviders)[0] != "anthropic" {
+		t.Errorf("expected the model providers, got %+v", updateReq.ModelProviders)
+	}
+
+	data.Type = types.StringValue("web")
+	updateReq = expandUpdateApplicationRequest(ctx, data, &diags)
+	if updateReq.AgentFramework != nil || updateReq.ModelProviders != nil || updateReq.CallbackURLs != nil {
+		t.Errorf("expected no agent settings for a web application, got %+v", updateReq)
+	}
+}
+
+func TestApplicationResourceMapsAgentSettings(t *testing.T) {
+	ctx := context.Background()
+	r := &ApplicationResource{}
+
+	var diags diag.Diagnostics
+	data := ApplicationResourceModel{
+		AgentFramework: types.StringNull(),
+		ModelProviders: types.SetNull(types.StringType),
+		CallbackURLs:   types.SetNull(types.StringType),
+	}
+	r.mapApplicationToModel(ctx, &client.Application{ID: "app-1", Type: "agent"}, &data, &diags)
+	if !data.AgentFramework.IsNull() || !data.ModelProviders.IsNull() || !data.CallbackURLs.IsNull() {
+		t.Errorf("expected unset agent settings to stay null, got %s, %s, %s", data.AgentFramework, data.ModelProviders, data.CallbackURLs)
+	}
+
+	r.mapApplicationToModel(ctx, &client.Application{
+		ID:             "app-1",
+		Type:           "agent",
+		AgentFramework: "langchain",
+		CallbackURLs:   []string{"https://agent.example.com/callback"},
+	}, &data, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if data.AgentFramework.ValueString() != "langchain" || len(data.CallbackURLs.Elements()) != 1 {
+		t.Errorf("expected the agent settings, got %s, %s", data.AgentFramework, data.CallbackURLs)
+	}
 }
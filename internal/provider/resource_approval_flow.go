@@ -0,0 +1,480 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ApprovalFlowResource{}
+var _ resource.ResourceWithImportState = &ApprovalFlowResource{}
+var _ resource.ResourceWithMoveState = &ApprovalFlowResource{}
+var _ resource.ResourceWithUpgradeState = &ApprovalFlowResource{}
+
+func NewApprovalFlowResource() resource.Resource {
+	return &ApprovalFlowResource{}
+}
+
+// ApprovalFlowResource defines the resource implementation.
+type ApprovalFlowResource struct {
+	client *client.Client
+}
+
+// ApprovalFlowResourceModel describes the resource data model.
+type ApprovalFlowResourceModel struct {
+	ID                      types.String            `tfsdk:"id"`
+	Name                    types.String            `tfsdk:"name"`
+	Description             types.String            `tfsdk:"description"`
+	IsActive                types.Bool              `tfsdk:"is_active"`
+	Channels                types.Set               `tfsdk:"channels"`
+	TimeoutMinutes          types.Int64             `tfsdk:"timeout_minutes"`
+	ReminderIntervalMinutes types.Int64             `tfsdk:"reminder_interval_minutes"`
+	AutoApproveMinutes      types.Int64             `tfsdk:"auto_approve_minutes"`
+	NotifyOnDecisions       types.Bool              `tfsdk:"notify_on_decisions"`
+	WebhookURL              types.String            `tfsdk:"webhook_url"`
+	Steps                   []ApprovalFlowStepModel `tfsdk:"steps"`
+}
+
+// ApprovalFlowStepModel describes a single approval step.
+type ApprovalFlowStepModel struct {
+	ApproverRoles        types.Set   `tfsdk:"approver_roles"`
+	ApproverEmails       types.Set   `tfsdk:"approver_emails"`
+	ApproverPhoneNumbers types.Set   `tfsdk:"approver_phone_numbers"`
+	MinApprovals         types.Int64 `tfsdk:"min_approvals"`
+}
+
+// approvalFlowChannels are the notification channels supported by approval flows
+var approvalFlowChannels = []string{"email", "sms"}
+
+const (
+	minApprovalTimeoutMinutes  = 5
+	maxApprovalTimeoutMinutes  = 10080
+	minApprovalReminderMinutes = 60
+)
+
+func (r *ApprovalFlowResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_approval_flow"
+}
+
+func (r *ApprovalFlowResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an approval flow used by policies with an APPROVAL_REQUIRED result. Steps run in order and each step completes once its quorum of approvers has approved.",
+		Version:     0,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The approval flow ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The approval flow name.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The approval flow description.",
+				Optional:    true,
+			},
+			"is_active": schema.BoolAttribute{
+				Description: "Whether the approval flow is active. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"channels": schema.SetAttribute{
+				Description: "How approvers are notified. Valid values: email, sms.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"timeout_minutes": schema.Int64Attribute{
+				Description: "How long a request waits for a decision before it expires, in minutes (5-10080).",
+				Optional:    true,
+			},
+			"reminder_interval_minutes": schema.Int64Attribute{
+				Description: "How often pending approvers are reminded, in minutes. Minimum value is 60.",
+				Optional:    true,
+			},
+			"auto_approve_minutes": schema.Int64Attribute{
+				Description: "Automatically approve requests that received no decision within this many minutes.",
+				Optional:    true,
+			},
+			"notify_on_decisions": schema.BoolAttribute{
+				Description: "Whether the requester is notified when a decision is made.",
+				Optional:    true,
+			},
+			"webhook_url": schema.StringAttribute{
+				Description: "URL notified about approval requests and decisions.",
+				Optional:    true,
+			},
+			"steps": schema.ListNestedAttribute{
+				Description: "The ordered approval steps. A step starts once the previous step is approved.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"approver_roles": schema.SetAttribute{
+							Description: "Keys of the roles whose members may approve the step.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"approver_emails": schema.SetAttribute{
+							Description: "Emails of the users who may approve the step.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"approver_phone_numbers": schema.SetAttribute{
+							Description: "Phone numbers of the users who may approve the step over SMS.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"min_approvals": schema.Int64Attribute{
+							Description: "The number of approvals required to complete the step. Defaults to 1.",
+							Optional:    true,
+							Computed:    true,
+							Default:     int64default.StaticInt64(1),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ApprovalFlowResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ApprovalFlowResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data ApprovalFlowResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	flowReq := expandApprovalFlow(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	flow, err := r.client.CreateApprovalFlow(ctx, flowReq)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to create approval flow", err)
+		return
+	}
+
+	flattenApprovalFlow(ctx, flow, &data, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ApprovalFlowResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data ApprovalFlowResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	flow, err := r.client.GetApprovalFlow(ctx, data.ID.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read approval flow", err)
+		return
+	}
+
+	flattenApprovalFlow(ctx, flow, &data, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ApprovalFlowResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data ApprovalFlowResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	flowReq := expandApprovalFlow(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	flow, err := r.client.UpdateApprovalFlow(ctx, data.ID.ValueString(), flowReq)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to update approval flow", err)
+		return
+	}
+
+	flattenApprovalFlow(ctx, flow, &data, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ApprovalFlowResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data ApprovalFlowResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteApprovalFlow(ctx, data.ID.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to delete approval flow", err)
+		return
+	}
+}
+
+func (r *ApprovalFlowResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *ApprovalFlowResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
+
+func (r *ApprovalFlowResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+// expandApprovalFlow builds the approval flow request from the resource model.
+// Steps are numbered in list order.
+func expandApprovalFlow(ctx context.Context, data ApprovalFlowResourceModel, diags *diag.Diagnostics) client.ApprovalFlowRequest {
+	req := client.ApprovalFlowRequest{
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+		IsActive:    data.IsActive.ValueBool(),
+		Steps:       []client.ApprovalFlowStep{},
+	}
+
+	var channels []string
+	diags.Append(data.Channels.ElementsAs(ctx, &channels, false)...)
+	for _, channel := range channels {
+		switch channel {
+		case "email":
+			req.Channels.Email = true
+		case "sms":
+			req.Channels.Sms = true
+		default:
+			diags.AddAttributeError(
+				path.Root("channels"),
+				"Invalid Channel",
+				fmt.Sprintf("Unsupported channel %q. Valid values: %v", channel, approvalFlowChannels),
+			)
+		}
+	}
+
+	if !data.TimeoutMinutes.IsNull() && !data.TimeoutMinutes.IsUnknown() {
+		timeout := data.TimeoutMinutes.ValueInt64()
+		if timeout < minApprovalTimeoutMinutes || timeout > maxApprovalTimeoutMinutes {
+			diags.AddAttributeError(
+				path.Root("timeout_minutes"),
+				"Invalid Timeout",
+				fmt.Sprintf("timeout_minutes must be between %d and %d", minApprovalTimeoutMinutes, maxApprovalTimeoutMinutes),
+			)
+		}
+		req.Configuration.TimeoutMinutes = int(timeout)
+	}
+
+	if !data.ReminderIntervalMinutes.IsNull() && !data.ReminderIntervalMinutes.IsUnknown() {
+		if data.ReminderIntervalMinutes.ValueInt64() < minApprovalReminderMinutes {
+			diags.AddAttributeError(
+				path.Root("reminder_interval_minutes"),
+				"Invalid Reminder Interval",
+				fmt.Sprintf("reminder_interval_minutes must be at least %d", minApprovalReminderMinutes),
+			)
+		}
+		req.Configuration.ReminderIntervalMinutes = int(data.ReminderIntervalMinutes.ValueInt64())
+	}
+
+	if !data.AutoApproveMinutes.IsNull() && !data.AutoApproveMinutes.IsUnknown() {
+		if data.AutoApproveMinutes.ValueInt64() < 1 {
+			diags.AddAttributeError(
+				path.Root("auto_approve_minutes"),
+				"Invalid Auto Approve Time",
+				"auto_approve_minutes must be at least 1",
+			)
+		}
+		req.Configuration.AutoApproveInMinutes = int(data.AutoApproveMinutes.ValueInt64())
+	}
+
+	if !data.NotifyOnDecisions.IsNull() && !data.NotifyOnDecisions.IsUnknown() {
+		notify := data.NotifyOnDecisions.ValueBool()
+		req.Configuration.NotifyOnDecisions = &notify
+	}
+	req.Configuration.WebhookURL = data.WebhookURL.ValueString()
+
+	if len(data.Steps) == 0 {
+		diags.AddAttributeError(path.Root("steps"), "Missing Steps", "An approval flow requires at least one step.")
+	}
+
+	for i, step := range data.Steps {
+		stepPath := path.Root("steps").AtListIndex(i)
+
+		selectors := client.ApproverSelectors{
+			RoleKeys:     expandStringSet(ctx, step.ApproverRoles, diags),
+			Emails:       expandStringSet(ctx, step.ApproverEmails, diags),
+			PhoneNumbers: expandStringSet(ctx, step.ApproverPhoneNumbers, diags),
+		}
+
+		namedApprovers := len(selectors.Emails) + len(selectors.PhoneNumbers)
+		if len(selectors.RoleKeys) == 0 && namedApprovers == 0 {
+			diags.AddAttributeError(
+				stepPath,
+				"Missing Approvers",
+				"Each step requires at least one of approver_roles, approver_emails or approver_phone_numbers.",
+			)
+		}
+
+		minApprovals := int(step.MinApprovals.ValueInt64())
+		if minApprovals < 1 {
+			diags.AddAttributeError(stepPath.AtName("min_approvals"), "Invalid Quorum", "min_approvals must be at least 1.")
+		} else if len(selectors.RoleKeys) == 0 && minApprovals > namedApprovers {
+			// Role membership is only known at runtime, so the quorum can only be checked for named approvers
+			diags.AddAttributeError(
+				stepPath.AtName("min_approvals"),
+				"Invalid Quorum",
+				fmt.Sprintf("min_approvals (%d) exceeds the number of approvers of the step (%d).", minApprovals, namedApprovers),
+			)
+		}
+
+		req.Steps = append(req.Steps, client.ApprovalFlowStep{
+			StepOrder:         i + 1,
+			ApproverSelectors: selectors,
+			Configuration:     client.ApprovalFlowStepQuorum{MinApprovals: minApprovals},
+		})
+	}
+
+	return req
+}
+
+// flattenApprovalFlow maps an approval flow response onto the resource model
+func flattenApprovalFlow(ctx context.Context, flow *client.ApprovalFlow, data *ApprovalFlowResourceModel, diags *diag.Diagnostics) {
+	data.ID = types.StringValue(flow.ID)
+	data.Name = types.StringValue(flow.Name)
+	data.IsActive = types.BoolValue(flow.IsActive)
+
+	// Keep optional attributes unset when the API returns no value
+	if flow.Description != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(flow.Description)
+	}
+
+	channels := []string{}
+	if flow.Channels.Email {
+		channels = append(channels, "email")
+	}
+	if flow.Channels.Sms {
+		channels = append(channels, "sms")
+	}
+	channelSet, d := types.SetValueFrom(ctx, types.StringType, channels)
+	diags.Append(d...)
+	data.Channels = channelSet
+
+	config := flow.Configuration
+	data.TimeoutMinutes = flattenOptionalInt64(config.TimeoutMinutes, data.TimeoutMinutes)
+	data.ReminderIntervalMinutes = flattenOptionalInt64(config.ReminderIntervalMinutes, data.ReminderIntervalMinutes)
+	data.AutoApproveMinutes = flattenOptionalInt64(config.AutoApproveInMinutes, data.AutoApproveMinutes)
+	if config.NotifyOnDecisions != nil && (*config.NotifyOnDecisions || !data.NotifyOnDecisions.IsNull()) {
+		data.NotifyOnDecisions = types.BoolValue(*config.NotifyOnDecisions)
+	}
+	if config.WebhookURL != "" || !data.WebhookURL.IsNull() {
+		data.WebhookURL = types.StringValue(config.WebhookURL)
+	}
+
+	steps := make([]client.ApprovalFlowStep, len(flow.Steps))
+	copy(steps, flow.Steps)
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].StepOrder < steps[j].StepOrder
+	})
+
+	result := make([]ApprovalFlowStepModel, len(steps))
+	for i, step := range steps {
+		prior := ApprovalFlowStepModel{
+			ApproverRoles:        types.SetNull(types.StringType),
+			ApproverEmails:       types.SetNull(types.StringType),
+			ApproverPhoneNumbers: types.SetNull(types.StringType),
+		}
+		if i < len(data.Steps) {
+			prior = data.Steps[i]
+		}
+
+		result[i] = ApprovalFlowStepModel{
+			ApproverRoles:        flattenOptionalStringSet(ctx, step.ApproverSelectors.RoleKeys, prior.ApproverRoles, diags),
+			ApproverEmails:       flattenOptionalStringSet(ctx, step.ApproverSelectors.Emails, prior.ApproverEmails, diags),
+			ApproverPhoneNumbers: flattenOptionalStringSet(ctx, step.ApproverSelectors.PhoneNumbers, prior.ApproverPhoneNumbers, diags),
+			MinApprovals:         types.Int64Value(int64(step.Configuration.MinApprovals)),
+		}
+	}
+	data.Steps = result
+}
+
+// expandStringSet returns the elements of a string set, or an empty slice when it is null or unknown
+func expandStringSet(ctx context.Context, set types.Set, diags *diag.Diagnostics) []string {
+	values := []string{}
+	if set.IsNull() || set.IsUnknown() {
+		return values
+	}
+	diags.Append(set.ElementsAs(ctx, &values, false)...)
+	return values
+}
+
+// flattenOptionalStringSet returns a set of values, keeping current unset when values is empty
+func flattenOptionalStringSet(ctx context.Context, values []string, current types.Set, diags *diag.Diagnostics) types.Set {
+	if len(values) == 0 && current.IsNull() {
+		return current
+	}
+	set, d := types.SetValueFrom(ctx, types.StringType, nonNilStrings(values))
+	diags.Append(d...)
+	return set
+}
+
+// flattenOptionalInt64 returns value, keeping current unset when value is zero
+func flattenOptionalInt64(value int, current types.Int64) types.Int64 {
+	if value == 0 && current.IsNull() {
+		return current
+	}
+	return types.Int64Value(int64(value))
+}
@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestApprovalFlowResourceHasExpectedSchema(t *testing.T) {
+	r := NewApprovalFlowResource()
+
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	attrs := []string{
+		"id", "name", "description", "is_active", "channels", "timeout_minutes",
+		"reminder_interval_minutes", "auto_approve_minutes", "notify_on_decisions", "webhook_url", "steps",
+	}
+	for _, attr := range attrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in schema", attr)
+		}
+	}
+}
+
+func TestApprovalFlowResourceMetadata(t *testing.T) {
+	r := NewApprovalFlowResource()
+
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_approval_flow"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func testApprovalFlowStep(roles, emails []string, minApprovals int64) ApprovalFlowStepModel {
+	toSet := func(values []string) types.Set {
+		if values == nil {
+			return types.SetNull(types.StringType)
+		}
+		elems := make([]attr.Value, len(values))
+		for i, v := range values {
+			elems[i] = types.StringValue(v)
+		}
+		return types.SetValueMust(types.StringType, elems)
+	}
+
+	return ApprovalFlowStepModel{
+		ApproverRoles:        toSet(roles),
+		ApproverEmails:       toSet(emails),
+		ApproverPhoneNumbers: types.SetNull(types.StringType),
+		MinApprovals:         types.Int64Value(minApprovals),
+	}
+}
+
+func testApprovalFlowModel(steps ...ApprovalFlowStepModel) ApprovalFlowResourceModel {
+	return ApprovalFlowResourceModel{
+		Name:                    types.StringValue("Payments"),
+		Description:             types.StringNull(),
+		IsActive:                types.BoolValue(true),
+		Channels:                types.SetValueMust(types.StringType, []attr.Value{types.StringValue("email")}),
+		TimeoutMinutes:          types.Int64Value(1440),
+		ReminderIntervalMinutes: types.Int64Null(),
+		AutoApproveMinutes:      types.Int64Null(),
+		NotifyOnDecisions:       types.BoolNull(),
+		WebhookURL:              types.StringNull(),
+		Steps:                   steps,
+	}
+}
+
+func TestExpandApprovalFlow(t *testing.T) {
+	ctx := context.Background()
+
+	var diags diag.Diagnostics
+	req := expandApprovalFlow(ctx, testApprovalFlowModel(
+		testApprovalFlowStep([]string{"finance"}, nil, 2),
+		testApprovalFlowStep(nil, []string{"cfo@example.com"}, 1),
+	), &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if !req.Channels.Email || req.Channels.Sms {
+		t.Errorf("unexpected channels: %+v", req.Channels)
+	}
+	if req.Configuration.TimeoutMinutes != 1440 {
+		t.Errorf("expected timeout 1440, got %d", req.Configuration.TimeoutMinutes)
+	}
+	if len(req.Steps) != 2 {
+		t.Fatalf("expected 2 steps, got %d", len(req.Steps))
+	}
+	if req.Steps[0].StepOrder != 1 || req.Steps[1].StepOrder != 2 {
+		t.Errorf("expected steps numbered in order, got %+v", req.Steps)
+	}
+	if req.Steps[0].Configuration.MinApprovals != 2 || req.Steps[0].ApproverSelectors.RoleKeys[0] != "finance" {
+		t.Errorf("unexpected first step: %+v", req.Steps[0])
+	}
+}
+
+func TestExpandApprovalFlowValidation(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]ApprovalFlowResourceModel{
+		"no steps":               testApprovalFlowModel(),
+		"step without approvers": testApprovalFlowModel(testApprovalFlowStep(nil, nil, 1)),
+		"quorum above approvers": testApprovalFlowModel(testApprovalFlowStep(nil, []string{"cfo@example.com"}, 2)),
+		"zero quorum":            testApprovalFlowModel(testApprovalFlowStep([]string{"finance"}, nil, 0)),
+	}
+
+	timeout := testApprovalFlowModel(testApprovalFlowStep([]string{"finance"}, nil, 1))
+	timeout.TimeoutMinutes = types.Int64Value(1)
+	tests["timeout out of range"] = timeout
+
+	channel := testApprovalFlowModel(testApprovalFlowStep([]string{"finance"}, nil, 1))
+	channel.Channels = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("slack")})
+	tests["invalid channel"] = channel
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			expandApprovalFlow(ctx, data, &diags)
+			if !diags.HasError() {
+				t.Error("expected a validation error")
+			}
+		})
+	}
+
+	// A role-based quorum cannot be checked until the request is evaluated
+	var diags diag.Diagnostics
+	expandApprovalFlow(ctx, testApprovalFlowModel(testApprovalFlowStep([]string{"finance"}, nil, 5)), &diags)
+	if diags.HasError() {
+		t.Errorf("unexpected error: %v", diags)
+	}
+}
+
+func TestFlattenApprovalFlow(t *testing.T) {
+	ctx := context.Background()
+
+	var diags diag.Diagnostics
+	data := testApprovalFlowModel()
+	flattenApprovalFlow(ctx, &client.ApprovalFlow{
+		ID:       "flow-1",
+		Name:     "Payments",
+		IsActive: true,
+		Channels: client.ApprovalFlowChannels{Email: true, Sms: true},
+		Configuration: client.ApprovalFlowConfiguration{
+			TimeoutMinutes: 60,
+		},
+		Steps: []client.ApprovalFlowStep{
+			{
+				StepOrder:         2,
+				ApproverSelectors: client.ApproverSelectors{Emails: []string{"cfo@example.com"}},
+				Configuration:     client.ApprovalFlowStepQuorum{MinApprovals: 1},
+			},
+			{
+				StepOrder:         1,
+				ApproverSelectors: client.ApproverSelectors{RoleKeys: []string{"finance"}},
+				Configuration:     client.ApprovalFlowStepQuorum{MinApprovals: 2},
+			},
+		},
+	}, &data, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if data.ID.ValueString() != "flow-1" || len(data.Channels.Elements()) != 2 {
+		t.Errorf("unexpected approval flow: %+v", data)
+	}
+	if data.TimeoutMinutes.ValueInt64() != 60 {
+		t.Errorf("expected timeout 60, got %v", data.TimeoutMinutes)
+	}
+	if !data.ReminderIntervalMinutes.IsNull() || !data.WebhookURL.IsNull() {
+		t.Errorf("expected unset optional attributes to stay unset, got %+v", data)
+	}
+	if len(data.Steps) != 2 {
+		t.Fatalf("expected 2 steps, got %d", len(data.Steps))
+	}
+	if data.Steps[0].MinApprovals.ValueInt64() != 2 || len(data.Steps[0].ApproverRoles.Elements()) != 1 {
+		t.Errorf("expected steps sorted by order, got %+v", data.Steps)
+	}
+	if !data.Steps[0].ApproverEmails.IsNull() {
+		t.Errorf("expected approver emails to stay unset, got %v", data.Steps[0].ApproverEmails)
+	}
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 
 	"github.com/frontegg/terraform-provider-agentlink/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -16,6 +17,10 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ConditionalPolicyResource{}
 var _ resource.ResourceWithImportState = &ConditionalPolicyResource{}
+var _ resource.ResourceWithIdentity = &ConditionalPolicyResource{}
+var _ resource.ResourceWithMoveState = &ConditionalPolicyResource{}
+var _ resource.ResourceWithUpgradeState = &ConditionalPolicyResource{}
+var _ resource.ResourceWithValidateConfig = &ConditionalPolicyResource{}
 
 func NewConditionalPolicyResource() resource.Resource {
 	return &ConditionalPolicyResource{}
@@ -28,15 +33,19 @@ type ConditionalPolicyResource struct {
 
 // ConditionalPolicyResourceModel describes the resource data model.
 type ConditionalPolicyResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Name            types.String `tfsdk:"name"`
-	Description     types.String `tfsdk:"description"`
-	Enabled         types.Bool   `tfsdk:"enabled"`
-	AppIDs          types.List   `tfsdk:"app_ids"`
-	TenantID        types.String `tfsdk:"tenant_id"`
-	InternalToolIDs types.List   `tfsdk:"internal_tool_ids"`
-	Targeting       types.Object `tfsdk:"targeting"`
-	Metadata        types.Map    `tfsdk:"metadata"`
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Description        types.String `tfsdk:"description"`
+	Enabled            types.Bool   `tfsdk:"enabled"`
+	AppIDs             types.List   `tfsdk:"app_ids"`
+	TenantID           types.String `tfsdk:"tenant_id"`
+	TenantIDs          types.List   `tfsdk:"tenant_ids"`
+	InternalToolIDs    types.List   `tfsdk:"internal_tool_ids"`
+	ToolTags           types.Set    `tfsdk:"tool_tags"`
+	Targeting          types.Object `tfsdk:"targeting"`
+	Metadata           types.Map    `tfsdk:"metadata"`
+	DisableOnDestroy   types.Bool   `tfsdk:"disable_on_destroy"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 func (r *ConditionalPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -46,6 +55,7 @@ func (r *ConditionalPolicyResource) Metadata(ctx context.Context, req resource.M
 func (r *ConditionalPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a conditional policy for access control with targeting rules.",
+		Version:     0,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The policy ID.",
@@ -72,74 +82,49 @@ func (r *ConditionalPolicyResource) Schema(ctx context.Context, req resource.Sch
 				ElementType: types.StringType,
 			},
 			"tenant_id": schema.StringAttribute{
-				Description: "The tenant ID this policy applies to.",
+				Description:        "The tenant ID this policy applies to. Conflicts with tenant_ids.",
+				Optional:           true,
+				DeprecationMessage: "Use tenant_ids instead. tenant_id will be removed in a future major version.",
+			},
+			"tenant_ids": schema.ListAttribute{
+				Description: "List of tenant IDs this policy applies to. Conflicts with tenant_id.",
 				Optional:    true,
+				ElementType: types.StringType,
 			},
 			"internal_tool_ids": schema.ListAttribute{
-				Description: "List of internal tool IDs this policy applies to. Empty list applies to all tools.",
+				Description: "List of internal tool IDs this policy applies to. Empty list applies to all tools unless tool_tags is set.",
 				Required:    true,
 				ElementType: types.StringType,
 			},
-			"targeting": schema.SingleNestedAttribute{
-				Description: "Targeting rules for when this policy applies.",
-				Optional:    true,
-				Attributes: map[string]schema.Attribute{
-					"if": schema.SingleNestedAttribute{
-						Description: "Conditions block.",
-						Required:    true,
-						Attributes: map[string]schema.Attribute{
-							"conditions": schema.ListNestedAttribute{
-								Description: "List of conditions to evaluate.",
-								Required:    true,
-								NestedObject: schema.NestedAttributeObject{
-									Attributes: map[string]schema.Attribute{
-										"attribute": schema.StringAttribute{
-											Description: "The attribute to evaluate.",
-											Required:    true,
-										},
-										"negate": schema.BoolAttribute{
-											Description: "Whether to negate the condition.",
-											Required:    true,
-										},
-										"op": schema.StringAttribute{
-											Description: "The operation to perform.",
-											Required:    true,
-										},
-										"value": schema.MapAttribute{
-											Description: "The value to compare against.",
-											Required:    true,
-											ElementType: types.StringType,
-										},
-									},
-								},
-							},
-						},
-					},
-					"then": schema.SingleNestedAttribute{
-						Description: "Result block.",
-						Required:    true,
-						Attributes: map[string]schema.Attribute{
-							"result": schema.StringAttribute{
-								Description: "The result when conditions are met. Valid values: ALLOW, DENY, APPROVAL_REQUIRED.",
-								Required:    true,
-							},
-							"approval_flow_id": schema.StringAttribute{
-								Description: "The approval flow ID (required when result is APPROVAL_REQUIRED).",
-								Optional:    true,
-							},
-						},
-					},
-				},
-			},
+			"tool_tags": policyToolTagsAttribute(),
+			"targeting": policyTargetingSchema(),
 			"metadata": schema.MapAttribute{
 				Description: "Additional metadata for the policy.",
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			"disable_on_destroy":  disableOnDestroyAttribute(),
+			"deletion_protection": deletionProtectionAttribute("Destroying a policy immediately stops enforcing it for agents."),
 		},
 	}
 }
 
+func (r *ConditionalPolicyResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema()
+}
+
+func (r *ConditionalPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var targeting types.Object
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("targeting"), &targeting)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validatePolicyTargetingResults(ctx, targeting, &resp.Diagnostics)
+	validatePolicyTenants(ctx, req.Config, &resp.Diagnostics)
+}
+
 func (r *ConditionalPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -158,6 +143,9 @@ func (r *ConditionalPolicyResource) Configure(ctx context.Context, req resource.
 }
 
 func (r *ConditionalPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data ConditionalPolicyResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -174,6 +162,12 @@ func (r *ConditionalPolicyResource) Create(ctx context.Context, req resource.Cre
 		}
 	}
 
+	// Convert tenant_id / tenant_ids
+	tenantID, tenantIDs := expandPolicyTenants(ctx, data.TenantID, data.TenantIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Convert internal_tool_ids
 	var toolIDs []string
 	resp.Diagnostics.Append(data.InternalToolIDs.ElementsAs(ctx, &toolIDs, false)...)
@@ -181,8 +175,14 @@ func (r *ConditionalPolicyResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
+	// Convert tool_tags
+	toolTags := expandStringSet(ctx, data.ToolTags, &resp.Diagnostics)
+
 	// Build targeting from state
-	targeting := r.buildTargetingFromState(ctx, data.Targeting)
+	targeting := expandPolicyTargeting(ctx, data.Targeting, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Convert metadata
 	var metadata map[string]interface{}
@@ -203,24 +203,30 @@ func (r *ConditionalPolicyResource) Create(ctx context.Context, req resource.Cre
 		Description:     data.Description.ValueString(),
 		Enabled:         data.Enabled.ValueBool(),
 		AppIDs:          appIDs,
-		TenantID:        data.TenantID.ValueString(),
+		TenantID:        tenantID,
+		TenantIDs:       tenantIDs,
 		InternalToolIDs: toolIDs,
+		ToolTags:        toolTags,
 		Targeting:       targeting,
 		Metadata:        metadata,
 	}
 
 	policy, err := r.client.CreateConditionalPolicy(ctx, createReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to create conditional policy: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to create conditional policy", err)
 		return
 	}
 
 	data.ID = types.StringValue(policy.ID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID)...)
 }
 
 func (r *ConditionalPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data ConditionalPolicyResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -229,13 +235,12 @@ func (r *ConditionalPolicyResource) Read(ctx context.Context, req resource.ReadR
 	}
 
 	policy, err := r.client.GetConditionalPolicy(ctx, data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to read conditional policy: "+err.Error())
+	if errors.Is(err, client.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
 		return
 	}
-
-	if policy == nil {
-		resp.State.RemoveResource(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read conditional policy", err)
 		return
 	}
 
@@ -255,7 +260,8 @@ func (r *ConditionalPolicyResource) Read(ctx context.Context, req resource.ReadR
 		data.AppIDs = types.ListNull(types.StringType)
 	}
 
-	if policy.TenantID != "" {
+	data.TenantIDs = flattenPolicyTenantIDs(ctx, policy, data.TenantID, data.TenantIDs)
+	if policy.TenantID != "" && data.TenantIDs.IsNull() {
 		data.TenantID = types.StringValue(policy.TenantID)
 	}
 
@@ -269,11 +275,21 @@ func (r *ConditionalPolicyResource) Read(ctx context.Context, req resource.ReadR
 	} else {
 		data.InternalToolIDs, _ = types.ListValue(types.StringType, []attr.Value{})
 	}
+	data.ToolTags = flattenOptionalStringSet(ctx, policy.ToolTags, data.ToolTags, &resp.Diagnostics)
+
+	data.Targeting = flattenPolicyTargeting(ctx, policy.Targeting, data.Targeting, &resp.Diagnostics)
+
+	data.DisableOnDestroy = flattenLocalBool(data.DisableOnDestroy)
+	data.DeletionProtection = flattenLocalBool(data.DeletionProtection)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID)...)
 }
 
 func (r *ConditionalPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data ConditionalPolicyResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -290,6 +306,12 @@ func (r *ConditionalPolicyResource) Update(ctx context.Context, req resource.Upd
 		}
 	}
 
+	// Convert tenant_id / tenant_ids
+	tenantID, tenantIDs := expandPolicyTenantsUpdate(ctx, data.TenantID, data.TenantIDs, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Convert internal_tool_ids
 	var toolIDs []string
 	resp.Diagnostics.Append(data.InternalToolIDs.ElementsAs(ctx, &toolIDs, false)...)
@@ -297,8 +319,14 @@ func (r *ConditionalPolicyResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
+	// Convert tool_tags
+	toolTags := expandStringSet(ctx, data.ToolTags, &resp.Diagnostics)
+
 	// Build targeting from state
-	targeting := r.buildTargetingFromState(ctx, data.Targeting)
+	targeting := expandPolicyTargeting(ctx, data.Targeting, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Convert metadata
 	var metadata map[string]interface{}
@@ -320,22 +348,28 @@ func (r *ConditionalPolicyResource) Update(ctx context.Context, req resource.Upd
 		Description:     data.Description.ValueString(),
 		Enabled:         &enabled,
 		AppIDs:          appIDs,
-		TenantID:        data.TenantID.ValueString(),
+		TenantID:        tenantID,
+		TenantIDs:       tenantIDs,
 		InternalToolIDs: toolIDs,
+		ToolTags:        toolTags,
 		Targeting:       targeting,
 		Metadata:        metadata,
 	}
 
 	_, err := r.client.UpdateConditionalPolicy(ctx, data.ID.ValueString(), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to update conditional policy: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to update conditional policy", err)
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID)...)
 }
 
 func (r *ConditionalPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data ConditionalPolicyResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -343,23 +377,33 @@ func (r *ConditionalPolicyResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
+	if checkDeletionProtection(data.DeletionProtection, "agentlink_conditional_policy", data.Name.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	// Keep the policy, and its history and references, when Terraform stops managing it
+	if data.DisableOnDestroy.ValueBool() {
+		if err := r.client.SetPolicyEnabled(ctx, data.ID.ValueString(), "CONDITIONAL", false); err != nil {
+			addClientError(&resp.Diagnostics, "Unable to disable conditional policy", err)
+		}
+		return
+	}
+
 	err := r.client.DeletePolicy(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to delete conditional policy: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to delete conditional policy", err)
 		return
 	}
 }
 
 func (r *ConditionalPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
 
-func (r *ConditionalPolicyResource) buildTargetingFromState(ctx context.Context, targeting types.Object) *client.PolicyTargeting {
-	if targeting.IsNull() || targeting.IsUnknown() {
-		return nil
-	}
+func (r *ConditionalPolicyResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
 
-	// This is a simplified implementation - in production you'd want to properly
-	// parse the nested object structure
-	return nil
+func (r *ConditionalPolicyResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
 }
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deletionProtectionAttribute returns the deletion_protection attribute of resources whose
+// destruction cascades to other objects or weakens access control.
+func deletionProtectionAttribute(consequence string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Whether Terraform is prevented from destroying this resource. " + consequence +
+			" Set to false and apply before destroying. Defaults to false.",
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+// flattenLocalBool returns the state value of a boolean setting that only exists in Terraform,
+// such as deletion_protection. Imported resources start with false.
+func flattenLocalBool(current types.Bool) types.Bool {
+	if current.IsNull() || current.IsUnknown() {
+		return types.BoolValue(false)
+	}
+	return current
+}
+
+// checkDeletionProtection adds an error and returns true when the resource may not be destroyed
+func checkDeletionProtection(protection types.Bool, resourceType, name string, diags *diag.Diagnostics) bool {
+	if !protection.ValueBool() {
+		return false
+	}
+
+	diags.AddError(
+		"Deletion Protection Enabled",
+		fmt.Sprintf("%s %q has deletion_protection enabled and cannot be destroyed. "+
+			"Set deletion_protection = false and apply before destroying it.", resourceType, name),
+	)
+	return true
+}
+
+// disableOnDestroyAttribute returns the disable_on_destroy attribute of policy resources
+func disableOnDestroyAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Whether destroying the resource disables the policy in Frontegg instead of deleting it, " +
+			"preserving its history and references when Terraform stops managing it. Defaults to false.",
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// IDIdentityModel describes the identity of resources identified by their API ID alone.
+type IDIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+// idIdentitySchema returns the identity schema of resources identified by their API ID alone.
+func idIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "The ID of the object in Frontegg.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// SourceIdentityModel describes the identity of a source, which is scoped to an application.
+type SourceIdentityModel struct {
+	ApplicationID types.String `tfsdk:"application_id"`
+	ID            types.String `tfsdk:"id"`
+}
+
+// sourceIdentitySchema returns the identity schema of the source resource.
+func sourceIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"application_id": identityschema.StringAttribute{
+				Description:       "The ID of the application the source belongs to.",
+				RequiredForImport: true,
+			},
+			"id": identityschema.StringAttribute{
+				Description:       "The source ID.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// setIDIdentity stores the API ID as the resource identity.
+func setIDIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, id types.String) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, IDIdentityModel{ID: id})
+}
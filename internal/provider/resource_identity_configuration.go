@@ -2,10 +2,14 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -14,6 +18,8 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &IdentityConfigurationResource{}
+var _ resource.ResourceWithMoveState = &IdentityConfigurationResource{}
+var _ resource.ResourceWithUpgradeState = &IdentityConfigurationResource{}
 
 func NewIdentityConfigurationResource() resource.Resource {
 	return &IdentityConfigurationResource{}
@@ -26,17 +32,24 @@ type IdentityConfigurationResource struct {
 
 // IdentityConfigurationResourceModel describes the resource data model.
 type IdentityConfigurationResourceModel struct {
-	ID                     types.String `tfsdk:"id"`
-	DefaultTokenExpiration types.Int64  `tfsdk:"default_token_expiration"`
+	ID                         types.String `tfsdk:"id"`
+	DefaultTokenExpiration     types.Int64  `tfsdk:"default_token_expiration"`
+	RefreshTokenExpiration     types.Int64  `tfsdk:"refresh_token_expiration"`
+	RotateRefreshTokens        types.Bool   `tfsdk:"rotate_refresh_tokens"`
+	RefreshTokensRotationLimit types.Int64  `tfsdk:"refresh_tokens_rotation_limit"`
 }
 
+// maxRefreshTokenExpiration is the longest refresh token lifetime accepted by the API (180 days)
+const maxRefreshTokenExpiration = 15552000
+
 func (r *IdentityConfigurationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_identity_configuration"
 }
 
 func (r *IdentityConfigurationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages identity configuration settings including default token expiration.",
+		Description: "Manages identity configuration settings including access and refresh token lifetimes and refresh token rotation.",
+		Version:     0,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The configuration ID.",
@@ -52,6 +65,30 @@ func (r *IdentityConfigurationResource) Schema(ctx context.Context, req resource
 					int64planmodifier.UseStateForUnknown(),
 				},
 			},
+			"refresh_token_expiration": schema.Int64Attribute{
+				Description: "The default refresh token expiration time in seconds. Maximum value is 15552000 (180 days).",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotate_refresh_tokens": schema.BoolAttribute{
+				Description: "Whether a new refresh token is issued each time a refresh token is used.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"refresh_tokens_rotation_limit": schema.Int64Attribute{
+				Description: "How many times a rotated refresh token may be reused before it is treated as a replay and the token family is revoked (reuse detection).",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -74,6 +111,9 @@ func (r *IdentityConfigurationResource) Configure(ctx context.Context, req resou
 }
 
 func (r *IdentityConfigurationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data IdentityConfigurationResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -81,26 +121,27 @@ func (r *IdentityConfigurationResource) Create(ctx context.Context, req resource
 		return
 	}
 
-	tokenExpiration := int(data.DefaultTokenExpiration.ValueInt64())
-
-	updateReq := client.UpdateIdentityConfigurationRequest{
-		DefaultTokenExpiration: &tokenExpiration,
+	updateReq := expandIdentityConfiguration(data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	config, err := r.client.UpdateIdentityConfiguration(ctx, updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to create identity configuration: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to create identity configuration", err)
 		return
 	}
 
 	// Map response to model
-	data.ID = types.StringValue(config.ID)
-	data.DefaultTokenExpiration = types.Int64Value(int64(config.DefaultTokenExpiration))
+	flattenIdentityConfiguration(config, &data)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *IdentityConfigurationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data IdentityConfigurationResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -110,18 +151,20 @@ func (r *IdentityConfigurationResource) Read(ctx context.Context, req resource.R
 
 	config, err := r.client.GetIdentityConfiguration(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to read identity configuration: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to read identity configuration", err)
 		return
 	}
 
 	// Map response to model
-	data.ID = types.StringValue(config.ID)
-	data.DefaultTokenExpiration = types.Int64Value(int64(config.DefaultTokenExpiration))
+	flattenIdentityConfiguration(config, &data)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *IdentityConfigurationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data IdentityConfigurationResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -129,27 +172,86 @@ func (r *IdentityConfigurationResource) Update(ctx context.Context, req resource
 		return
 	}
 
-	tokenExpiration := int(data.DefaultTokenExpiration.ValueInt64())
-
-	updateReq := client.UpdateIdentityConfigurationRequest{
-		DefaultTokenExpiration: &tokenExpiration,
+	updateReq := expandIdentityConfiguration(data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	config, err := r.client.UpdateIdentityConfiguration(ctx, updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to update identity configuration: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to update identity configuration", err)
 		return
 	}
 
 	// Map response to model
-	data.ID = types.StringValue(config.ID)
-	data.DefaultTokenExpiration = types.Int64Value(int64(config.DefaultTokenExpiration))
+	flattenIdentityConfiguration(config, &data)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *IdentityConfigurationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	// Identity configuration cannot be deleted, it's a singleton resource.
 	// On destroy, we simply remove it from state. The configuration will remain
 	// on the server with its current values.
 }
+
+func (r *IdentityConfigurationResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
+
+func (r *IdentityConfigurationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+// expandIdentityConfiguration builds the identity configuration request from the resource model.
+// Unset optional attributes are omitted so the server keeps its current values.
+func expandIdentityConfiguration(data IdentityConfigurationResourceModel, diags *diag.Diagnostics) client.UpdateIdentityConfigurationRequest {
+	tokenExpiration := int(data.DefaultTokenExpiration.ValueInt64())
+
+	req := client.UpdateIdentityConfigurationRequest{
+		DefaultTokenExpiration: &tokenExpiration,
+	}
+
+	if !data.RefreshTokenExpiration.IsNull() && !data.RefreshTokenExpiration.IsUnknown() {
+		refreshExpiration := int(data.RefreshTokenExpiration.ValueInt64())
+		if refreshExpiration < 1 || refreshExpiration > maxRefreshTokenExpiration {
+			diags.AddAttributeError(
+				path.Root("refresh_token_expiration"),
+				"Invalid Refresh Token Expiration",
+				fmt.Sprintf("refresh_token_expiration must be between 1 and %d seconds", maxRefreshTokenExpiration),
+			)
+		}
+		req.DefaultRefreshTokenExpiration = &refreshExpiration
+	}
+
+	if !data.RotateRefreshTokens.IsNull() && !data.RotateRefreshTokens.IsUnknown() {
+		rotate := data.RotateRefreshTokens.ValueBool()
+		req.RotateRefreshTokens = &rotate
+	}
+
+	if !data.RefreshTokensRotationLimit.IsNull() && !data.RefreshTokensRotationLimit.IsUnknown() {
+		limit := int(data.RefreshTokensRotationLimit.ValueInt64())
+		if limit < 0 {
+			diags.AddAttributeError(
+				path.Root("refresh_tokens_rotation_limit"),
+				"Invalid Rotation Limit",
+				"refresh_tokens_rotation_limit must not be negative",
+			)
+		}
+		req.RefreshTokensRotationLimit = &limit
+	}
+
+	return req
+}
+
+// flattenIdentityConfiguration maps an identity configuration response onto the resource model
+func flattenIdentityConfiguration(config *client.IdentityConfiguration, data *IdentityConfigurationResourceModel) {
+	data.ID = types.StringValue(config.ID)
+	data.DefaultTokenExpiration = types.Int64Value(int64(config.DefaultTokenExpiration))
+	data.RefreshTokenExpiration = types.Int64Value(int64(config.DefaultRefreshTokenExpiration))
+	data.RotateRefreshTokens = types.BoolValue(config.RotateRefreshTokens)
+	data.RefreshTokensRotationLimit = types.Int64Value(int64(config.RefreshTokensRotationLimit))
+}
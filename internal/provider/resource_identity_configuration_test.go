@@ -4,8 +4,10 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func TestIdentityConfigurationResourceHasExpectedSchema(t *testing.T) {
@@ -26,6 +28,14 @@ func TestIdentityConfigurationResourceHasExpectedSchema(t *testing.T) {
 		}
 	}
 
+	// Check optional attributes
+	optionalAttrs := []string{"refresh_token_expiration", "rotate_refresh_tokens", "refresh_tokens_rotation_limit"}
+	for _, attr := range optionalAttrs {
+		if _, ok := schemaResp.Schema.Attributes[attr]; !ok {
+			t.Errorf("Schema should have optional attribute: %s", attr)
+		}
+	}
+
 	// Check computed attributes
 	computedAttrs := []string{"id"}
 	for _, attr := range computedAttrs {
@@ -40,6 +50,40 @@ func TestIdentityConfigurationResourceHasExpectedSchema(t *testing.T) {
 	}
 }
 
+func TestExpandIdentityConfiguration(t *testing.T) {
+	var diags diag.Diagnostics
+	req := expandIdentityConfiguration(IdentityConfigurationResourceModel{
+		DefaultTokenExpiration:     types.Int64Value(300),
+		RefreshTokenExpiration:     types.Int64Value(86400),
+		RotateRefreshTokens:        types.BoolValue(true),
+		RefreshTokensRotationLimit: types.Int64Unknown(),
+	}, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if req.DefaultTokenExpiration == nil || *req.DefaultTokenExpiration != 300 {
+		t.Errorf("expected default token expiration 300, got %v", req.DefaultTokenExpiration)
+	}
+	if req.DefaultRefreshTokenExpiration == nil || *req.DefaultRefreshTokenExpiration != 86400 {
+		t.Errorf("expected refresh token expiration 86400, got %v", req.DefaultRefreshTokenExpiration)
+	}
+	if req.RotateRefreshTokens == nil || !*req.RotateRefreshTokens {
+		t.Errorf("expected rotate refresh tokens to be true, got %v", req.RotateRefreshTokens)
+	}
+	if req.RefreshTokensRotationLimit != nil {
+		t.Errorf("expected unknown rotation limit to be omitted, got %v", *req.RefreshTokensRotationLimit)
+	}
+
+	diags = diag.Diagnostics{}
+	expandIdentityConfiguration(IdentityConfigurationResourceModel{
+		DefaultTokenExpiration: types.Int64Value(300),
+		RefreshTokenExpiration: types.Int64Value(maxRefreshTokenExpiration + 1),
+	}, &diags)
+	if !diags.HasError() {
+		t.Error("expected an error for a refresh token expiration above the maximum")
+	}
+}
+
 func TestIdentityConfigurationResourceMetadata(t *testing.T) {
 	r := NewIdentityConfigurationResource()
 
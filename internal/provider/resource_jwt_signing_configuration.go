@@ -0,0 +1,270 @@
+package provider
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// jwtAlgorithms lists the JWT signing algorithms supported by the identity service
+var jwtAlgorithms = []string{"RS256", "HS256"}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &JwtSigningConfigurationResource{}
+var _ resource.ResourceWithImportState = &JwtSigningConfigurationResource{}
+var _ resource.ResourceWithMoveState = &JwtSigningConfigurationResource{}
+var _ resource.ResourceWithUpgradeState = &JwtSigningConfigurationResource{}
+
+func NewJwtSigningConfigurationResource() resource.Resource {
+	return &JwtSigningConfigurationResource{}
+}
+
+// JwtSigningConfigurationResource defines the resource implementation.
+type JwtSigningConfigurationResource struct {
+	client *client.Client
+}
+
+// JwtSigningConfigurationResourceModel describes the resource data model.
+type JwtSigningConfigurationResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Algorithm   types.String `tfsdk:"algorithm"`
+	ActiveKeyID types.String `tfsdk:"active_key_id"`
+	PublicKey   types.String `tfsdk:"public_key"`
+}
+
+func (r *JwtSigningConfigurationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jwt_signing_configuration"
+}
+
+func (r *JwtSigningConfigurationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the JWT signing algorithm of the vendor and exposes the active signing key.",
+		Version:     0,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The configuration ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"algorithm": schema.StringAttribute{
+				Description: "The JWT signing algorithm. Valid values: RS256, HS256.",
+				Required:    true,
+			},
+			"active_key_id": schema.StringAttribute{
+				Description: "The ID of the active signing key (RFC 7638 JWK thumbprint of the public key). Empty when no public key is available.",
+				Computed:    true,
+			},
+			"public_key": schema.StringAttribute{
+				Description: "The public key used to verify tokens. Empty when no public key is available.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *JwtSigningConfigurationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *JwtSigningConfigurationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data JwtSigningConfigurationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	algorithm := data.Algorithm.ValueString()
+	if !isValidJwtAlgorithm(algorithm) {
+		resp.Diagnostics.AddAttributeError(path.Root("algorithm"), "Invalid Algorithm", fmt.Sprintf("algorithm must be one of %v", jwtAlgorithms))
+		return
+	}
+
+	config, err := r.client.UpdateIdentityConfiguration(ctx, client.UpdateIdentityConfigurationRequest{
+		JwtAlgorithm: &algorithm,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to create JWT signing configuration", err)
+		return
+	}
+
+	r.mapConfiguration(config, &data, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *JwtSigningConfigurationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data JwtSigningConfigurationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetIdentityConfiguration(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read JWT signing configuration", err)
+		return
+	}
+
+	data.Algorithm = types.StringValue(config.JwtAlgorithm)
+	r.mapConfiguration(config, &data, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *JwtSigningConfigurationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data JwtSigningConfigurationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	algorithm := data.Algorithm.ValueString()
+	if !isValidJwtAlgorithm(algorithm) {
+		resp.Diagnostics.AddAttributeError(path.Root("algorithm"), "Invalid Algorithm", fmt.Sprintf("algorithm must be one of %v", jwtAlgorithms))
+		return
+	}
+
+	config, err := r.client.UpdateIdentityConfiguration(ctx, client.UpdateIdentityConfigurationRequest{
+		JwtAlgorithm: &algorithm,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to update JWT signing configuration", err)
+		return
+	}
+
+	r.mapConfiguration(config, &data, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *JwtSigningConfigurationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	// JWT signing configuration is part of the identity configuration singleton.
+	// On destroy, we simply remove it from state. The algorithm and active key
+	// remain on the server with their current values.
+}
+
+func (r *JwtSigningConfigurationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *JwtSigningConfigurationResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
+
+func (r *JwtSigningConfigurationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+// mapConfiguration maps the signing related fields of an identity configuration onto the resource model
+func (r *JwtSigningConfigurationResource) mapConfiguration(config *client.IdentityConfiguration, data *JwtSigningConfigurationResourceModel, diags *diag.Diagnostics) {
+	data.ID = types.StringValue(config.ID)
+	data.PublicKey = types.StringValue(config.PublicKey)
+	data.ActiveKeyID = types.StringValue("")
+
+	if config.PublicKey == "" {
+		return
+	}
+
+	keyID, err := signingKeyID(config.PublicKey)
+	if err != nil {
+		diags.AddWarning("Signing Key Warning", "Unable to compute the active key ID: "+err.Error())
+		return
+	}
+	data.ActiveKeyID = types.StringValue(keyID)
+}
+
+// isValidJwtAlgorithm reports whether algorithm is a supported JWT signing algorithm
+func isValidJwtAlgorithm(algorithm string) bool {
+	for _, valid := range jwtAlgorithms {
+		if algorithm == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRSAPublicKey parses a PEM encoded or bare base64 DER encoded RSA public key
+func parseRSAPublicKey(publicKey string) (*rsa.PublicKey, error) {
+	var der []byte
+	if block, _ := pem.Decode([]byte(publicKey)); block != nil {
+		der = block.Bytes
+	} else {
+		decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(publicKey), ""))
+		if err != nil {
+			return nil, fmt.Errorf("public key is neither PEM nor base64 encoded: %w", err)
+		}
+		der = decoded
+	}
+
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+
+	return rsaKey, nil
+}
+
+// signingKeyID returns the RFC 7638 JWK thumbprint of an RSA public key
+func signingKeyID(publicKey string) (string, error) {
+	key, err := parseRSAPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	// Members in lexicographic order, without whitespace, as required by RFC 7638
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	n := base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+	thumbprint := sha256.Sum256([]byte(`{"e":"` + e + `","kty":"RSA","n":"` + n + `"}`))
+
+	return base64.RawURLEncoding.EncodeToString(thumbprint[:]), nil
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// rfc7638ExampleModulus is the modulus of the example key in RFC 7638 section 3.1
+const rfc7638ExampleModulus = "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"
+
+func TestJwtSigningConfigurationResourceHasExpectedSchema(t *testing.T) {
+	r := NewJwtSigningConfigurationResource()
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.Background(), req, resp)
+
+	// Check required attribute
+	if _, ok := resp.Schema.Attributes["algorithm"]; !ok {
+		t.Error("expected 'algorithm' attribute in schema")
+	}
+
+	// Check computed attributes
+	computedAttrs := []string{"id", "active_key_id", "public_key"}
+	for _, attr := range computedAttrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected computed attribute '%s' in schema", attr)
+		}
+	}
+}
+
+func TestJwtSigningConfigurationResourceMetadata(t *testing.T) {
+	r := NewJwtSigningConfigurationResource()
+
+	req := resource.MetadataRequest{ProviderTypeName: "agentlink"}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	expected := "agentlink_jwt_signing_configuration"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func TestJwtSigningConfigurationResourceImplementsResource(t *testing.T) {
+	r := NewJwtSigningConfigurationResource()
+
+	var _ = r
+	var _ resource.ResourceWithImportState = r.(*JwtSigningConfigurationResource)
+}
+
+func TestSigningKeyID(t *testing.T) {
+	modulus, err := base64.RawURLEncoding.DecodeString(rfc7638ExampleModulus)
+	if err != nil {
+		t.Fatalf("failed to decode modulus: %v", err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: 65537})
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+
+	expected := "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"
+
+	// PEM encoded
+	pemKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	if got, err := signingKeyID(pemKey); err != nil || got != expected {
+		t.Errorf("expected key ID '%s', got '%s' (err: %v)", expected, got, err)
+	}
+
+	// Bare base64 DER
+	if got, err := signingKeyID(base64.StdEncoding.EncodeToString(der)); err != nil || got != expected {
+		t.Errorf("expected key ID '%s', got '%s' (err: %v)", expected, got, err)
+	}
+
+	if _, err := signingKeyID("not a key"); err == nil {
+		t.Error("expected an error for an invalid public key")
+	}
+}
@@ -2,6 +2,8 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/frontegg/terraform-provider-agentlink/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -12,12 +14,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &MaskingPolicyResource{}
 var _ resource.ResourceWithImportState = &MaskingPolicyResource{}
+var _ resource.ResourceWithIdentity = &MaskingPolicyResource{}
+var _ resource.ResourceWithMoveState = &MaskingPolicyResource{}
+var _ resource.ResourceWithUpgradeState = &MaskingPolicyResource{}
+var _ resource.ResourceWithValidateConfig = &MaskingPolicyResource{}
 
 func NewMaskingPolicyResource() resource.Resource {
 	return &MaskingPolicyResource{}
@@ -36,8 +43,13 @@ type MaskingPolicyResourceModel struct {
 	Enabled             types.Bool   `tfsdk:"enabled"`
 	AppIDs              types.List   `tfsdk:"app_ids"`
 	TenantID            types.String `tfsdk:"tenant_id"`
+	TenantIDs           types.List   `tfsdk:"tenant_ids"`
 	InternalToolIDs     types.List   `tfsdk:"internal_tool_ids"`
+	ToolTags            types.Set    `tfsdk:"tool_tags"`
 	PolicyConfiguration types.Object `tfsdk:"policy_configuration"`
+	Targeting           types.Object `tfsdk:"targeting"`
+	DisableOnDestroy    types.Bool   `tfsdk:"disable_on_destroy"`
+	DeletionProtection  types.Bool   `tfsdk:"deletion_protection"`
 }
 
 // MaskingConfigModel represents the masking configuration
@@ -57,6 +69,32 @@ type MaskingConfigModel struct {
 	EthereumAddress types.Bool `tfsdk:"ethereum_address"`
 	CvvCvc          types.Bool `tfsdk:"cvv_cvc"`
 	Url             types.Bool `tfsdk:"url"`
+	Formatting      types.Map  `tfsdk:"formatting"`
+}
+
+// MaskingFormatModel represents the mask formatting options for a single entity
+type MaskingFormatModel struct {
+	MaskChar     types.String `tfsdk:"mask_char"`
+	VisibleChars types.Int64  `tfsdk:"visible_chars"`
+}
+
+// maskingEntityAPINames maps policy_configuration entity attributes to their API field names
+var maskingEntityAPINames = map[string]string{
+	"credit_card":       "creditCard",
+	"email_address":     "emailAddress",
+	"phone_number":      "phoneNumber",
+	"ip_address":        "ipAddress",
+	"us_ssn":            "usSsn",
+	"us_driver_license": "usDriverLicense",
+	"us_passport":       "usPassport",
+	"us_itin":           "usItin",
+	"us_bank_number":    "usBankNumber",
+	"iban_code":         "ibanCode",
+	"swift_code":        "swiftCode",
+	"bitcoin_address":   "bitcoinAddress",
+	"ethereum_address":  "ethereumAddress",
+	"cvv_cvc":           "cvvCvc",
+	"url":               "url",
 }
 
 func (r *MaskingPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -66,6 +104,7 @@ func (r *MaskingPolicyResource) Metadata(ctx context.Context, req resource.Metad
 func (r *MaskingPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a data masking policy for sensitive information protection.",
+		Version:     0,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The policy ID.",
@@ -92,14 +131,21 @@ func (r *MaskingPolicyResource) Schema(ctx context.Context, req resource.SchemaR
 				ElementType: types.StringType,
 			},
 			"tenant_id": schema.StringAttribute{
-				Description: "The tenant ID this policy applies to.",
+				Description:        "The tenant ID this policy applies to. Conflicts with tenant_ids.",
+				Optional:           true,
+				DeprecationMessage: "Use tenant_ids instead. tenant_id will be removed in a future major version.",
+			},
+			"tenant_ids": schema.ListAttribute{
+				Description: "List of tenant IDs this policy applies to. Conflicts with tenant_id.",
 				Optional:    true,
+				ElementType: types.StringType,
 			},
 			"internal_tool_ids": schema.ListAttribute{
-				Description: "List of internal tool IDs this policy applies to. Empty list applies to all tools.",
+				Description: "List of internal tool IDs this policy applies to. Empty list applies to all tools unless tool_tags is set.",
 				Required:    true,
 				ElementType: types.StringType,
 			},
+			"tool_tags": policyToolTagsAttribute(),
 			"policy_configuration": schema.SingleNestedAttribute{
 				Description: "Configuration specifying what data types to mask.",
 				Required:    true,
@@ -194,12 +240,80 @@ func (r *MaskingPolicyResource) Schema(ctx context.Context, req resource.SchemaR
 						Computed:    true,
 						Default:     booldefault.StaticBool(false),
 					},
+					"formatting": schema.MapNestedAttribute{
+						Description: "Per-entity mask formatting, keyed by entity name (e.g. credit_card). Entities without an entry use the server defaults.",
+						Optional:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"mask_char": schema.StringAttribute{
+									Description: "The single character used to mask the entity (e.g. *).",
+									Optional:    true,
+								},
+								"visible_chars": schema.Int64Attribute{
+									Description: "Number of trailing characters left unmasked (e.g. 4 to show the last 4 digits of a credit card). 0 masks the entity completely.",
+									Optional:    true,
+								},
+							},
+						},
+					},
 				},
 			},
+			"targeting":           policyTargetingSchema(),
+			"disable_on_destroy":  disableOnDestroyAttribute(),
+			"deletion_protection": deletionProtectionAttribute("Destroying a policy immediately stops enforcing it for agents."),
 		},
 	}
 }
 
+func (r *MaskingPolicyResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema()
+}
+
+func (r *MaskingPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var targeting types.Object
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("targeting"), &targeting)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validatePolicyTargetingResults(ctx, targeting, &resp.Diagnostics)
+	validatePolicyTenants(ctx, req.Config, &resp.Diagnostics)
+	validateMaskingFormatting(ctx, req.Config, &resp.Diagnostics)
+}
+
+// validateMaskingFormatting checks the entity names and mask options of policy_configuration.formatting
+func validateMaskingFormatting(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) {
+	formattingPath := path.Root("policy_configuration").AtName("formatting")
+
+	var formatting types.Map
+	diags.Append(config.GetAttribute(ctx, formattingPath, &formatting)...)
+	if diags.HasError() || formatting.IsNull() || formatting.IsUnknown() {
+		return
+	}
+
+	for entity, value := range formatting.Elements() {
+		entityPath := formattingPath.AtMapKey(entity)
+		if _, ok := maskingEntityAPINames[entity]; !ok {
+			diags.AddAttributeError(entityPath, "Invalid Masking Entity", fmt.Sprintf("Unknown masking entity %q. Valid values are the entity attributes of policy_configuration, e.g. credit_card.", entity))
+			continue
+		}
+
+		format, ok := value.(types.Object)
+		if !ok || format.IsNull() || format.IsUnknown() {
+			continue
+		}
+		attrs := format.Attributes()
+
+		if maskChar, ok := attrs["mask_char"].(types.String); ok && !maskChar.IsNull() && !maskChar.IsUnknown() && len([]rune(maskChar.ValueString())) != 1 {
+			diags.AddAttributeError(entityPath.AtName("mask_char"), "Invalid Mask Character", fmt.Sprintf("mask_char must be a single character, got %q.", maskChar.ValueString()))
+		}
+		if visibleChars, ok := attrs["visible_chars"].(types.Int64); ok && !visibleChars.IsNull() && !visibleChars.IsUnknown() && visibleChars.ValueInt64() < 0 {
+			diags.AddAttributeError(entityPath.AtName("visible_chars"), "Invalid Visible Characters", fmt.Sprintf("visible_chars must not be negative, got %d.", visibleChars.ValueInt64()))
+		}
+	}
+}
+
 func (r *MaskingPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -218,6 +332,9 @@ func (r *MaskingPolicyResource) Configure(ctx context.Context, req resource.Conf
 }
 
 func (r *MaskingPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data MaskingPolicyResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -234,6 +351,12 @@ func (r *MaskingPolicyResource) Create(ctx context.Context, req resource.CreateR
 		}
 	}
 
+	// Convert tenant_id / tenant_ids
+	tenantID, tenantIDs := expandPolicyTenants(ctx, data.TenantID, data.TenantIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Convert internal_tool_ids
 	var toolIDs []string
 	resp.Diagnostics.Append(data.InternalToolIDs.ElementsAs(ctx, &toolIDs, false)...)
@@ -241,34 +364,50 @@ func (r *MaskingPolicyResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	// Convert tool_tags
+	toolTags := expandStringSet(ctx, data.ToolTags, &resp.Diagnostics)
+
 	// Parse policy configuration
 	policyConfig := r.extractPolicyConfig(ctx, data.PolicyConfiguration, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Build targeting from state
+	targeting := expandPolicyTargeting(ctx, data.Targeting, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	createReq := client.CreateMaskingPolicyRequest{
 		Name:                data.Name.ValueString(),
 		Description:         data.Description.ValueString(),
 		Enabled:             data.Enabled.ValueBool(),
 		AppIDs:              appIDs,
-		TenantID:            data.TenantID.ValueString(),
+		TenantID:            tenantID,
+		TenantIDs:           tenantIDs,
 		InternalToolIDs:     toolIDs,
+		ToolTags:            toolTags,
 		PolicyConfiguration: policyConfig,
+		Targeting:           targeting,
 	}
 
 	policy, err := r.client.CreateMaskingPolicy(ctx, createReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to create masking policy: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to create masking policy", err)
 		return
 	}
 
 	data.ID = types.StringValue(policy.ID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID)...)
 }
 
 func (r *MaskingPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data MaskingPolicyResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -277,13 +416,12 @@ func (r *MaskingPolicyResource) Read(ctx context.Context, req resource.ReadReque
 	}
 
 	policy, err := r.client.GetMaskingPolicy(ctx, data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to read masking policy: "+err.Error())
+	if errors.Is(err, client.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
 		return
 	}
-
-	if policy == nil {
-		resp.State.RemoveResource(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read masking policy", err)
 		return
 	}
 
@@ -303,7 +441,8 @@ func (r *MaskingPolicyResource) Read(ctx context.Context, req resource.ReadReque
 		data.AppIDs = types.ListNull(types.StringType)
 	}
 
-	if policy.TenantID != "" {
+	data.TenantIDs = flattenPolicyTenantIDs(ctx, policy, data.TenantID, data.TenantIDs)
+	if policy.TenantID != "" && data.TenantIDs.IsNull() {
 		data.TenantID = types.StringValue(policy.TenantID)
 	}
 
@@ -317,11 +456,22 @@ func (r *MaskingPolicyResource) Read(ctx context.Context, req resource.ReadReque
 	} else {
 		data.InternalToolIDs, _ = types.ListValue(types.StringType, []attr.Value{})
 	}
+	data.ToolTags = flattenOptionalStringSet(ctx, policy.ToolTags, data.ToolTags, &resp.Diagnostics)
+
+	data.PolicyConfiguration = r.flattenPolicyConfig(ctx, policy.PolicyConfiguration, data.PolicyConfiguration, &resp.Diagnostics)
+	data.Targeting = flattenPolicyTargeting(ctx, policy.Targeting, data.Targeting, &resp.Diagnostics)
+
+	data.DisableOnDestroy = flattenLocalBool(data.DisableOnDestroy)
+	data.DeletionProtection = flattenLocalBool(data.DeletionProtection)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID)...)
 }
 
 func (r *MaskingPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data MaskingPolicyResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -338,6 +488,12 @@ func (r *MaskingPolicyResource) Update(ctx context.Context, req resource.UpdateR
 		}
 	}
 
+	// Convert tenant_id / tenant_ids
+	tenantID, tenantIDs := expandPolicyTenantsUpdate(ctx, data.TenantID, data.TenantIDs, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Convert internal_tool_ids
 	var toolIDs []string
 	resp.Diagnostics.Append(data.InternalToolIDs.ElementsAs(ctx, &toolIDs, false)...)
@@ -345,33 +501,49 @@ func (r *MaskingPolicyResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	// Convert tool_tags
+	toolTags := expandStringSet(ctx, data.ToolTags, &resp.Diagnostics)
+
 	// Parse policy configuration
 	policyConfig := r.extractPolicyConfig(ctx, data.PolicyConfiguration, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Build targeting from state
+	targeting := expandPolicyTargeting(ctx, data.Targeting, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	enabled := data.Enabled.ValueBool()
 	updateReq := client.UpdateMaskingPolicyRequest{
 		Name:                data.Name.ValueString(),
 		Description:         data.Description.ValueString(),
 		Enabled:             &enabled,
 		AppIDs:              appIDs,
-		TenantID:            data.TenantID.ValueString(),
+		TenantID:            tenantID,
+		TenantIDs:           tenantIDs,
 		InternalToolIDs:     toolIDs,
+		ToolTags:            toolTags,
 		PolicyConfiguration: policyConfig,
+		Targeting:           targeting,
 	}
 
 	_, err := r.client.UpdateMaskingPolicy(ctx, data.ID.ValueString(), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to update masking policy: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to update masking policy", err)
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID)...)
 }
 
 func (r *MaskingPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data MaskingPolicyResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -379,15 +551,35 @@ func (r *MaskingPolicyResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
+	if checkDeletionProtection(data.DeletionProtection, "agentlink_masking_policy", data.Name.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	// Keep the policy, and its history and references, when Terraform stops managing it
+	if data.DisableOnDestroy.ValueBool() {
+		if err := r.client.SetPolicyEnabled(ctx, data.ID.ValueString(), "MASKING", false); err != nil {
+			addClientError(&resp.Diagnostics, "Unable to disable masking policy", err)
+		}
+		return
+	}
+
 	err := r.client.DeletePolicy(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to delete masking policy: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to delete masking policy", err)
 		return
 	}
 }
 
 func (r *MaskingPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importTypedPolicy(ctx, r.client, "agentlink_masking_policy", req, resp)
+}
+
+func (r *MaskingPolicyResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
+
+func (r *MaskingPolicyResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
 }
 
 func (r *MaskingPolicyResource) extractPolicyConfig(ctx context.Context, configObj types.Object, diags *diag.Diagnostics) *client.MaskingPolicyConfiguration {
@@ -406,7 +598,7 @@ func (r *MaskingPolicyResource) extractPolicyConfig(ctx context.Context, configO
 		return false
 	}
 
-	return &client.MaskingPolicyConfiguration{
+	config := &client.MaskingPolicyConfiguration{
 		CreditCard:      getBool("credit_card"),
 		EmailAddress:    getBool("email_address"),
 		PhoneNumber:     getBool("phone_number"),
@@ -423,4 +615,100 @@ func (r *MaskingPolicyResource) extractPolicyConfig(ctx context.Context, configO
 		CvvCvc:          getBool("cvv_cvc"),
 		Url:             getBool("url"),
 	}
+
+	if v, ok := attrs["formatting"]; ok {
+		if formatting, ok := v.(types.Map); ok && !formatting.IsNull() && !formatting.IsUnknown() {
+			var formats map[string]MaskingFormatModel
+			diags.Append(formatting.ElementsAs(ctx, &formats, false)...)
+			if diags.HasError() {
+				return nil
+			}
+
+			config.EntityFormats = make(map[string]client.MaskingEntityFormat, len(formats))
+			for entity, format := range formats {
+				// Entity names and mask options are checked in ValidateConfig
+				apiName, ok := maskingEntityAPINames[entity]
+				if !ok {
+					continue
+				}
+				entityFormat := client.MaskingEntityFormat{MaskChar: format.MaskChar.ValueString()}
+				if !format.VisibleChars.IsNull() && !format.VisibleChars.IsUnknown() {
+					visibleChars := int(format.VisibleChars.ValueInt64())
+					entityFormat.VisibleCharsCount = &visibleChars
+				}
+				config.EntityFormats[apiName] = entityFormat
+			}
+		}
+	}
+
+	return config
+}
+
+// flattenPolicyConfig converts a masking configuration response into the policy_configuration state
+// value. A missing configuration masks no entities. Formatting options the API returns as zero
+// values stay unset when they are unset in the current state.
+func (r *MaskingPolicyResource) flattenPolicyConfig(ctx context.Context, config *client.MaskingPolicyConfiguration, current types.Object, diags *diag.Diagnostics) types.Object {
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	configType := schemaResp.Schema.Attributes["policy_configuration"].GetType().(types.ObjectType)
+	formatType := configType.AttrTypes["formatting"].(types.MapType).ElemType.(types.ObjectType)
+
+	if config == nil {
+		config = &client.MaskingPolicyConfiguration{}
+	}
+
+	var currentFormats map[string]MaskingFormatModel
+	if !current.IsNull() && !current.IsUnknown() {
+		if formatting, ok := current.Attributes()["formatting"].(types.Map); ok && !formatting.IsNull() && !formatting.IsUnknown() {
+			diags.Append(formatting.ElementsAs(ctx, &currentFormats, false)...)
+		}
+	}
+
+	formatting := types.MapNull(formatType)
+	if len(config.EntityFormats) > 0 || currentFormats != nil {
+		formats := map[string]MaskingFormatModel{}
+		for entity, apiName := range maskingEntityAPINames {
+			format, ok := config.EntityFormats[apiName]
+			if !ok {
+				continue
+			}
+			previous := currentFormats[entity]
+			model := MaskingFormatModel{
+				MaskChar:     types.StringValue(format.MaskChar),
+				VisibleChars: types.Int64Null(),
+			}
+			if format.MaskChar == "" && (previous.MaskChar.IsNull() || previous.MaskChar.IsUnknown()) {
+				model.MaskChar = types.StringNull()
+			}
+			if format.VisibleCharsCount != nil && (*format.VisibleCharsCount != 0 || !previous.VisibleChars.IsNull() && !previous.VisibleChars.IsUnknown()) {
+				model.VisibleChars = types.Int64Value(int64(*format.VisibleCharsCount))
+			}
+			formats[entity] = model
+		}
+
+		var d diag.Diagnostics
+		formatting, d = types.MapValueFrom(ctx, formatType, formats)
+		diags.Append(d...)
+	}
+
+	obj, d := types.ObjectValueFrom(ctx, configType.AttrTypes, MaskingConfigModel{
+		CreditCard:      types.BoolValue(config.CreditCard),
+		EmailAddress:    types.BoolValue(config.EmailAddress),
+		PhoneNumber:     types.BoolValue(config.PhoneNumber),
+		IpAddress:       types.BoolValue(config.IpAddress),
+		UsSsn:           types.BoolValue(config.UsSsn),
+		UsDriverLicense: types.BoolValue(config.UsDriverLicense),
+		UsPassport:      types.BoolValue(config.UsPassport),
+		UsItin:          types.BoolValue(config.UsItin),
+		UsBankNumber:    types.BoolValue(config.UsBankNumber),
+		IbanCode:        types.BoolValue(config.IbanCode),
+		SwiftCode:       types.BoolValue(config.SwiftCode),
+		BitcoinAddress:  types.BoolValue(config.BitcoinAddress),
+		EthereumAddress: types.BoolValue(config.EthereumAddress),
+		CvvCvc:          types.BoolValue(config.CvvCvc),
+		Url:             types.BoolValue(config.Url),
+		Formatting:      formatting,
+	})
+	diags.Append(d...)
+	return obj
 }
@@ -2,8 +2,11 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -16,6 +19,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &McpConfigurationResource{}
 var _ resource.ResourceWithImportState = &McpConfigurationResource{}
+var _ resource.ResourceWithMoveState = &McpConfigurationResource{}
+var _ resource.ResourceWithUpgradeState = &McpConfigurationResource{}
 
 func NewMcpConfigurationResource() resource.Resource {
 	return &McpConfigurationResource{}
@@ -41,6 +46,7 @@ func (r *McpConfigurationResource) Metadata(ctx context.Context, req resource.Me
 func (r *McpConfigurationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages MCP (Model Context Protocol) configuration for an application.",
+		Version:     0,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The MCP configuration ID.",
@@ -88,6 +94,9 @@ func (r *McpConfigurationResource) Configure(ctx context.Context, req resource.C
 }
 
 func (r *McpConfigurationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data McpConfigurationResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -101,9 +110,9 @@ func (r *McpConfigurationResource) Create(ctx context.Context, req resource.Crea
 		APITimeout: int(data.APITimeout.ValueInt64()),
 	}
 
-	config, err := r.client.CreateOrUpdateMcpConfiguration(ctx, createReq)
+	config, err := r.client.UpdateMcpConfiguration(ctx, createReq, nil)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to create MCP configuration: "+err.Error())
+		addMcpConfigurationError(&resp.Diagnostics, "create", err)
 		return
 	}
 
@@ -116,6 +125,9 @@ func (r *McpConfigurationResource) Create(ctx context.Context, req resource.Crea
 }
 
 func (r *McpConfigurationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data McpConfigurationResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -124,13 +136,12 @@ func (r *McpConfigurationResource) Read(ctx context.Context, req resource.ReadRe
 	}
 
 	config, err := r.client.GetMcpConfiguration(ctx, data.ApplicationID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to read MCP configuration: "+err.Error())
+	if errors.Is(err, client.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
 		return
 	}
-
-	if config == nil {
-		resp.State.RemoveResource(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read MCP configuration", err)
 		return
 	}
 
@@ -143,6 +154,9 @@ func (r *McpConfigurationResource) Read(ctx context.Context, req resource.ReadRe
 }
 
 func (r *McpConfigurationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data McpConfigurationResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -156,9 +170,22 @@ func (r *McpConfigurationResource) Update(ctx context.Context, req resource.Upda
 		APITimeout: int(data.APITimeout.ValueInt64()),
 	}
 
-	config, err := r.client.CreateOrUpdateMcpConfiguration(ctx, updateReq)
+	var state McpConfigurationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The configuration last read by this workspace; a different current value means another
+	// workspace changed it since
+	expected := &client.McpConfiguration{
+		BaseURL:    state.BaseURL.ValueString(),
+		APITimeout: int(state.APITimeout.ValueInt64()),
+	}
+
+	config, err := r.client.UpdateMcpConfiguration(ctx, updateReq, expected)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to update MCP configuration: "+err.Error())
+		addMcpConfigurationError(&resp.Diagnostics, "update", err)
 		return
 	}
 
@@ -171,6 +198,9 @@ func (r *McpConfigurationResource) Update(ctx context.Context, req resource.Upda
 }
 
 func (r *McpConfigurationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	// MCP Configuration doesn't have a delete endpoint - it's tied to the application
 	// Just remove from state
 }
@@ -179,3 +209,29 @@ func (r *McpConfigurationResource) ImportState(ctx context.Context, req resource
 	// Import by application_id
 	resource.ImportStatePassthroughID(ctx, path.Root("application_id"), req, resp)
 }
+
+func (r *McpConfigurationResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
+
+func (r *McpConfigurationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+// addMcpConfigurationError reports a failed MCP configuration write, explaining concurrent changes
+func addMcpConfigurationError(diags *diag.Diagnostics, action string, err error) {
+	var conflict *client.McpConfigurationConflictError
+	if errors.As(err, &conflict) {
+		diags.AddAttributeError(
+			path.Root("application_id"),
+			"MCP Configuration Changed Concurrently",
+			fmt.Sprintf("Unable to %s MCP configuration because it was changed by someone else, e.g. another "+
+				"Terraform workspace managing the same application: %s. Run terraform plan again to review "+
+				"the current configuration, and make sure only one configuration manages the MCP "+
+				"configuration of an application.", action, err.Error()),
+		)
+		return
+	}
+
+	addClientError(diags, "Unable to "+action+" MCP configuration", err)
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// legacyProviderTypeName is the type name of the internal build this provider replaces. Its
+// resources were named frontegg_<type> and share their schema with agentlink_<type>.
+const legacyProviderTypeName = "frontegg"
+
+// legacyStateMovers lets moved blocks migrate a resource managed by the legacy frontegg
+// provider to the matching agentlink resource without recreating it.
+func legacyStateMovers(ctx context.Context, r resource.Resource) []resource.StateMover {
+	metadataResp := &resource.MetadataResponse{}
+	r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: legacyProviderTypeName}, metadataResp)
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	return []resource.StateMover{
+		{
+			SourceSchema: &schemaResp.Schema,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				// Leave moves from other providers or resource types to other movers
+				if req.SourceTypeName != metadataResp.TypeName || !isLegacyProviderAddress(req.SourceProviderAddress) {
+					return
+				}
+
+				if req.SourceState == nil {
+					resp.Diagnostics.AddError(
+						"Unable to Move Legacy Resource State",
+						"The state of "+req.SourceTypeName+" could not be read with the schema of this provider. "+
+							"Upgrade the legacy provider to its final release and refresh the state before moving it.",
+					)
+					return
+				}
+
+				// Attributes added since the legacy build are null and populated by the next refresh
+				resp.TargetState.Raw = req.SourceState.Raw
+			},
+		},
+	}
+}
+
+// isLegacyProviderAddress reports whether address refers to the legacy frontegg provider, e.g.
+// registry.terraform.io/frontegg/frontegg or a private mirror of it. States already rewritten by
+// terraform state replace-provider keep the legacy type names under the agentlink address.
+func isLegacyProviderAddress(address string) bool {
+	return strings.HasSuffix(address, "/"+legacyProviderTypeName) || strings.HasSuffix(address, "/agentlink")
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestLegacyStateMovers(t *testing.T) {
+	ctx := context.Background()
+	r := NewApplicationResource()
+
+	movers := legacyStateMovers(ctx, r)
+	if len(movers) != 1 || movers[0].SourceSchema == nil {
+		t.Fatalf("expected one state mover with a source schema, got %+v", movers)
+	}
+	sourceSchema := *movers[0].SourceSchema
+
+	// Legacy states may lack attributes added since and carry attributes removed since
+	rawState := tfprotov6.RawState{JSON: []byte(`{"id":"app-1","name":"Support","legacy_only":"x"}`)}
+	rawValue, err := rawState.UnmarshalWithOpts(sourceSchema.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{
+		ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error decoding legacy state: %v", err)
+	}
+	sourceState := &tfsdk.State{Schema: sourceSchema, Raw: rawValue}
+
+	move := func(req resource.MoveStateRequest) *resource.MoveStateResponse {
+		resp := &resource.MoveStateResponse{
+			TargetState: tfsdk.State{
+				Schema: sourceSchema,
+				Raw:    tftypes.NewValue(sourceSchema.Type().TerraformType(ctx), nil),
+			},
+		}
+		movers[0].StateMover(ctx, req, resp)
+		return resp
+	}
+
+	resp := move(resource.MoveStateRequest{
+		SourceProviderAddress: "registry.terraform.io/frontegg/frontegg",
+		SourceTypeName:        "frontegg_application",
+		SourceState:           sourceState,
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	resp = move(resource.MoveStateRequest{
+		SourceProviderAddress: "registry.terraform.io/frontegg/agentlink",
+		SourceTypeName:        "frontegg_application",
+		SourceState:           sourceState,
+	})
+	if resp.Diagnostics.HasError() || resp.TargetState.Raw.IsNull() {
+		t.Errorf("expected legacy type names to be moved after replace-provider, got %v", resp.Diagnostics)
+	}
+
+	var id, name types.String
+	resp.Diagnostics.Append(resp.TargetState.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(resp.TargetState.GetAttribute(ctx, path.Root("name"), &name)...)
+	if id.ValueString() != "app-1" || name.ValueString() != "Support" {
+		t.Errorf("expected the legacy state to be moved, got id %s and name %s", id, name)
+	}
+
+	for _, req := range []resource.MoveStateRequest{
+		{SourceProviderAddress: "registry.terraform.io/frontegg/frontegg", SourceTypeName: "frontegg_source", SourceState: sourceState},
+		{SourceProviderAddress: "registry.terraform.io/hashicorp/random", SourceTypeName: "frontegg_application", SourceState: sourceState},
+	} {
+		resp := move(req)
+		if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+			t.Errorf("expected %s from %s to be left to other movers", req.SourceTypeName, req.SourceProviderAddress)
+		}
+	}
+
+	resp = move(resource.MoveStateRequest{
+		SourceProviderAddress: "registry.terraform.io/frontegg/frontegg",
+		SourceTypeName:        "frontegg_application",
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error when the legacy state cannot be decoded")
+	}
+}
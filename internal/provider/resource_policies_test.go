@@ -2,9 +2,23 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/frontegg/terraform-provider-agentlink/clienttest"
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 // ============================================================================
@@ -28,7 +42,7 @@ func TestConditionalPolicyResourceHasExpectedSchema(t *testing.T) {
 	}
 
 	// Check optional attributes
-	optionalAttrs := []string{"description", "app_ids", "tenant_id", "targeting", "metadata"}
+	optionalAttrs := []string{"description", "app_ids", "tenant_id", "tenant_ids", "targeting", "metadata", "tool_tags"}
 	for _, attr := range optionalAttrs {
 		if _, ok := resp.Schema.Attributes[attr]; !ok {
 			t.Errorf("expected optional attribute '%s' in schema", attr)
@@ -83,7 +97,7 @@ func TestRbacPolicyResourceHasExpectedSchema(t *testing.T) {
 	}
 
 	// Check optional attributes
-	optionalAttrs := []string{"description", "app_ids", "tenant_id"}
+	optionalAttrs := []string{"description", "app_ids", "tenant_id", "tenant_ids", "tool_tags", "targeting"}
 	for _, attr := range optionalAttrs {
 		if _, ok := resp.Schema.Attributes[attr]; !ok {
 			t.Errorf("expected optional attribute '%s' in schema", attr)
@@ -133,7 +147,7 @@ func TestMaskingPolicyResourceHasExpectedSchema(t *testing.T) {
 	}
 
 	// Check optional attributes
-	optionalAttrs := []string{"description", "app_ids", "tenant_id"}
+	optionalAttrs := []string{"description", "app_ids", "tenant_id", "tenant_ids", "tool_tags", "targeting"}
 	for _, attr := range optionalAttrs {
 		if _, ok := resp.Schema.Attributes[attr]; !ok {
 			t.Errorf("expected optional attribute '%s' in schema", attr)
@@ -161,3 +175,766 @@ func TestMaskingPolicyResourceImplementsResource(t *testing.T) {
 	var _ = r
 	var _ resource.ResourceWithImportState = r.(*MaskingPolicyResource)
 }
+
+func TestMaskingPolicyConfigurationHasFormatting(t *testing.T) {
+	r := NewMaskingPolicyResource()
+
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	config, ok := resp.Schema.Attributes["policy_configuration"].(schema.SingleNestedAttribute)
+	if !ok {
+		t.Fatal("policy_configuration should be a SingleNestedAttribute")
+	}
+
+	formatting, ok := config.Attributes["formatting"].(schema.MapNestedAttribute)
+	if !ok {
+		t.Fatal("policy_configuration.formatting should be a MapNestedAttribute")
+	}
+
+	for _, attr := range []string{"mask_char", "visible_chars"} {
+		if _, ok := formatting.NestedObject.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in formatting", attr)
+		}
+	}
+
+	// Every boolean entity must have an API name so it can be formatted
+	for name, attr := range config.Attributes {
+		if _, isBool := attr.(schema.BoolAttribute); !isBool {
+			continue
+		}
+		if _, ok := maskingEntityAPINames[name]; !ok {
+			t.Errorf("entity '%s' has no API name mapping", name)
+		}
+	}
+}
+
+func TestMaskingPolicyValidateConfigFormatting(t *testing.T) {
+	ctx := context.Background()
+	r := NewMaskingPolicyResource().(*MaskingPolicyResource)
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	configType := schemaResp.Schema.Attributes["policy_configuration"].GetType().(types.ObjectType)
+	formatType := configType.AttrTypes["formatting"].(types.MapType).ElemType.(types.ObjectType)
+
+	validate := func(formats map[string]MaskingFormatModel) diag.Diagnostics {
+		formatting, diags := types.MapValueFrom(ctx, formatType, formats)
+		config, d := types.ObjectValueFrom(ctx, configType.AttrTypes, MaskingConfigModel{Formatting: formatting})
+		diags.Append(d...)
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		diags.Append(state.SetAttribute(ctx, path.Root("policy_configuration"), config)...)
+		if diags.HasError() {
+			t.Fatalf("failed to build config: %v", diags)
+		}
+
+		resp := &resource.ValidateConfigResponse{}
+		r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+		return resp.Diagnostics
+	}
+
+	valid := map[string]MaskingFormatModel{
+		"credit_card": {MaskChar: types.StringValue("#"), VisibleChars: types.Int64Value(0)},
+		"us_ssn":      {MaskChar: types.StringNull(), VisibleChars: types.Int64Value(4)},
+	}
+	if diags := validate(valid); diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+
+	for name, formats := range map[string]map[string]MaskingFormatModel{
+		"unknown entity":   {"passport": {MaskChar: types.StringValue("#"), VisibleChars: types.Int64Null()}},
+		"long mask_char":   {"credit_card": {MaskChar: types.StringValue("##"), VisibleChars: types.Int64Null()}},
+		"empty mask_char":  {"credit_card": {MaskChar: types.StringValue(""), VisibleChars: types.Int64Null()}},
+		"negative visible": {"credit_card": {MaskChar: types.StringNull(), VisibleChars: types.Int64Value(-1)}},
+	} {
+		if diags := validate(formats); !diags.HasError() {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestExpandPolicyTenants(t *testing.T) {
+	ctx := context.Background()
+	tenantIDs, _ := types.ListValue(types.StringType, []attr.Value{
+		types.StringValue("tenant-1"),
+		types.StringValue("tenant-2"),
+	})
+
+	// Singular tenant_id is passed through unchanged
+	var diags diag.Diagnostics
+	tenantID, ids := expandPolicyTenants(ctx, types.StringValue("tenant-1"), types.ListNull(types.StringType), &diags)
+	if diags.HasError() || tenantID != "tenant-1" || ids != nil {
+		t.Errorf("expected tenant_id passthrough without tenant IDs, got %q %v %v", tenantID, ids, diags)
+	}
+
+	// tenant_ids is expanded to a list
+	diags = diag.Diagnostics{}
+	tenantID, ids = expandPolicyTenants(ctx, types.StringNull(), tenantIDs, &diags)
+	if diags.HasError() || tenantID != "" || len(ids) != 2 {
+		t.Errorf("expected 2 tenant IDs, got %q %v %v", tenantID, ids, diags)
+	}
+}
+
+func TestPolicyValidateConfigTenants(t *testing.T) {
+	ctx := context.Background()
+	tenantIDs := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("tenant-1")})
+
+	for _, r := range []resource.ResourceWithValidateConfig{
+		NewConditionalPolicyResource().(*ConditionalPolicyResource),
+		NewRbacPolicyResource().(*RbacPolicyResource),
+		NewMaskingPolicyResource().(*MaskingPolicyResource),
+	} {
+		schemaResp := &resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+		validate := func(tenantID types.String, tenantIDs types.List) diag.Diagnostics {
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			diags := state.SetAttribute(ctx, path.Root("tenant_id"), tenantID)
+			diags.Append(state.SetAttribute(ctx, path.Root("tenant_ids"), tenantIDs)...)
+			if diags.HasError() {
+				t.Fatalf("failed to build config: %v", diags)
+			}
+
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+			return resp.Diagnostics
+		}
+
+		if diags := validate(types.StringNull(), tenantIDs); diags.HasError() {
+			t.Errorf("unexpected diagnostics: %v", diags)
+		}
+		if diags := validate(types.StringValue("tenant-1"), tenantIDs); !diags.HasError() {
+			t.Error("expected an error when both tenant_id and tenant_ids are set")
+		}
+	}
+}
+
+func TestFlattenPolicyTenantIDs(t *testing.T) {
+	ctx := context.Background()
+	configured := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("tenant-1")})
+	empty := types.ListValueMust(types.StringType, []attr.Value{})
+
+	// Tenants removed outside Terraform show up as drift
+	if value := flattenPolicyTenantIDs(ctx, &client.Policy{}, types.StringNull(), configured); !value.IsNull() {
+		t.Errorf("expected removed tenants to be null, got %v", value)
+	}
+	if value := flattenPolicyTenantIDs(ctx, &client.Policy{}, types.StringNull(), empty); !value.Equal(empty) {
+		t.Errorf("expected a configured empty list to be kept, got %v", value)
+	}
+
+	value := flattenPolicyTenantIDs(ctx, &client.Policy{TenantIDs: []string{"tenant-2"}}, types.StringNull(), configured)
+	if len(value.Elements()) != 1 || value.Elements()[0].(types.String).ValueString() != "tenant-2" {
+		t.Errorf("expected the tenants of the API, got %v", value)
+	}
+
+	// Policies managed through tenant_id keep tenant_ids unset
+	if value := flattenPolicyTenantIDs(ctx, &client.Policy{TenantID: "tenant-1", TenantIDs: []string{"tenant-1"}}, types.StringValue("tenant-1"), types.ListNull(types.StringType)); !value.IsNull() {
+		t.Errorf("expected tenant_ids to stay null with tenant_id, got %v", value)
+	}
+}
+
+func TestExpandPolicyTargetingWithElse(t *testing.T) {
+	ctx := context.Background()
+	value, _ := types.MapValue(types.StringType, map[string]attr.Value{
+		"list": types.StringValue("admin@example.com"),
+	})
+
+	model := PolicyTargetingModel{
+		If: &PolicyIfModel{
+			Conditions: []PolicyConditionModel{{
+				Attribute: types.StringValue("user.email"),
+				Negate:    types.BoolValue(false),
+				Op:        types.StringValue("in_list"),
+				Value:     value,
+			}},
+		},
+		Then: &PolicyResultModel{
+			Result:         types.StringValue("ALLOW"),
+			ApprovalFlowID: types.StringNull(),
+		},
+		Else: &PolicyResultModel{
+			Result:         types.StringValue("APPROVAL_REQUIRED"),
+			ApprovalFlowID: types.StringValue("flow-1"),
+		},
+	}
+
+	objectType := policyTargetingSchema().GetType().(types.ObjectType)
+	obj, diags := types.ObjectValueFrom(ctx, objectType.AttrTypes, model)
+	if diags.HasError() {
+		t.Fatalf("failed to build targeting object: %v", diags)
+	}
+
+	targeting := expandPolicyTargeting(ctx, obj, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if targeting == nil {
+		t.Fatal("expected targeting, got nil")
+	}
+	if len(targeting.If.Conditions) != 1 || targeting.If.Conditions[0].Op != "in_list" {
+		t.Errorf("unexpected conditions: %+v", targeting.If.Conditions)
+	}
+	if targeting.Then.Result != "ALLOW" {
+		t.Errorf("expected then result ALLOW, got %s", targeting.Then.Result)
+	}
+	if targeting.Else == nil || targeting.Else.Result != "APPROVAL_REQUIRED" || targeting.Else.ApprovalFlowID != "flow-1" {
+		t.Errorf("unexpected else block: %+v", targeting.Else)
+	}
+
+	// A null targeting object produces no targeting
+	if expandPolicyTargeting(ctx, types.ObjectNull(objectType.AttrTypes), &diags) != nil {
+		t.Error("expected nil targeting for null object")
+	}
+}
+
+func TestFlattenPolicyTargeting(t *testing.T) {
+	ctx := context.Background()
+	objectType := policyTargetingSchema().GetType().(types.ObjectType)
+
+	current, diags := types.ObjectValueFrom(ctx, objectType.AttrTypes, PolicyTargetingModel{
+		If: &PolicyIfModel{Conditions: []PolicyConditionModel{{
+			Attribute: types.StringValue("user.age"),
+			Negate:    types.BoolValue(false),
+			Op:        types.StringValue("gte"),
+			Value:     types.MapValueMust(types.StringType, map[string]attr.Value{"number": types.StringValue("18.0")}),
+		}}},
+		Then: &PolicyResultModel{Result: types.StringValue("ALLOW"), ApprovalFlowID: types.StringNull()},
+	})
+	if diags.HasError() {
+		t.Fatalf("failed to build targeting object: %v", diags)
+	}
+
+	targeting := &client.PolicyTargeting{
+		If: client.PolicyIfBlock{Conditions: []client.PolicyCondition{
+			{Attribute: "user.age", Op: "gte", Value: map[string]interface{}{"number": float64(18)}},
+			{Attribute: "user.verified", Negate: true, Op: "is", Value: map[string]interface{}{"boolean": true}},
+		}},
+		Then: client.PolicyThenBlock{Result: "ALLOW"},
+		Else: &client.PolicyThenBlock{Result: "APPROVAL_REQUIRED", ApprovalFlowID: "flow-1"},
+	}
+
+	obj := flattenPolicyTargeting(ctx, targeting, current, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var model PolicyTargetingModel
+	diags.Append(obj.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(model.If.Conditions) != 2 {
+		t.Fatalf("expected 2 conditions, got %+v", model.If.Conditions)
+	}
+	if value := model.If.Conditions[0].Value.Elements()["number"]; value != types.StringValue("18.0") {
+		t.Errorf("expected the configured number to be kept, got %s", value)
+	}
+	if value := model.If.Conditions[1].Value.Elements()["boolean"]; value != types.StringValue("true") || !model.If.Conditions[1].Negate.ValueBool() {
+		t.Errorf("unexpected second condition: %+v", model.If.Conditions[1])
+	}
+	if !model.Then.ApprovalFlowID.IsNull() {
+		t.Errorf("expected then approval_flow_id to stay unset, got %s", model.Then.ApprovalFlowID)
+	}
+	if model.Else == nil || model.Else.ApprovalFlowID.ValueString() != "flow-1" {
+		t.Errorf("unexpected else block: %+v", model.Else)
+	}
+
+	// Policies without targeting, including an empty targeting object, have no targeting
+	if !flattenPolicyTargeting(ctx, nil, current, &diags).IsNull() {
+		t.Error("expected null targeting for a policy without targeting")
+	}
+	if !flattenPolicyTargeting(ctx, &client.PolicyTargeting{}, current, &diags).IsNull() {
+		t.Error("expected null targeting for an empty targeting object")
+	}
+}
+
+func TestConditionalPolicyReadSetsTargeting(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	id := server.Put(clienttest.Policies, clienttest.Object{
+		"name":    "Refunds",
+		"type":    "CONDITIONAL",
+		"enabled": true,
+		"targeting": map[string]interface{}{
+			"if": map[string]interface{}{"conditions": []interface{}{
+				map[string]interface{}{"attribute": "user.email", "negate": false, "op": "ends_with", "value": map[string]interface{}{"list": "@example.com"}},
+			}},
+			"then": map[string]interface{}{"result": "DENY"},
+		},
+	})
+
+	r := &ConditionalPolicyResource{client: client.NewClient(server.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	// An imported policy has no targeting in state yet
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := state.SetAttribute(ctx, path.Root("id"), id)
+	if diags.HasError() {
+		t.Fatalf("failed to build state: %v", diags)
+	}
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var targeting types.Object
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("targeting"), &targeting)...)
+	targetingModel := expandPolicyTargeting(ctx, targeting, &resp.Diagnostics)
+	if targetingModel == nil || len(targetingModel.If.Conditions) != 1 || targetingModel.Then.Result != "DENY" {
+		t.Errorf("expected the targeting to be read into state, got %s", targeting)
+	}
+}
+
+func TestConditionalPolicyUpdateRemovesTargeting(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	id := server.Put(clienttest.Policies, clienttest.Object{
+		"name":    "Refunds",
+		"type":    "CONDITIONAL",
+		"enabled": true,
+		"targeting": map[string]interface{}{
+			"if":   map[string]interface{}{"conditions": []interface{}{}},
+			"then": map[string]interface{}{"result": "DENY"},
+		},
+	})
+
+	r := &ConditionalPolicyResource{client: client.NewClient(server.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.SetAttribute(ctx, path.Root("id"), id); diags.HasError() {
+		t.Fatalf("failed to build state: %v", diags)
+	}
+	readResp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+	}
+
+	// The targeting block is removed from the configuration
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: readResp.State.Raw.Copy()}
+	if diags := plan.SetAttribute(ctx, path.Root("targeting"), types.ObjectNull(policyTargetingSchema().GetType().(types.ObjectType).AttrTypes)); diags.HasError() {
+		t.Fatalf("failed to build plan: %v", diags)
+	}
+
+	updateResp := &resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	if targeting := server.Get(clienttest.Policies, id)["targeting"]; targeting != nil {
+		t.Errorf("expected the targeting to be cleared on the server, got %v", targeting)
+	}
+}
+
+func TestConditionalPolicyUpdateRemovesTenants(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		tenants   clienttest.Object
+		attribute string
+		null      attr.Value
+		cleared   string
+		empty     interface{}
+		omitted   string
+	}{
+		{"tenant_ids", clienttest.Object{"tenantIds": []interface{}{"tenant-1", "tenant-2"}}, "tenant_ids", types.ListNull(types.StringType), "tenantIds", []interface{}{}, "tenantId"},
+		{"tenant_id", clienttest.Object{"tenantId": "tenant-1"}, "tenant_id", types.StringNull(), "tenantId", "", "tenantIds"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			server := clienttest.NewServer()
+			defer server.Close()
+
+			policy := clienttest.Object{"name": "Refunds", "type": "CONDITIONAL", "enabled": true}
+			for k, v := range tc.tenants {
+				policy[k] = v
+			}
+			id := server.Put(clienttest.Policies, policy)
+
+			r := &ConditionalPolicyResource{client: client.NewClient(server.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)}
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			if diags := state.SetAttribute(ctx, path.Root("id"), id); diags.HasError() {
+				t.Fatalf("failed to build state: %v", diags)
+			}
+			readResp := &resource.ReadResponse{State: state}
+			r.Read(ctx, resource.ReadRequest{State: state}, readResp)
+			if readResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+			}
+
+			// The tenant attribute is removed from the configuration
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: readResp.State.Raw.Copy()}
+			if diags := plan.SetAttribute(ctx, path.Root(tc.attribute), tc.null); diags.HasError() {
+				t.Fatalf("failed to build plan: %v", diags)
+			}
+
+			before := len(server.Requests())
+			updateResp := &resource.UpdateResponse{State: readResp.State}
+			r.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, updateResp)
+			if updateResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", updateResp.Diagnostics)
+			}
+
+			var body map[string]interface{}
+			for _, request := range server.Requests()[before:] {
+				if request.Method == http.MethodPatch {
+					_ = json.Unmarshal(request.Body, &body)
+				}
+			}
+			if value, ok := body[tc.cleared]; !ok || !reflect.DeepEqual(value, tc.empty) {
+				t.Errorf("expected %s to be sent empty, got %v", tc.cleared, body)
+			}
+			if _, ok := body[tc.omitted]; ok {
+				t.Errorf("expected %s to be left out, got %v", tc.omitted, body)
+			}
+		})
+	}
+}
+
+func TestConditionalPolicyValidateConfigRequiresApprovalFlow(t *testing.T) {
+	ctx := context.Background()
+	r := NewConditionalPolicyResource().(*ConditionalPolicyResource)
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	objectType := policyTargetingSchema().GetType().(types.ObjectType)
+
+	validate := func(then, otherwise *PolicyResultModel) diag.Diagnostics {
+		targeting, diags := types.ObjectValueFrom(ctx, objectType.AttrTypes, PolicyTargetingModel{
+			If:   &PolicyIfModel{Conditions: []PolicyConditionModel{}},
+			Then: then,
+			Else: otherwise,
+		})
+		if diags.HasError() {
+			t.Fatalf("failed to build targeting object: %v", diags)
+		}
+
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		diags = state.Set(ctx, &ConditionalPolicyResourceModel{
+			ID:                 types.StringUnknown(),
+			Name:               types.StringValue("approvals"),
+			Description:        types.StringNull(),
+			Enabled:            types.BoolValue(true),
+			AppIDs:             types.ListNull(types.StringType),
+			TenantID:           types.StringNull(),
+			TenantIDs:          types.ListNull(types.StringType),
+			InternalToolIDs:    types.ListValueMust(types.StringType, []attr.Value{}),
+			ToolTags:           types.SetNull(types.StringType),
+			Targeting:          targeting,
+			Metadata:           types.MapNull(types.StringType),
+			DisableOnDestroy:   types.BoolNull(),
+			DeletionProtection: types.BoolNull(),
+		})
+		if diags.HasError() {
+			t.Fatalf("failed to build config: %v", diags)
+		}
+
+		resp := &resource.ValidateConfigResponse{}
+		r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+		return resp.Diagnostics
+	}
+
+	allow := &PolicyResultModel{Result: types.StringValue("ALLOW"), ApprovalFlowID: types.StringNull()}
+	missing := &PolicyResultModel{Result: types.StringValue("APPROVAL_REQUIRED"), ApprovalFlowID: types.StringNull()}
+	pending := &PolicyResultModel{Result: types.StringValue("APPROVAL_REQUIRED"), ApprovalFlowID: types.StringUnknown()}
+
+	if diags := validate(allow, nil); diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+	if diags := validate(pending, allow); diags.HasError() {
+		t.Errorf("expected an unknown approval flow to be accepted, got %v", diags)
+	}
+
+	diags := validate(allow, missing)
+	if len(diags) != 1 {
+		t.Fatalf("expected one error, got %v", diags)
+	}
+	expectedPath := path.Root("targeting").AtName("else").AtName("approval_flow_id")
+	if withPath, ok := diags[0].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(expectedPath) {
+		t.Errorf("expected the error on %s, got %v", expectedPath, diags[0])
+	}
+}
+
+func TestDeletionProtection(t *testing.T) {
+	if !flattenLocalBool(types.BoolNull()).Equal(types.BoolValue(false)) {
+		t.Error("expected imported resources to be unprotected")
+	}
+	if !flattenLocalBool(types.BoolValue(true)).ValueBool() {
+		t.Error("expected deletion_protection to be kept")
+	}
+
+	var diags diag.Diagnostics
+	if checkDeletionProtection(types.BoolValue(false), "agentlink_rbac_policy", "Admins only", &diags) || diags.HasError() {
+		t.Errorf("expected unprotected policies to be deletable, got %v", diags)
+	}
+	if !checkDeletionProtection(types.BoolValue(true), "agentlink_rbac_policy", "Admins only", &diags) || !diags.HasError() {
+		t.Error("expected protected policies to be blocked")
+	}
+}
+
+func TestRbacPolicyDisableOnDestroy(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	id := server.Put(clienttest.Policies, clienttest.Object{"type": "RBAC_ROLES", "name": "Admins only", "enabled": true})
+
+	r := &RbacPolicyResource{client: client.NewClient(server.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := state.Set(ctx, &RbacPolicyResourceModel{
+		ID:                 types.StringValue(id),
+		Name:               types.StringValue("Admins only"),
+		Type:               types.StringValue("RBAC_ROLES"),
+		AppIDs:             types.ListNull(types.StringType),
+		TenantIDs:          types.ListNull(types.StringType),
+		Keys:               types.ListNull(types.StringType),
+		InternalToolIDs:    types.ListNull(types.StringType),
+		ToolTags:           types.SetNull(types.StringType),
+		Targeting:          types.ObjectNull(policyTargetingSchema().GetType().(types.ObjectType).AttrTypes),
+		DisableOnDestroy:   types.BoolValue(true),
+		DeletionProtection: types.BoolValue(false),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	policy := server.Get(clienttest.Policies, id)
+	if policy == nil {
+		t.Fatal("expected the policy to be kept")
+	}
+	if policy["enabled"] != false {
+		t.Errorf("expected the policy to be disabled, got %v", policy["enabled"])
+	}
+}
+
+func TestMaskingPolicyCreateSendsTargeting(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	r := &MaskingPolicyResource{client: client.NewClient(server.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	configType := schemaResp.Schema.Attributes["policy_configuration"].GetType().(types.ObjectType)
+	formattingType := configType.AttrTypes["formatting"].(types.MapType)
+	policyConfig, diags := types.ObjectValueFrom(ctx, configType.AttrTypes, MaskingConfigModel{
+		EmailAddress: types.BoolValue(true),
+		Formatting:   types.MapNull(formattingType.ElemType),
+	})
+	if diags.HasError() {
+		t.Fatalf("failed to build policy configuration: %v", diags)
+	}
+
+	targetingType := policyTargetingSchema().GetType().(types.ObjectType)
+	targeting, diags := types.ObjectValueFrom(ctx, targetingType.AttrTypes, PolicyTargetingModel{
+		If: &PolicyIfModel{Conditions: []PolicyConditionModel{{
+			Attribute: types.StringValue("user.email"),
+			Negate:    types.BoolValue(true),
+			Op:        types.StringValue("ends_with"),
+			Value:     types.MapValueMust(types.StringType, map[string]attr.Value{"list": types.StringValue("@example.com")}),
+		}}},
+		Then: &PolicyResultModel{Result: types.StringValue("ALLOW"), ApprovalFlowID: types.StringNull()},
+	})
+	if diags.HasError() {
+		t.Fatalf("failed to build targeting object: %v", diags)
+	}
+
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags = plan.Set(ctx, &MaskingPolicyResourceModel{
+		ID:                  types.StringUnknown(),
+		Name:                types.StringValue("External PII"),
+		Description:         types.StringNull(),
+		Enabled:             types.BoolValue(true),
+		AppIDs:              types.ListNull(types.StringType),
+		TenantID:            types.StringNull(),
+		TenantIDs:           types.ListNull(types.StringType),
+		InternalToolIDs:     types.ListValueMust(types.StringType, []attr.Value{}),
+		ToolTags:            types.SetNull(types.StringType),
+		PolicyConfiguration: policyConfig,
+		Targeting:           targeting,
+		DisableOnDestroy:    types.BoolValue(false),
+		DeletionProtection:  types.BoolValue(false),
+	})
+	if diags.HasError() {
+		t.Fatalf("failed to build plan: %v", diags)
+	}
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: plan.Raw}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	policies := server.List(clienttest.Policies)
+	if len(policies) != 1 {
+		t.Fatalf("expected one policy, got %v", policies)
+	}
+	stored, _ := policies[0]["targeting"].(map[string]interface{})
+	conditions, _ := stored["if"].(map[string]interface{})["conditions"].([]interface{})
+	if len(conditions) != 1 || conditions[0].(map[string]interface{})["op"] != "ends_with" {
+		t.Errorf("expected the targeting conditions to be sent, got %v", policies[0]["targeting"])
+	}
+}
+
+func TestMaskingPolicyReadSetsPolicyConfiguration(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	id := server.Put(clienttest.Policies, clienttest.Object{
+		"name":    "PII",
+		"type":    "MASKING",
+		"enabled": true,
+		"policyConfiguration": map[string]interface{}{
+			"creditCard":   true,
+			"emailAddress": true,
+			"entityFormats": map[string]interface{}{
+				"creditCard": map[string]interface{}{"visibleCharsCount": 4},
+			},
+		},
+	})
+
+	r := &MaskingPolicyResource{client: client.NewClient(server.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	// An imported policy has no policy configuration in state yet
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := state.SetAttribute(ctx, path.Root("id"), id)
+	if diags.HasError() {
+		t.Fatalf("failed to build state: %v", diags)
+	}
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var config MaskingConfigModel
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("policy_configuration"), &config)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if !config.CreditCard.ValueBool() || !config.EmailAddress.ValueBool() || config.PhoneNumber.ValueBool() {
+		t.Errorf("unexpected masked entities: %+v", config)
+	}
+
+	var formats map[string]MaskingFormatModel
+	resp.Diagnostics.Append(config.Formatting.ElementsAs(ctx, &formats, false)...)
+	format, ok := formats["credit_card"]
+	if !ok || format.VisibleChars.ValueInt64() != 4 || !format.MaskChar.IsNull() {
+		t.Errorf("unexpected formatting: %v", formats)
+	}
+
+	var targeting types.Object
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("targeting"), &targeting)...)
+	if !targeting.IsNull() {
+		t.Errorf("expected targeting to stay unset, got %s", targeting)
+	}
+}
+
+func TestMaskingPolicyFlattenPolicyConfigWithoutConfiguration(t *testing.T) {
+	ctx := context.Background()
+	r := &MaskingPolicyResource{}
+
+	var diags diag.Diagnostics
+	obj := r.flattenPolicyConfig(ctx, nil, types.ObjectNull(map[string]attr.Type{}), &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var config MaskingConfigModel
+	diags.Append(obj.As(ctx, &config, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if config.CreditCard.ValueBool() || config.Url.IsNull() || !config.Formatting.IsNull() {
+		t.Errorf("expected no masked entities and no formatting, got %+v", config)
+	}
+}
+
+func TestPolicyImportChecksPolicyType(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	rbacID := server.Put(clienttest.Policies, clienttest.Object{"type": "RBAC_ROLES", "name": "Admins"})
+	maskingID := server.Put(clienttest.Policies, clienttest.Object{"type": "MASKING", "name": "PII"})
+
+	c := client.NewClient(server.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)
+	importPolicy := func(r resource.ResourceWithImportState, id string) diag.Diagnostics {
+		schemaResp := &resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+		resp := &resource.ImportStateResponse{
+			State: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			},
+		}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
+		return resp.Diagnostics
+	}
+
+	if diags := importPolicy(&MaskingPolicyResource{client: c}, maskingID); diags.HasError() {
+		t.Errorf("unexpected diagnostics importing a masking policy: %v", diags)
+	}
+	if diags := importPolicy(&RbacPolicyResource{client: c}, rbacID); diags.HasError() {
+		t.Errorf("unexpected diagnostics importing an RBAC policy: %v", diags)
+	}
+
+	diags := importPolicy(&MaskingPolicyResource{client: c}, rbacID)
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Policy Type Mismatch" {
+		t.Fatalf("expected a type mismatch error, got %v", diags)
+	}
+	if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, "agentlink_rbac_policy") {
+		t.Errorf("expected the error to name agentlink_rbac_policy, got %q", detail)
+	}
+
+	diags = importPolicy(&RbacPolicyResource{client: c}, "missing")
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Policy Not Found" {
+		t.Errorf("expected a not found error, got %v", diags)
+	}
+}
@@ -0,0 +1,318 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PolicyKillSwitchResource{}
+var _ resource.ResourceWithValidateConfig = &PolicyKillSwitchResource{}
+var _ resource.ResourceWithMoveState = &PolicyKillSwitchResource{}
+var _ resource.ResourceWithUpgradeState = &PolicyKillSwitchResource{}
+
+func NewPolicyKillSwitchResource() resource.Resource {
+	return &PolicyKillSwitchResource{}
+}
+
+// PolicyKillSwitchResource defines the resource implementation.
+type PolicyKillSwitchResource struct {
+	client *client.Client
+}
+
+// PolicyKillSwitchResourceModel describes the resource data model.
+type PolicyKillSwitchResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	ApplicationID     types.String `tfsdk:"application_id"`
+	PolicyIDs         types.Set    `tfsdk:"policy_ids"`
+	Engaged           types.Bool   `tfsdk:"engaged"`
+	DisabledPolicyIDs types.Set    `tfsdk:"disabled_policy_ids"`
+}
+
+func (r *PolicyKillSwitchResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_kill_switch"
+}
+
+func (r *PolicyKillSwitchResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Disables a set of policies, or all policies applying to an application, in one operation, e.g. to pause agents in an emergency. Releasing the switch or destroying the resource re-enables only the policies the switch disabled.",
+		Version:     0,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The application ID, or the comma-separated policy IDs when policy_ids is set.",
+				Computed:    true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "The ID of the application whose policies are disabled. Conflicts with policy_ids.",
+				Optional:    true,
+			},
+			"policy_ids": schema.SetAttribute{
+				Description: "The IDs of the policies to disable. Any policy kind is supported. Conflicts with application_id.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"engaged": schema.BoolAttribute{
+				Description: "Whether the policies are disabled. Set to false to re-enable them while keeping the switch in configuration. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"disabled_policy_ids": schema.SetAttribute{
+				Description: "The IDs of the policies disabled by the switch. Policies that were already disabled are not included and stay disabled when the switch is released.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *PolicyKillSwitchResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data PolicyKillSwitchResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ApplicationID.IsUnknown() || data.PolicyIDs.IsUnknown() {
+		return
+	}
+
+	if data.ApplicationID.IsNull() == data.PolicyIDs.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("application_id"),
+			"Invalid Kill Switch Configuration",
+			"Exactly one of application_id and policy_ids must be set.",
+		)
+	}
+}
+
+func (r *PolicyKillSwitchResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PolicyKillSwitchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data PolicyKillSwitchResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.DisabledPolicyIDs = types.SetValueMust(types.StringType, nil)
+	r.apply(ctx, &data, &resp.Diagnostics)
+	data.ID = types.StringValue(policyKillSwitchID(ctx, data, &resp.Diagnostics))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PolicyKillSwitchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data PolicyKillSwitchResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policies, err := r.client.ListPolicies(ctx, client.PolicyFilters{})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list policies", err)
+		return
+	}
+
+	// Deleted policies can no longer be re-enabled
+	var disabled []string
+	for _, id := range expandStringSet(ctx, data.DisabledPolicyIDs, &resp.Diagnostics) {
+		if slices.ContainsFunc(policies, func(p client.Policy) bool { return p.ID == id }) {
+			disabled = append(disabled, id)
+		}
+	}
+	data.DisabledPolicyIDs = stringSet(ctx, disabled, &resp.Diagnostics)
+
+	// A targeted policy enabled outside Terraform, or added to the application later, releases the
+	// switch in state so the next apply disables it again
+	if data.Engaged.ValueBool() {
+		for _, policy := range killSwitchTargets(ctx, policies, data, &resp.Diagnostics) {
+			if policy.Enabled {
+				data.Engaged = types.BoolValue(false)
+				break
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PolicyKillSwitchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data, state PolicyKillSwitchResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.DisabledPolicyIDs = state.DisabledPolicyIDs
+	r.apply(ctx, &data, &resp.Diagnostics)
+	data.ID = types.StringValue(policyKillSwitchID(ctx, data, &resp.Diagnostics))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PolicyKillSwitchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data PolicyKillSwitchResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policies, err := r.client.ListPolicies(ctx, client.PolicyFilters{})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list policies", err)
+		return
+	}
+
+	for _, id := range expandStringSet(ctx, data.DisabledPolicyIDs, &resp.Diagnostics) {
+		// Deleted policies can no longer be re-enabled
+		i := slices.IndexFunc(policies, func(p client.Policy) bool { return p.ID == id })
+		if i < 0 {
+			continue
+		}
+		err := r.client.SetPolicyEnabled(ctx, id, policies[i].Type, true)
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			addClientError(&resp.Diagnostics, "Unable to re-enable policy "+id, err)
+		}
+	}
+}
+
+func (r *PolicyKillSwitchResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
+
+func (r *PolicyKillSwitchResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+// apply disables the targeted policies when the switch is engaged and re-enables the policies it
+// disabled that are no longer targeted. DisabledPolicyIDs is kept up to date after each change, so
+// a partial failure still records every policy the switch has to re-enable.
+func (r *PolicyKillSwitchResource) apply(ctx context.Context, data *PolicyKillSwitchResourceModel, diags *diag.Diagnostics) {
+	policies, err := r.client.ListPolicies(ctx, client.PolicyFilters{})
+	if err != nil {
+		addClientError(diags, "Unable to list policies", err)
+		return
+	}
+
+	var targets []client.Policy
+	if data.Engaged.ValueBool() {
+		targets = killSwitchTargets(ctx, policies, *data, diags)
+		if !data.PolicyIDs.IsNull() {
+			for _, id := range expandStringSet(ctx, data.PolicyIDs, diags) {
+				if !slices.ContainsFunc(targets, func(p client.Policy) bool { return p.ID == id }) {
+					diags.AddAttributeError(path.Root("policy_ids"), "Policy Not Found", "No policy with ID "+id+" exists.")
+				}
+			}
+		}
+		if diags.HasError() {
+			return
+		}
+	}
+
+	disabled := expandStringSet(ctx, data.DisabledPolicyIDs, diags)
+	for _, id := range append([]string{}, disabled...) {
+		if slices.ContainsFunc(targets, func(p client.Policy) bool { return p.ID == id }) {
+			continue
+		}
+		// Deleted policies can no longer be re-enabled
+		if i := slices.IndexFunc(policies, func(p client.Policy) bool { return p.ID == id }); i >= 0 {
+			err := r.client.SetPolicyEnabled(ctx, id, policies[i].Type, true)
+			if err != nil && !errors.Is(err, client.ErrNotFound) {
+				addClientError(diags, "Unable to re-enable policy "+id, err)
+				continue
+			}
+		}
+		disabled = subtractStrings(disabled, []string{id})
+	}
+
+	for _, policy := range targets {
+		if !policy.Enabled {
+			continue
+		}
+		if err := r.client.SetPolicyEnabled(ctx, policy.ID, policy.Type, false); err != nil {
+			addClientError(diags, "Unable to disable policy "+policy.ID, err)
+			continue
+		}
+		disabled = append(disabled, policy.ID)
+	}
+
+	data.DisabledPolicyIDs = stringSet(ctx, disabled, diags)
+}
+
+// killSwitchTargets returns the policies targeted by a kill switch: the listed policies, or every
+// policy applying to the application
+func killSwitchTargets(ctx context.Context, policies []client.Policy, data PolicyKillSwitchResourceModel, diags *diag.Diagnostics) []client.Policy {
+	policyIDs := expandStringSet(ctx, data.PolicyIDs, diags)
+	appID := data.ApplicationID.ValueString()
+
+	var targets []client.Policy
+	for _, policy := range policies {
+		if data.PolicyIDs.IsNull() && slices.Contains(policy.AppIDs, appID) || slices.Contains(policyIDs, policy.ID) {
+			targets = append(targets, policy)
+		}
+	}
+	return targets
+}
+
+// policyKillSwitchID returns the application ID, or the sorted policy IDs of a kill switch
+func policyKillSwitchID(ctx context.Context, data PolicyKillSwitchResourceModel, diags *diag.Diagnostics) string {
+	if !data.ApplicationID.IsNull() {
+		return data.ApplicationID.ValueString()
+	}
+	policyIDs := expandStringSet(ctx, data.PolicyIDs, diags)
+	sort.Strings(policyIDs)
+	return strings.Join(policyIDs, ",")
+}
+
+// stringSet converts values into a set attribute value
+func stringSet(ctx context.Context, values []string, diags *diag.Diagnostics) types.Set {
+	set, d := types.SetValueFrom(ctx, types.StringType, nonNilStrings(values))
+	diags.Append(d...)
+	return set
+}
@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/clienttest"
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPolicyKillSwitchResourceHasExpectedSchema(t *testing.T) {
+	r := NewPolicyKillSwitchResource()
+
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	for _, attr := range []string{"id", "application_id", "policy_ids", "engaged", "disabled_policy_ids"} {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in schema", attr)
+		}
+	}
+}
+
+func TestPolicyKillSwitchResourceMetadata(t *testing.T) {
+	r := NewPolicyKillSwitchResource()
+
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_policy_kill_switch"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func TestPolicyKillSwitchValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := &PolicyKillSwitchResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	validate := func(appID types.String, policyIDs types.Set) diag.Diagnostics {
+		config := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		diags := config.Set(ctx, &PolicyKillSwitchResourceModel{
+			ID:                types.StringNull(),
+			ApplicationID:     appID,
+			PolicyIDs:         policyIDs,
+			Engaged:           types.BoolNull(),
+			DisabledPolicyIDs: types.SetNull(types.StringType),
+		})
+		if diags.HasError() {
+			t.Fatalf("failed to build config: %v", diags)
+		}
+
+		resp := &resource.ValidateConfigResponse{}
+		r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, resp)
+		return resp.Diagnostics
+	}
+
+	policyIDs := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("policy-1")})
+
+	if diags := validate(types.StringValue("app-1"), types.SetNull(types.StringType)); diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+	if diags := validate(types.StringNull(), policyIDs); diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+	if diags := validate(types.StringValue("app-1"), policyIDs); len(diags) != 1 {
+		t.Errorf("expected one error when both are set, got %v", diags)
+	}
+	if diags := validate(types.StringNull(), types.SetNull(types.StringType)); len(diags) != 1 {
+		t.Errorf("expected one error when neither is set, got %v", diags)
+	}
+}
+
+func TestKillSwitchTargets(t *testing.T) {
+	ctx := context.Background()
+	policies := []client.Policy{
+		{ID: "policy-1", AppIDs: []string{"app-1"}, Enabled: true},
+		{ID: "policy-2", AppIDs: []string{"app-1", "app-2"}},
+		{ID: "policy-3", AppIDs: []string{"app-2"}, Enabled: true},
+	}
+
+	var diags diag.Diagnostics
+	byApp := killSwitchTargets(ctx, policies, PolicyKillSwitchResourceModel{
+		ApplicationID: types.StringValue("app-1"),
+		PolicyIDs:     types.SetNull(types.StringType),
+	}, &diags)
+	if len(byApp) != 2 || byApp[0].ID != "policy-1" || byApp[1].ID != "policy-2" {
+		t.Errorf("expected the policies of app-1, got %+v", byApp)
+	}
+
+	byID := killSwitchTargets(ctx, policies, PolicyKillSwitchResourceModel{
+		ApplicationID: types.StringNull(),
+		PolicyIDs:     types.SetValueMust(types.StringType, []attr.Value{types.StringValue("policy-3"), types.StringValue("policy-9")}),
+	}, &diags)
+	if len(byID) != 1 || byID[0].ID != "policy-3" {
+		t.Errorf("expected policy-3, got %+v", byID)
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+}
+
+func TestPolicyKillSwitchID(t *testing.T) {
+	ctx := context.Background()
+
+	var diags diag.Diagnostics
+	if id := policyKillSwitchID(ctx, PolicyKillSwitchResourceModel{ApplicationID: types.StringValue("app-1")}, &diags); id != "app-1" {
+		t.Errorf("expected the application ID, got %s", id)
+	}
+
+	id := policyKillSwitchID(ctx, PolicyKillSwitchResourceModel{
+		ApplicationID: types.StringNull(),
+		PolicyIDs:     types.SetValueMust(types.StringType, []attr.Value{types.StringValue("policy-2"), types.StringValue("policy-1")}),
+	}, &diags)
+	if id != "policy-1,policy-2" {
+		t.Errorf("expected sorted policy IDs, got %s", id)
+	}
+}
+
+func TestPolicyKillSwitchDisablesEveryPolicyType(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	conditionalID := server.Put(clienttest.Policies, clienttest.Object{"type": "CONDITIONAL", "name": "Business hours", "enabled": true, "appIds": []interface{}{"app-1"}})
+	rbacID := server.Put(clienttest.Policies, clienttest.Object{"type": "RBAC_ROLES", "name": "Admins only", "enabled": true, "appIds": []interface{}{"app-1"}})
+	maskingID := server.Put(clienttest.Policies, clienttest.Object{"type": "MASKING", "name": "PII", "enabled": true, "appIds": []interface{}{"app-1"}})
+	otherID := server.Put(clienttest.Policies, clienttest.Object{"type": "MASKING", "name": "Other app", "enabled": true, "appIds": []interface{}{"app-2"}})
+
+	r := &PolicyKillSwitchResource{client: client.NewClient(server.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	enabled := func(id string) interface{} { return server.Get(clienttest.Policies, id)["enabled"] }
+
+	for name, data := range map[string]PolicyKillSwitchResourceModel{
+		"application_id": {
+			ApplicationID: types.StringValue("app-1"),
+			PolicyIDs:     types.SetNull(types.StringType),
+		},
+		"policy_ids": {
+			ApplicationID: types.StringNull(),
+			PolicyIDs: types.SetValueMust(types.StringType, []attr.Value{
+				types.StringValue(conditionalID), types.StringValue(rbacID), types.StringValue(maskingID),
+			}),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			data.ID = types.StringUnknown()
+			data.Engaged = types.BoolValue(true)
+			data.DisabledPolicyIDs = types.SetUnknown(types.StringType)
+
+			plan := tfsdk.Plan{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			if diags := plan.Set(ctx, &data); diags.HasError() {
+				t.Fatalf("failed to build plan: %v", diags)
+			}
+
+			createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: plan.Raw}}
+			r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+			if createResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+			}
+			for _, id := range []string{conditionalID, rbacID, maskingID} {
+				if enabled(id) != false {
+					t.Errorf("expected policy %s to be disabled", id)
+				}
+			}
+			if enabled(otherID) != true {
+				t.Error("expected the policy of the other application to stay enabled")
+			}
+
+			readResp := &resource.ReadResponse{State: createResp.State}
+			r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+			if readResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+			}
+			var state PolicyKillSwitchResourceModel
+			readResp.Diagnostics.Append(readResp.State.Get(ctx, &state)...)
+			if disabled := len(state.DisabledPolicyIDs.Elements()); disabled != 3 || !state.Engaged.ValueBool() {
+				t.Errorf("expected three disabled policies on an engaged switch, got %d (engaged=%v)", disabled, state.Engaged)
+			}
+
+			deleteResp := &resource.DeleteResponse{State: readResp.State}
+			r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, deleteResp)
+			if deleteResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", deleteResp.Diagnostics)
+			}
+			for _, id := range []string{conditionalID, rbacID, maskingID} {
+				if enabled(id) != true {
+					t.Errorf("expected policy %s to be re-enabled", id)
+				}
+			}
+		})
+	}
+}
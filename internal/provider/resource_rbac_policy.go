@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 
 	"github.com/frontegg/terraform-provider-agentlink/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -16,6 +17,10 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &RbacPolicyResource{}
 var _ resource.ResourceWithImportState = &RbacPolicyResource{}
+var _ resource.ResourceWithIdentity = &RbacPolicyResource{}
+var _ resource.ResourceWithMoveState = &RbacPolicyResource{}
+var _ resource.ResourceWithUpgradeState = &RbacPolicyResource{}
+var _ resource.ResourceWithValidateConfig = &RbacPolicyResource{}
 
 func NewRbacPolicyResource() resource.Resource {
 	return &RbacPolicyResource{}
@@ -28,15 +33,20 @@ type RbacPolicyResource struct {
 
 // RbacPolicyResourceModel describes the resource data model.
 type RbacPolicyResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Name            types.String `tfsdk:"name"`
-	Description     types.String `tfsdk:"description"`
-	Enabled         types.Bool   `tfsdk:"enabled"`
-	AppIDs          types.List   `tfsdk:"app_ids"`
-	TenantID        types.String `tfsdk:"tenant_id"`
-	Type            types.String `tfsdk:"type"`
-	Keys            types.List   `tfsdk:"keys"`
-	InternalToolIDs types.List   `tfsdk:"internal_tool_ids"`
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Description        types.String `tfsdk:"description"`
+	Enabled            types.Bool   `tfsdk:"enabled"`
+	AppIDs             types.List   `tfsdk:"app_ids"`
+	TenantID           types.String `tfsdk:"tenant_id"`
+	TenantIDs          types.List   `tfsdk:"tenant_ids"`
+	Type               types.String `tfsdk:"type"`
+	Keys               types.List   `tfsdk:"keys"`
+	InternalToolIDs    types.List   `tfsdk:"internal_tool_ids"`
+	ToolTags           types.Set    `tfsdk:"tool_tags"`
+	Targeting          types.Object `tfsdk:"targeting"`
+	DisableOnDestroy   types.Bool   `tfsdk:"disable_on_destroy"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 func (r *RbacPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -46,6 +56,7 @@ func (r *RbacPolicyResource) Metadata(ctx context.Context, req resource.Metadata
 func (r *RbacPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages an RBAC (Role-Based Access Control) policy.",
+		Version:     0,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The policy ID.",
@@ -72,8 +83,14 @@ func (r *RbacPolicyResource) Schema(ctx context.Context, req resource.SchemaRequ
 				ElementType: types.StringType,
 			},
 			"tenant_id": schema.StringAttribute{
-				Description: "The tenant ID this policy applies to.",
+				Description:        "The tenant ID this policy applies to. Conflicts with tenant_ids.",
+				Optional:           true,
+				DeprecationMessage: "Use tenant_ids instead. tenant_id will be removed in a future major version.",
+			},
+			"tenant_ids": schema.ListAttribute{
+				Description: "List of tenant IDs this policy applies to. Conflicts with tenant_id.",
 				Optional:    true,
+				ElementType: types.StringType,
 			},
 			"type": schema.StringAttribute{
 				Description: "The RBAC policy type. Valid values: RBAC_ROLES, RBAC_PERMISSIONS.",
@@ -88,14 +105,34 @@ func (r *RbacPolicyResource) Schema(ctx context.Context, req resource.SchemaRequ
 				ElementType: types.StringType,
 			},
 			"internal_tool_ids": schema.ListAttribute{
-				Description: "List of internal tool IDs this policy applies to. At least one is required.",
+				Description: "List of internal tool IDs this policy applies to. At least one of internal_tool_ids or tool_tags is required.",
 				Required:    true,
 				ElementType: types.StringType,
 			},
+			"tool_tags":           policyToolTagsAttribute(),
+			"targeting":           policyTargetingSchema(),
+			"disable_on_destroy":  disableOnDestroyAttribute(),
+			"deletion_protection": deletionProtectionAttribute("Destroying a policy immediately stops enforcing it for agents."),
 		},
 	}
 }
 
+func (r *RbacPolicyResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema()
+}
+
+func (r *RbacPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var targeting types.Object
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("targeting"), &targeting)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validatePolicyTargetingResults(ctx, targeting, &resp.Diagnostics)
+	validatePolicyTenants(ctx, req.Config, &resp.Diagnostics)
+}
+
 func (r *RbacPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -114,6 +151,9 @@ func (r *RbacPolicyResource) Configure(ctx context.Context, req resource.Configu
 }
 
 func (r *RbacPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data RbacPolicyResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -130,6 +170,12 @@ func (r *RbacPolicyResource) Create(ctx context.Context, req resource.CreateRequ
 		}
 	}
 
+	// Convert tenant_id / tenant_ids
+	tenantID, tenantIDs := expandPolicyTenants(ctx, data.TenantID, data.TenantIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Convert keys
 	var keys []string
 	resp.Diagnostics.Append(data.Keys.ElementsAs(ctx, &keys, false)...)
@@ -144,8 +190,17 @@ func (r *RbacPolicyResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	if len(toolIDs) == 0 {
-		resp.Diagnostics.AddError("Validation Error", "At least one internal_tool_id is required for RBAC policies")
+	// Convert tool_tags
+	toolTags := expandStringSet(ctx, data.ToolTags, &resp.Diagnostics)
+
+	// Build targeting from state
+	targeting := expandPolicyTargeting(ctx, data.Targeting, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(toolIDs) == 0 && len(toolTags) == 0 {
+		resp.Diagnostics.AddError("Validation Error", "At least one internal_tool_id or tool_tag is required for RBAC policies")
 		return
 	}
 
@@ -154,24 +209,31 @@ func (r *RbacPolicyResource) Create(ctx context.Context, req resource.CreateRequ
 		Description:     data.Description.ValueString(),
 		Enabled:         data.Enabled.ValueBool(),
 		AppIDs:          appIDs,
-		TenantID:        data.TenantID.ValueString(),
+		TenantID:        tenantID,
+		TenantIDs:       tenantIDs,
 		Type:            data.Type.ValueString(),
 		Keys:            keys,
 		InternalToolIDs: toolIDs,
+		ToolTags:        toolTags,
+		Targeting:       targeting,
 	}
 
 	policy, err := r.client.CreateRbacPolicy(ctx, createReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to create RBAC policy: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to create RBAC policy", err)
 		return
 	}
 
 	data.ID = types.StringValue(policy.ID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID)...)
 }
 
 func (r *RbacPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data RbacPolicyResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -180,13 +242,12 @@ func (r *RbacPolicyResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	policy, err := r.client.GetRbacPolicy(ctx, data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to read RBAC policy: "+err.Error())
+	if errors.Is(err, client.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
 		return
 	}
-
-	if policy == nil {
-		resp.State.RemoveResource(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read RBAC policy", err)
 		return
 	}
 
@@ -207,7 +268,8 @@ func (r *RbacPolicyResource) Read(ctx context.Context, req resource.ReadRequest,
 		data.AppIDs = types.ListNull(types.StringType)
 	}
 
-	if policy.TenantID != "" {
+	data.TenantIDs = flattenPolicyTenantIDs(ctx, policy, data.TenantID, data.TenantIDs)
+	if policy.TenantID != "" && data.TenantIDs.IsNull() {
 		data.TenantID = types.StringValue(policy.TenantID)
 	}
 
@@ -232,11 +294,21 @@ func (r *RbacPolicyResource) Read(ctx context.Context, req resource.ReadRequest,
 	} else {
 		data.InternalToolIDs, _ = types.ListValue(types.StringType, []attr.Value{})
 	}
+	data.ToolTags = flattenOptionalStringSet(ctx, policy.ToolTags, data.ToolTags, &resp.Diagnostics)
+
+	data.Targeting = flattenPolicyTargeting(ctx, policy.Targeting, data.Targeting, &resp.Diagnostics)
+
+	data.DisableOnDestroy = flattenLocalBool(data.DisableOnDestroy)
+	data.DeletionProtection = flattenLocalBool(data.DeletionProtection)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID)...)
 }
 
 func (r *RbacPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data RbacPolicyResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -253,6 +325,12 @@ func (r *RbacPolicyResource) Update(ctx context.Context, req resource.UpdateRequ
 		}
 	}
 
+	// Convert tenant_id / tenant_ids
+	tenantID, tenantIDs := expandPolicyTenantsUpdate(ctx, data.TenantID, data.TenantIDs, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Convert keys
 	var keys []string
 	resp.Diagnostics.Append(data.Keys.ElementsAs(ctx, &keys, false)...)
@@ -267,27 +345,43 @@ func (r *RbacPolicyResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	// Convert tool_tags
+	toolTags := expandStringSet(ctx, data.ToolTags, &resp.Diagnostics)
+
+	// Build targeting from state
+	targeting := expandPolicyTargeting(ctx, data.Targeting, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	enabled := data.Enabled.ValueBool()
 	updateReq := client.UpdateRbacPolicyRequest{
 		Name:            data.Name.ValueString(),
 		Description:     data.Description.ValueString(),
 		Enabled:         &enabled,
 		AppIDs:          appIDs,
-		TenantID:        data.TenantID.ValueString(),
+		TenantID:        tenantID,
+		TenantIDs:       tenantIDs,
 		Keys:            keys,
 		InternalToolIDs: toolIDs,
+		ToolTags:        toolTags,
+		Targeting:       targeting,
 	}
 
 	_, err := r.client.UpdateRbacPolicy(ctx, data.ID.ValueString(), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to update RBAC policy: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to update RBAC policy", err)
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, data.ID)...)
 }
 
 func (r *RbacPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data RbacPolicyResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -295,13 +389,33 @@ func (r *RbacPolicyResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	if checkDeletionProtection(data.DeletionProtection, "agentlink_rbac_policy", data.Name.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	// Keep the policy, and its history and references, when Terraform stops managing it
+	if data.DisableOnDestroy.ValueBool() {
+		if err := r.client.SetPolicyEnabled(ctx, data.ID.ValueString(), data.Type.ValueString(), false); err != nil {
+			addClientError(&resp.Diagnostics, "Unable to disable RBAC policy", err)
+		}
+		return
+	}
+
 	err := r.client.DeletePolicy(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to delete RBAC policy: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to delete RBAC policy", err)
 		return
 	}
 }
 
 func (r *RbacPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importTypedPolicy(ctx, r.client, "agentlink_rbac_policy", req, resp)
+}
+
+func (r *RbacPolicyResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
+
+func (r *RbacPolicyResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
 }
@@ -0,0 +1,287 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SmsProviderResource{}
+var _ resource.ResourceWithImportState = &SmsProviderResource{}
+var _ resource.ResourceWithMoveState = &SmsProviderResource{}
+var _ resource.ResourceWithUpgradeState = &SmsProviderResource{}
+
+func NewSmsProviderResource() resource.Resource {
+	return &SmsProviderResource{}
+}
+
+// SmsProviderResource defines the resource implementation.
+type SmsProviderResource struct {
+	client *client.Client
+}
+
+// SmsProviderResourceModel describes the resource data model.
+type SmsProviderResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	ProviderName        types.String `tfsdk:"provider_name"`
+	AccountSID          types.String `tfsdk:"account_sid"`
+	AuthToken           types.String `tfsdk:"auth_token"`
+	MessagingServiceSID types.String `tfsdk:"messaging_service_sid"`
+	SenderID            types.String `tfsdk:"sender_id"`
+	TenantID            types.String `tfsdk:"tenant_id"`
+}
+
+// smsProviders are the SMS providers supported by the API
+var smsProviders = []string{"twilio"}
+
+func (r *SmsProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sms_provider"
+}
+
+func (r *SmsProviderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the SMS provider used to deliver one-time passcodes, for environments where MFA uses SMS. This is a singleton resource; destroying it removes the SMS configuration.",
+		Version:     0,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The client ID of the vendor the SMS provider is configured for.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"provider_name": schema.StringAttribute{
+				Description: "The SMS provider. Currently only twilio is supported. Defaults to twilio.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("twilio"),
+			},
+			"account_sid": schema.StringAttribute{
+				Description: "The Twilio account SID.",
+				Required:    true,
+			},
+			"auth_token": schema.StringAttribute{
+				Description: "The Twilio auth token. The API never returns it, so changes made outside Terraform are not detected.",
+				Required:    true,
+				Sensitive:   true,
+			},
+			"messaging_service_sid": schema.StringAttribute{
+				Description: "The Twilio messaging service SID used to send messages.",
+				Optional:    true,
+			},
+			"sender_id": schema.StringAttribute{
+				Description: "The sender ID shown to recipients, either a phone number or an alphanumeric sender name where the recipient country supports it.",
+				Optional:    true,
+			},
+			"tenant_id": schema.StringAttribute{
+				Description: "The tenant to configure the SMS provider for, overriding the vendor-wide SMS provider for its users. When unset, the vendor-wide SMS provider is managed.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SmsProviderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SmsProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data SmsProviderResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateReq := expandSmsProvider(data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = client.WithTenantID(ctx, data.TenantID.ValueString())
+	config, err := r.client.UpdateSmsConfiguration(ctx, updateReq)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to create SMS provider", err)
+		return
+	}
+
+	data.ID = types.StringValue(r.client.ClientID())
+	flattenSmsProvider(config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SmsProviderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data SmsProviderResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = client.WithTenantID(ctx, data.TenantID.ValueString())
+	config, err := r.client.GetSmsConfiguration(ctx)
+	if errors.Is(err, client.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read SMS provider", err)
+		return
+	}
+
+	data.ID = types.StringValue(r.client.ClientID())
+	flattenSmsProvider(config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SmsProviderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data SmsProviderResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateReq := expandSmsProvider(data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = client.WithTenantID(ctx, data.TenantID.ValueString())
+	config, err := r.client.UpdateSmsConfiguration(ctx, updateReq)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to update SMS provider", err)
+		return
+	}
+
+	flattenSmsProvider(config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SmsProviderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data SmsProviderResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = client.WithTenantID(ctx, data.TenantID.ValueString())
+	err := r.client.DeleteSmsConfiguration(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to delete SMS provider", err)
+		return
+	}
+}
+
+func (r *SmsProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Only the SMS provider of the vendor the provider is authenticated as can be imported
+	if req.ID != r.client.ClientID() {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected the client ID the provider is authenticated with (%s), got %q.", r.client.ClientID(), req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *SmsProviderResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
+
+func (r *SmsProviderResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+// expandSmsProvider builds the SMS configuration request from the resource model
+func expandSmsProvider(data SmsProviderResourceModel, diags *diag.Diagnostics) client.UpdateSmsConfigurationRequest {
+	providerName := data.ProviderName.ValueString()
+	if !isValidSmsProvider(providerName) {
+		diags.AddAttributeError(
+			path.Root("provider_name"),
+			"Invalid SMS Provider",
+			fmt.Sprintf("Unsupported SMS provider %q. Valid values: %v", providerName, smsProviders),
+		)
+	}
+
+	return client.UpdateSmsConfigurationRequest{
+		Provider:   providerName,
+		AccountID:  data.AccountSID.ValueString(),
+		Token:      data.AuthToken.ValueString(),
+		ServiceID:  data.MessagingServiceSID.ValueString(),
+		SenderName: data.SenderID.ValueString(),
+	}
+}
+
+// flattenSmsProvider maps an SMS configuration response onto the resource model.
+// The auth token is not returned by the API and keeps its configured value.
+func flattenSmsProvider(config *client.SmsConfiguration, data *SmsProviderResourceModel) {
+	if config.Provider != "" {
+		data.ProviderName = types.StringValue(config.Provider)
+	}
+	if config.AccountID != "" {
+		data.AccountSID = types.StringValue(config.AccountID)
+	}
+
+	// Keep optional attributes unset when the API returns no value
+	if config.ServiceID != "" || !data.MessagingServiceSID.IsNull() {
+		data.MessagingServiceSID = types.StringValue(config.ServiceID)
+	}
+	if config.SenderName != "" || !data.SenderID.IsNull() {
+		data.SenderID = types.StringValue(config.SenderName)
+	}
+}
+
+// isValidSmsProvider reports whether providerName is a supported SMS provider
+func isValidSmsProvider(providerName string) bool {
+	for _, valid := range smsProviders {
+		if providerName == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSmsProviderResourceHasExpectedSchema(t *testing.T) {
+	r := NewSmsProviderResource()
+
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	attrs := []string{"id", "provider_name", "account_sid", "auth_token", "messaging_service_sid", "sender_id", "tenant_id"}
+	for _, attr := range attrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in schema", attr)
+		}
+	}
+
+	token, ok := resp.Schema.Attributes["auth_token"].(schema.StringAttribute)
+	if !ok {
+		t.Fatal("auth_token should be a StringAttribute")
+	}
+	if !token.Sensitive {
+		t.Error("auth_token should be sensitive")
+	}
+}
+
+func TestSmsProviderResourceMetadata(t *testing.T) {
+	r := NewSmsProviderResource()
+
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_sms_provider"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func TestExpandSmsProvider(t *testing.T) {
+	var diags diag.Diagnostics
+	req := expandSmsProvider(SmsProviderResourceModel{
+		ProviderName:        types.StringValue("twilio"),
+		AccountSID:          types.StringValue("AC123"),
+		AuthToken:           types.StringValue("auth-token"),
+		MessagingServiceSID: types.StringNull(),
+		SenderID:            types.StringValue("Acme"),
+	}, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if req.AccountID != "AC123" || req.Token != "auth-token" || req.SenderName != "Acme" {
+		t.Errorf("unexpected request: %+v", req)
+	}
+	if req.ServiceID != "" {
+		t.Errorf("expected unset messaging service SID to be omitted, got %q", req.ServiceID)
+	}
+
+	diags = diag.Diagnostics{}
+	expandSmsProvider(SmsProviderResourceModel{
+		ProviderName: types.StringValue("sns"),
+		AccountSID:   types.StringValue("AC123"),
+		AuthToken:    types.StringValue("auth-token"),
+	}, &diags)
+	if !diags.HasError() {
+		t.Error("expected an error for an unsupported SMS provider")
+	}
+}
+
+func TestFlattenSmsProviderKeepsAuthToken(t *testing.T) {
+	data := SmsProviderResourceModel{
+		AuthToken:           types.StringValue("auth-token"),
+		MessagingServiceSID: types.StringNull(),
+		SenderID:            types.StringNull(),
+	}
+	flattenSmsProvider(&client.SmsConfiguration{
+		Provider:   "twilio",
+		AccountID:  "AC123",
+		SenderName: "Acme",
+	}, &data)
+
+	if data.AuthToken.ValueString() != "auth-token" {
+		t.Errorf("expected auth token to be kept, got %q", data.AuthToken.ValueString())
+	}
+	if data.AccountSID.ValueString() != "AC123" {
+		t.Errorf("expected account SID 'AC123', got %q", data.AccountSID.ValueString())
+	}
+	if data.SenderID.ValueString() != "Acme" {
+		t.Errorf("expected sender ID 'Acme', got %q", data.SenderID.ValueString())
+	}
+	if !data.MessagingServiceSID.IsNull() {
+		t.Errorf("expected messaging service SID to stay unset, got %v", data.MessagingServiceSID)
+	}
+}
@@ -2,9 +2,13 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -13,11 +17,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &SourceResource{}
 var _ resource.ResourceWithImportState = &SourceResource{}
+var _ resource.ResourceWithIdentity = &SourceResource{}
+var _ resource.ResourceWithMoveState = &SourceResource{}
+var _ resource.ResourceWithUpgradeState = &SourceResource{}
 
 func NewSourceResource() resource.Resource {
 	return &SourceResource{}
@@ -30,14 +38,18 @@ type SourceResource struct {
 
 // SourceResourceModel describes the resource data model.
 type SourceResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	ApplicationID types.String `tfsdk:"application_id"`
-	Name          types.String `tfsdk:"name"`
-	Type          types.String `tfsdk:"type"`
-	SourceURL     types.String `tfsdk:"source_url"`
-	APITimeout    types.Int64  `tfsdk:"api_timeout"`
-	Enabled       types.Bool   `tfsdk:"enabled"`
-	VendorID      types.String `tfsdk:"vendor_id"`
+	ID                 types.String `tfsdk:"id"`
+	ApplicationID      types.String `tfsdk:"application_id"`
+	Name               types.String `tfsdk:"name"`
+	Type               types.String `tfsdk:"type"`
+	SourceURL          types.String `tfsdk:"source_url"`
+	APITimeout         types.Int64  `tfsdk:"api_timeout"`
+	Enabled            types.Bool   `tfsdk:"enabled"`
+	VendorID           types.String `tfsdk:"vendor_id"`
+	AdoptExisting      types.Bool   `tfsdk:"adopt_existing"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	ToolCount          types.Int64  `tfsdk:"tool_count"`
+	ToolsSyncedAt      types.String `tfsdk:"tools_synced_at"`
 }
 
 func (r *SourceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -47,6 +59,7 @@ func (r *SourceResource) Metadata(ctx context.Context, req resource.MetadataRequ
 func (r *SourceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages an MCP configuration source for an application.",
+		Version:     0,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The source ID.",
@@ -93,10 +106,29 @@ func (r *SourceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Whether to take over an existing source with the same name in the application when creating, e.g. after state was lost, instead of failing. The existing source is updated to match the configuration. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"deletion_protection": deletionProtectionAttribute("Destroying a source deletes all of its tools, which removes them from any policies referencing them."),
+			"tool_count": schema.Int64Attribute{
+				Description: "The number of tools imported from the source, as of the last refresh.",
+				Computed:    true,
+			},
+			"tools_synced_at": schema.StringAttribute{
+				Description: "When the tools of the source were last created or updated, as of the last refresh. Null when the source has no tools.",
+				Computed:    true,
+			},
 		},
 	}
 }
 
+func (r *SourceResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = sourceIdentitySchema()
+}
+
 func (r *SourceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -115,6 +147,9 @@ func (r *SourceResource) Configure(ctx context.Context, req resource.ConfigureRe
 }
 
 func (r *SourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data SourceResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -132,8 +167,13 @@ func (r *SourceResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	source, err := r.client.CreateSource(ctx, createReq)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to create source: "+err.Error())
+	if client.IsConflict(err) {
+		source = r.adoptExistingSource(ctx, data, err, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to create source", err)
 		return
 	}
 
@@ -146,10 +186,21 @@ func (r *SourceResource) Create(ctx context.Context, req resource.CreateRequest,
 	data.Enabled = types.BoolValue(source.Enabled)
 	data.VendorID = types.StringValue(source.VendorID)
 
+	r.readToolSync(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Identity != nil {
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, SourceIdentityModel{ApplicationID: data.ApplicationID, ID: data.ID})...)
+	}
 }
 
 func (r *SourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data SourceResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -158,13 +209,12 @@ func (r *SourceResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	source, err := r.client.GetSourceByID(ctx, data.ApplicationID.ValueString(), data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to read source: "+err.Error())
+	if errors.Is(err, client.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
 		return
 	}
-
-	if source == nil {
-		resp.State.RemoveResource(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read source", err)
 		return
 	}
 
@@ -177,10 +227,28 @@ func (r *SourceResource) Read(ctx context.Context, req resource.ReadRequest, res
 	data.Enabled = types.BoolValue(source.Enabled)
 	data.VendorID = types.StringValue(source.VendorID)
 
+	// adopt_existing is not stored by Frontegg; imported sources default to false
+	if data.AdoptExisting.IsNull() {
+		data.AdoptExisting = types.BoolValue(false)
+	}
+
+	data.DeletionProtection = flattenLocalBool(data.DeletionProtection)
+
+	r.readToolSync(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Identity != nil {
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, SourceIdentityModel{ApplicationID: data.ApplicationID, ID: data.ID})...)
+	}
 }
 
 func (r *SourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data SourceResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -188,19 +256,9 @@ func (r *SourceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	enabled := data.Enabled.ValueBool()
-	updateReq := client.UpdateSourceRequest{
-		AppID:      data.ApplicationID.ValueString(),
-		Name:       data.Name.ValueString(),
-		Type:       data.Type.ValueString(),
-		SourceURL:  data.SourceURL.ValueString(),
-		APITimeout: int(data.APITimeout.ValueInt64()),
-		Enabled:    &enabled,
-	}
-
-	source, err := r.client.UpdateSource(ctx, data.ID.ValueString(), updateReq)
+	source, err := r.client.UpdateSource(ctx, data.ID.ValueString(), expandUpdateSourceRequest(data))
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to update source: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to update source", err)
 		return
 	}
 
@@ -213,10 +271,21 @@ func (r *SourceResource) Update(ctx context.Context, req resource.UpdateRequest,
 	data.Enabled = types.BoolValue(source.Enabled)
 	data.VendorID = types.StringValue(source.VendorID)
 
+	r.readToolSync(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Identity != nil {
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, SourceIdentityModel{ApplicationID: data.ApplicationID, ID: data.ID})...)
+	}
 }
 
 func (r *SourceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data SourceResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -224,14 +293,31 @@ func (r *SourceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	if checkDeletionProtection(data.DeletionProtection, "agentlink_source", data.Name.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
 	err := r.client.DeleteSource(ctx, data.ApplicationID.ValueString(), data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to delete source: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to delete source", err)
 		return
 	}
 }
 
 func (r *SourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by identity (Terraform 1.12+)
+	if req.ID == "" && req.Identity != nil {
+		var identity SourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("application_id"), identity.ApplicationID)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ID)...)
+		return
+	}
+
 	// Import format: application_id:source_id
 	parts := strings.Split(req.ID, ":")
 	if len(parts) != 2 {
@@ -245,3 +331,100 @@ func (r *SourceResource) ImportState(ctx context.Context, req resource.ImportSta
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("application_id"), parts[0])...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
 }
+
+func (r *SourceResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
+
+func (r *SourceResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+// adoptExistingSource resolves a name conflict on create. With adopt_existing the existing source
+// is updated to the planned values and returned; otherwise the error points to import.
+func (r *SourceResource) adoptExistingSource(ctx context.Context, data SourceResourceModel, createErr error, diags *diag.Diagnostics) *client.Source {
+	appID := data.ApplicationID.ValueString()
+
+	existing, err := r.client.FindSourceByName(ctx, appID, data.Name.ValueString())
+	if err != nil {
+		addClientError(diags, "Unable to look up existing source", err)
+		return nil
+	}
+	if existing == nil {
+		addClientError(diags, "Unable to create source", createErr)
+		return nil
+	}
+
+	if !data.AdoptExisting.ValueBool() {
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Source Already Exists",
+			fmt.Sprintf("A source named %q already exists in application %s with ID %s. Import it with "+
+				"`terraform import agentlink_source.<name> %s:%s`, or set adopt_existing = true "+
+				"to manage the existing source with this resource.", existing.Name, appID, existing.ID, appID, existing.ID),
+		)
+		return nil
+	}
+
+	tflog.Info(ctx, "Adopting existing source", map[string]interface{}{
+		"name":   existing.Name,
+		"id":     existing.ID,
+		"app_id": appID,
+	})
+
+	source, err := r.client.UpdateSource(ctx, existing.ID, expandUpdateSourceRequest(data))
+	if err != nil {
+		addClientError(diags, "Unable to adopt existing source", err)
+		return nil
+	}
+	return source
+}
+
+// readToolSync sets tool_count and tools_synced_at from the tools currently imported from the source
+func (r *SourceResource) readToolSync(ctx context.Context, data *SourceResourceModel, diags *diag.Diagnostics) {
+	tools, err := r.client.GetToolsBySource(ctx, data.ApplicationID.ValueString(), data.ID.ValueString())
+	if err != nil {
+		addClientError(diags, "Unable to read source tools", err)
+		return
+	}
+
+	data.ToolCount = types.Int64Value(int64(len(tools)))
+	data.ToolsSyncedAt = types.StringNull()
+	if syncedAt := toolsSyncedAt(tools); syncedAt != "" {
+		data.ToolsSyncedAt = types.StringValue(syncedAt)
+	}
+}
+
+// toolsSyncedAt returns the latest creation or update timestamp of the tools, or an empty string
+// when no tool has a valid timestamp
+func toolsSyncedAt(tools []client.InternalTool) string {
+	var latest time.Time
+	var result string
+	for _, tool := range tools {
+		for _, timestamp := range []string{tool.CreatedAt, tool.UpdatedAt} {
+			t, err := time.Parse(time.RFC3339, timestamp)
+			if err != nil {
+				continue
+			}
+			if result == "" || t.After(latest) {
+				latest = t
+				result = timestamp
+			}
+		}
+	}
+	return result
+}
+
+// expandUpdateSourceRequest builds the source update request from the resource model
+func expandUpdateSourceRequest(data SourceResourceModel) client.UpdateSourceRequest {
+	enabled := data.Enabled.ValueBool()
+
+	return client.UpdateSourceRequest{
+		AppID:      data.ApplicationID.ValueString(),
+		Name:       data.Name.ValueString(),
+		Type:       data.Type.ValueString(),
+		SourceURL:  data.SourceURL.ValueString(),
+		APITimeout: int(data.APITimeout.ValueInt64()),
+		Enabled:    &enabled,
+	}
+}
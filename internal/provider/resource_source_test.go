@@ -2,9 +2,17 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/frontegg/terraform-provider-agentlink/clienttest"
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestSourceResourceHasExpectedSchema(t *testing.T) {
@@ -24,7 +32,7 @@ func TestSourceResourceHasExpectedSchema(t *testing.T) {
 	}
 
 	// Check computed attributes
-	computedAttrs := []string{"id", "vendor_id"}
+	computedAttrs := []string{"id", "vendor_id", "tool_count", "tools_synced_at"}
 	for _, attr := range computedAttrs {
 		if _, ok := resp.Schema.Attributes[attr]; !ok {
 			t.Errorf("expected computed attribute '%s' in schema", attr)
@@ -32,7 +40,7 @@ func TestSourceResourceHasExpectedSchema(t *testing.T) {
 	}
 
 	// Check optional attributes
-	optionalAttrs := []string{"api_timeout", "enabled"}
+	optionalAttrs := []string{"api_timeout", "enabled", "adopt_existing"}
 	for _, attr := range optionalAttrs {
 		if _, ok := resp.Schema.Attributes[attr]; !ok {
 			t.Errorf("expected optional attribute '%s' in schema", attr)
@@ -59,4 +67,146 @@ func TestSourceResourceImplementsResource(t *testing.T) {
 
 	var _ = r
 	var _ resource.ResourceWithImportState = r.(*SourceResource)
+	var _ resource.ResourceWithIdentity = r.(*SourceResource)
+}
+
+func TestSourceResourceIdentitySchema(t *testing.T) {
+	r := &SourceResource{}
+
+	resp := &resource.IdentitySchemaResponse{}
+	r.IdentitySchema(context.Background(), resource.IdentitySchemaRequest{}, resp)
+
+	for _, attr := range []string{"application_id", "id"} {
+		if _, ok := resp.IdentitySchema.Attributes[attr]; !ok {
+			t.Errorf("expected '%s' attribute in identity schema", attr)
+		}
+	}
+}
+
+func TestSourceResourceImportStateByIdentity(t *testing.T) {
+	ctx := context.Background()
+	r := &SourceResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	identityResp := &resource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identityResp)
+
+	req := resource.ImportStateRequest{
+		Identity: &tfsdk.ResourceIdentity{
+			Schema: identityResp.IdentitySchema,
+			Raw: tftypes.NewValue(identityResp.IdentitySchema.Type().TerraformType(ctx), map[string]tftypes.Value{
+				"application_id": tftypes.NewValue(tftypes.String, "app-1"),
+				"id":             tftypes.NewValue(tftypes.String, "src-1"),
+			}),
+		},
+	}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	r.ImportState(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var applicationID, id types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("application_id"), &applicationID)...)
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if applicationID.ValueString() != "app-1" || id.ValueString() != "src-1" {
+		t.Errorf("expected application_id 'app-1' and id 'src-1', got '%s' and '%s'", applicationID.ValueString(), id.ValueString())
+	}
+}
+
+func TestSourceResourceAdoptExisting(t *testing.T) {
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	id := server.Put(clienttest.Sources, clienttest.Object{"appId": "app-1", "name": "Orders API", "sourceUrl": "https://old.example.com"})
+
+	r := &SourceResource{client: client.NewClient(server.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)}
+	data := SourceResourceModel{
+		ApplicationID: types.StringValue("app-1"),
+		Name:          types.StringValue("Orders API"),
+		Type:          types.StringValue("REST"),
+		SourceURL:     types.StringValue("https://api.example.com"),
+		APITimeout:    types.Int64Value(3000),
+		Enabled:       types.BoolValue(true),
+		AdoptExisting: types.BoolValue(false),
+	}
+	conflict := errors.New("failed to create source with status 409")
+
+	// Without adopt_existing the error points to the existing source
+	var diags diag.Diagnostics
+	if source := r.adoptExistingSource(context.Background(), data, conflict, &diags); source != nil || !diags.HasError() {
+		t.Fatalf("expected an error, got %+v", source)
+	}
+	if summary := diags.Errors()[0].Summary(); summary != "Source Already Exists" {
+		t.Errorf("expected 'Source Already Exists', got %q", summary)
+	}
+
+	// With adopt_existing the existing source is updated and returned
+	data.AdoptExisting = types.BoolValue(true)
+	diags = diag.Diagnostics{}
+	source := r.adoptExistingSource(context.Background(), data, conflict, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if source.ID != id || source.SourceURL != "https://api.example.com" {
+		t.Errorf("expected adopted source %s with updated URL, got %+v", id, source)
+	}
+}
+
+func TestSourceResourceDeletionProtection(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	id := server.Put(clienttest.Sources, clienttest.Object{"appId": "app-1", "name": "Orders API"})
+
+	r := &SourceResource{client: client.NewClient(server.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := state.Set(ctx, &SourceResourceModel{
+		ID:                 types.StringValue(id),
+		ApplicationID:      types.StringValue("app-1"),
+		Name:               types.StringValue("Orders API"),
+		DeletionProtection: types.BoolValue(true),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected deletion protection to block the delete")
+	}
+	if server.Get(clienttest.Sources, id) == nil {
+		t.Error("expected the protected source to remain")
+	}
+}
+
+func TestToolsSyncedAt(t *testing.T) {
+	tools := []client.InternalTool{
+		{Name: "listOrders", CreatedAt: "2024-01-01T00:00:00Z", UpdatedAt: "2024-03-01T10:00:00Z"},
+		{Name: "createOrder", CreatedAt: "2024-02-01T00:00:00+02:00", UpdatedAt: "not-a-timestamp"},
+		{Name: "getOrder", CreatedAt: "2024-03-01T11:30:00+02:00"},
+	}
+
+	if got := toolsSyncedAt(tools); got != "2024-03-01T10:00:00Z" {
+		t.Errorf("expected the latest timestamp, got %q", got)
+	}
+
+	if got := toolsSyncedAt([]client.InternalTool{{Name: "listOrders"}}); got != "" {
+		t.Errorf("expected no timestamp for tools without timestamps, got %q", got)
+	}
 }
@@ -0,0 +1,406 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SourcesBundleResource{}
+var _ resource.ResourceWithImportState = &SourcesBundleResource{}
+var _ resource.ResourceWithMoveState = &SourcesBundleResource{}
+var _ resource.ResourceWithUpgradeState = &SourcesBundleResource{}
+
+func NewSourcesBundleResource() resource.Resource {
+	return &SourcesBundleResource{}
+}
+
+// SourcesBundleResource defines the resource implementation.
+type SourcesBundleResource struct {
+	client *client.Client
+}
+
+// SourcesBundleResourceModel describes the resource data model.
+type SourcesBundleResourceModel struct {
+	ID                 types.String                        `tfsdk:"id"`
+	ApplicationID      types.String                        `tfsdk:"application_id"`
+	Sources            map[string]SourcesBundleSourceModel `tfsdk:"sources"`
+	AdoptExisting      types.Bool                          `tfsdk:"adopt_existing"`
+	DeletionProtection types.Bool                          `tfsdk:"deletion_protection"`
+}
+
+// SourcesBundleSourceModel describes a source of the bundle, keyed by its name.
+type SourcesBundleSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Type       types.String `tfsdk:"type"`
+	SourceURL  types.String `tfsdk:"source_url"`
+	APITimeout types.Int64  `tfsdk:"api_timeout"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+}
+
+func (r *SourcesBundleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sources_bundle"
+}
+
+func (r *SourcesBundleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages many MCP configuration sources of an application at once. Sources are reconciled in one pass with a single list call, which cuts API calls for configurations declaring many sources. Sources must not also be managed with agentlink_source.",
+		Version:     0,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The application ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"application_id": schema.StringAttribute{
+				Description: "The application ID the sources belong to. Changing this forces a new resource to be created.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sources": schema.MapNestedAttribute{
+				Description: "The sources of the application, keyed by source name. Sources removed from the map are deleted.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The source ID.",
+							Computed:    true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"type": schema.StringAttribute{
+							Description: "The source type. Valid values: REST, GRAPHQL, MOCK, MCP_PROXY, FRONTEGG, CUSTOM_INTEGRATION.",
+							Required:    true,
+						},
+						"source_url": schema.StringAttribute{
+							Description: "The source URL (must be HTTPS).",
+							Required:    true,
+						},
+						"api_timeout": schema.Int64Attribute{
+							Description: "API timeout in milliseconds (500-5000). Defaults to 3000.",
+							Optional:    true,
+							Computed:    true,
+							Default:     int64default.StaticInt64(3000),
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the source is enabled.",
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(true),
+						},
+					},
+				},
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Whether to take over existing sources with the same names in the application, e.g. after state was lost, instead of failing. The existing sources are updated to match the configuration. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"deletion_protection": deletionProtectionAttribute("Destroying the bundle deletes all of its sources and their tools, which removes them from any policies referencing them."),
+		},
+	}
+}
+
+func (r *SourcesBundleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SourcesBundleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data SourcesBundleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.ApplicationID
+	data.Sources = r.reconcile(ctx, data, nil, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SourcesBundleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data SourcesBundleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID := data.ApplicationID.ValueString()
+	sources, err := r.client.GetSources(ctx, appID)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read sources", err)
+		return
+	}
+
+	// Imported bundles start with every source of the application
+	if data.Sources == nil {
+		data.Sources = map[string]SourcesBundleSourceModel{}
+		for _, source := range sources {
+			data.Sources[source.Name] = SourcesBundleSourceModel{ID: types.StringValue(source.ID)}
+		}
+	}
+
+	byID := make(map[string]client.Source, len(sources))
+	for _, source := range sources {
+		byID[source.ID] = source
+	}
+
+	refreshed := make(map[string]SourcesBundleSourceModel, len(data.Sources))
+	for _, current := range data.Sources {
+		// Sources no longer listed for the application were deleted
+		source, ok := byID[current.ID.ValueString()]
+		if !ok || source.AppID != appID {
+			continue
+		}
+		// The map key is the source name, so a renamed source moves to its new key
+		refreshed[source.Name] = flattenSourcesBundleSource(source)
+	}
+	data.Sources = refreshed
+
+	data.ID = data.ApplicationID
+	if data.AdoptExisting.IsNull() {
+		data.AdoptExisting = types.BoolValue(false)
+	}
+	data.DeletionProtection = flattenLocalBool(data.DeletionProtection)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SourcesBundleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data, state SourcesBundleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.ApplicationID
+	data.Sources = r.reconcile(ctx, data, state.Sources, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SourcesBundleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data SourcesBundleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if checkDeletionProtection(data.DeletionProtection, "agentlink_sources_bundle", data.ApplicationID.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	for _, name := range sortedSourceNames(data.Sources) {
+		err := r.client.DeleteSource(ctx, data.ApplicationID.ValueString(), data.Sources[name].ID.ValueString())
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			addClientError(&resp.Diagnostics, "Unable to delete source "+name, err)
+		}
+	}
+}
+
+func (r *SourcesBundleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by application_id; every source of the application is imported into the bundle
+	resource.ImportStatePassthroughID(ctx, path.Root("application_id"), req, resp)
+}
+
+func (r *SourcesBundleResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
+
+func (r *SourcesBundleResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+// reconcile creates, updates and deletes sources so the application matches the planned bundle,
+// listing the sources of the application once to find existing ones. Unchanged sources are not
+// written. It returns the sources that exist afterwards, so a partial failure keeps track of
+// every source created before it.
+func (r *SourcesBundleResource) reconcile(ctx context.Context, data SourcesBundleResourceModel, current map[string]SourcesBundleSourceModel, diags *diag.Diagnostics) map[string]SourcesBundleSourceModel {
+	appID := data.ApplicationID.ValueString()
+	result := make(map[string]SourcesBundleSourceModel, len(data.Sources))
+
+	for _, name := range sortedSourceNames(current) {
+		if _, ok := data.Sources[name]; ok {
+			continue
+		}
+		err := r.client.DeleteSource(ctx, appID, current[name].ID.ValueString())
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			addClientError(diags, "Unable to delete source "+name, err)
+			result[name] = current[name]
+		}
+	}
+
+	var existing []client.Source
+	var listed bool
+
+	for _, name := range sortedSourceNames(data.Sources) {
+		planned := data.Sources[name]
+		updateReq := expandSourcesBundleUpdateRequest(appID, name, planned)
+
+		if state, ok := current[name]; ok {
+			if sourcesBundleSourceEqual(state, planned) {
+				result[name] = state
+				continue
+			}
+			source, err := r.client.UpdateSource(ctx, state.ID.ValueString(), updateReq)
+			if err != nil {
+				addClientError(diags, "Unable to update source "+name, err)
+				result[name] = state
+				continue
+			}
+			result[name] = flattenSourcesBundleSource(*source)
+			continue
+		}
+
+		if !listed {
+			sources, err := r.client.GetSources(ctx, appID)
+			if err != nil {
+				addClientError(diags, "Unable to read sources", err)
+				return result
+			}
+			existing, listed = sources, true
+		}
+
+		var source *client.Source
+		var err error
+		action := "Unable to create source " + name
+		if match := findSourceByName(existing, name); match != nil {
+			if !data.AdoptExisting.ValueBool() {
+				diags.AddAttributeError(
+					path.Root("sources").AtMapKey(name),
+					"Source Already Exists",
+					fmt.Sprintf("A source named %q already exists in application %s with ID %s. Remove it from the "+
+						"bundle, or set adopt_existing = true to manage the existing source with this resource.", name, appID, match.ID),
+				)
+				continue
+			}
+			tflog.Info(ctx, "Adopting existing source", map[string]interface{}{
+				"name":   name,
+				"id":     match.ID,
+				"app_id": appID,
+			})
+			action = "Unable to adopt existing source " + name
+			source, err = r.client.UpdateSource(ctx, match.ID, updateReq)
+		} else {
+			source, err = r.client.CreateSource(ctx, client.CreateSourceRequest{
+				AppID:      appID,
+				Name:       name,
+				Type:       planned.Type.ValueString(),
+				SourceURL:  planned.SourceURL.ValueString(),
+				APITimeout: int(planned.APITimeout.ValueInt64()),
+				Enabled:    planned.Enabled.ValueBool(),
+			})
+		}
+		if err != nil {
+			addClientError(diags, action, err)
+			continue
+		}
+		result[name] = flattenSourcesBundleSource(*source)
+	}
+
+	return result
+}
+
+// expandSourcesBundleUpdateRequest builds the update request of a source of the bundle
+func expandSourcesBundleUpdateRequest(appID, name string, source SourcesBundleSourceModel) client.UpdateSourceRequest {
+	enabled := source.Enabled.ValueBool()
+
+	return client.UpdateSourceRequest{
+		AppID:      appID,
+		Name:       name,
+		Type:       source.Type.ValueString(),
+		SourceURL:  source.SourceURL.ValueString(),
+		APITimeout: int(source.APITimeout.ValueInt64()),
+		Enabled:    &enabled,
+	}
+}
+
+// flattenSourcesBundleSource maps a source response onto a source of the bundle
+func flattenSourcesBundleSource(source client.Source) SourcesBundleSourceModel {
+	return SourcesBundleSourceModel{
+		ID:         types.StringValue(source.ID),
+		Type:       types.StringValue(source.Type),
+		SourceURL:  types.StringValue(source.SourceURL),
+		APITimeout: types.Int64Value(int64(source.APITimeout)),
+		Enabled:    types.BoolValue(source.Enabled),
+	}
+}
+
+// sourcesBundleSourceEqual reports whether a planned source matches its state, ignoring the ID
+func sourcesBundleSourceEqual(state, planned SourcesBundleSourceModel) bool {
+	return state.Type.Equal(planned.Type) &&
+		state.SourceURL.Equal(planned.SourceURL) &&
+		state.APITimeout.Equal(planned.APITimeout) &&
+		state.Enabled.Equal(planned.Enabled)
+}
+
+// findSourceByName returns the source with the given name, or nil
+func findSourceByName(sources []client.Source, name string) *client.Source {
+	for i := range sources {
+		if sources[i].Name == name {
+			return &sources[i]
+		}
+	}
+	return nil
+}
+
+// sortedSourceNames returns the keys of a bundle's sources in order, so API calls are made in a
+// stable order
+func sortedSourceNames(sources map[string]SourcesBundleSourceModel) []string {
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/clienttest"
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSourcesBundleResourceMetadata(t *testing.T) {
+	r := NewSourcesBundleResource()
+
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	if resp.TypeName != "agentlink_sources_bundle" {
+		t.Errorf("expected type name 'agentlink_sources_bundle', got '%s'", resp.TypeName)
+	}
+}
+
+func TestSourcesBundleResourceHasExpectedSchema(t *testing.T) {
+	r := NewSourcesBundleResource()
+
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	sources, ok := resp.Schema.Attributes["sources"].(schema.MapNestedAttribute)
+	if !ok || !sources.Required {
+		t.Fatalf("expected a required sources map, got %+v", resp.Schema.Attributes["sources"])
+	}
+	for _, attr := range []string{"id", "type", "source_url", "api_timeout", "enabled"} {
+		if _, ok := sources.NestedObject.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in sources", attr)
+		}
+	}
+	for _, attr := range []string{"id", "application_id", "adopt_existing", "deletion_protection"} {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in schema", attr)
+		}
+	}
+}
+
+func TestSourcesBundleReconcile(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	existingID := server.Put(clienttest.Sources, clienttest.Object{
+		"appId": "app-1", "name": "billing", "type": "REST", "sourceUrl": "https://old.example.com", "enabled": true,
+	})
+
+	r := &SourcesBundleResource{client: client.NewClient(server.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)}
+	source := func(url string) SourcesBundleSourceModel {
+		return SourcesBundleSourceModel{
+			ID:         types.StringUnknown(),
+			Type:       types.StringValue("REST"),
+			SourceURL:  types.StringValue(url),
+			APITimeout: types.Int64Value(3000),
+			Enabled:    types.BoolValue(true),
+		}
+	}
+	data := SourcesBundleResourceModel{
+		ApplicationID: types.StringValue("app-1"),
+		AdoptExisting: types.BoolValue(false),
+		Sources: map[string]SourcesBundleSourceModel{
+			"billing": source("https://billing.example.com"),
+			"orders":  source("https://orders.example.com"),
+		},
+	}
+
+	// A same-named source is only taken over with adopt_existing
+	var diags diag.Diagnostics
+	result := r.reconcile(ctx, data, nil, &diags)
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Source Already Exists" {
+		t.Fatalf("expected a 'Source Already Exists' error, got %v", diags)
+	}
+	if _, ok := result["orders"]; !ok || len(result) != 1 {
+		t.Fatalf("expected only the created source to be tracked, got %+v", result)
+	}
+
+	data.AdoptExisting = types.BoolValue(true)
+	diags = diag.Diagnostics{}
+	result = r.reconcile(ctx, data, result, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if result["billing"].ID.ValueString() != existingID || result["billing"].SourceURL.ValueString() != "https://billing.example.com" {
+		t.Errorf("expected the existing source to be adopted and updated, got %+v", result["billing"])
+	}
+
+	// Unchanged sources are not written and removed sources are deleted, without listing again
+	before := len(server.Requests())
+	delete(data.Sources, "orders")
+	data.Sources["billing"] = result["billing"]
+	diags = diag.Diagnostics{}
+	result = r.reconcile(ctx, data, result, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	requests := server.Requests()[before:]
+	if len(requests) != 1 || requests[0].Method != http.MethodDelete {
+		t.Errorf("expected a single delete request, got %+v", requests)
+	}
+	if len(result) != 1 || len(server.List(clienttest.Sources)) != 1 {
+		t.Errorf("expected one remaining source, got %+v", result)
+	}
+}
+
+func TestSourcesBundleSourceEqual(t *testing.T) {
+	state := SourcesBundleSourceModel{
+		ID:         types.StringValue("src-1"),
+		Type:       types.StringValue("REST"),
+		SourceURL:  types.StringValue("https://api.example.com"),
+		APITimeout: types.Int64Value(3000),
+		Enabled:    types.BoolValue(true),
+	}
+
+	planned := state
+	planned.ID = types.StringUnknown()
+	if !sourcesBundleSourceEqual(state, planned) {
+		t.Errorf("expected sources differing only in ID to be equal")
+	}
+
+	planned.APITimeout = types.Int64Value(5000)
+	if sourcesBundleSourceEqual(state, planned) {
+		t.Errorf("expected sources with different timeouts to differ")
+	}
+}
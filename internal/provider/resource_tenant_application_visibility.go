@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TenantApplicationVisibilityResource{}
+var _ resource.ResourceWithImportState = &TenantApplicationVisibilityResource{}
+var _ resource.ResourceWithMoveState = &TenantApplicationVisibilityResource{}
+var _ resource.ResourceWithUpgradeState = &TenantApplicationVisibilityResource{}
+
+func NewTenantApplicationVisibilityResource() resource.Resource {
+	return &TenantApplicationVisibilityResource{}
+}
+
+// TenantApplicationVisibilityResource defines the resource implementation.
+type TenantApplicationVisibilityResource struct {
+	client *client.Client
+}
+
+// TenantApplicationVisibilityResourceModel describes the resource data model.
+type TenantApplicationVisibilityResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	TenantID types.String `tfsdk:"tenant_id"`
+	AppIDs   types.Set    `tfsdk:"app_ids"`
+}
+
+func (r *TenantApplicationVisibilityResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tenant_application_visibility"
+}
+
+func (r *TenantApplicationVisibilityResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Makes applications visible to a tenant, e.g. when onboarding a customer. Only the listed applications are managed, so other applications assigned to the tenant are left untouched.",
+		Version:     0,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The tenant ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tenant_id": schema.StringAttribute{
+				Description: "The ID of the tenant. Changing this forces a new resource to be created.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"app_ids": schema.SetAttribute{
+				Description: "The IDs of the applications visible to the tenant. Applications assigned to the tenant outside this resource are left untouched.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *TenantApplicationVisibilityResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TenantApplicationVisibilityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data TenantApplicationVisibilityResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appIDs := expandStringSet(ctx, data.AppIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tenantID := data.TenantID.ValueString()
+	if err := r.client.AssignTenantApplications(ctx, tenantID, appIDs); err != nil {
+		addClientError(&resp.Diagnostics, "Unable to assign applications to tenant", err)
+		return
+	}
+
+	data.ID = types.StringValue(tenantID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TenantApplicationVisibilityResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data TenantApplicationVisibilityResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assigned, err := r.client.GetTenantApplications(ctx, data.TenantID.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read tenant applications", err)
+		return
+	}
+
+	// Imported resources take over all applications assigned to the tenant
+	var appIDs []string
+	if data.AppIDs.IsNull() {
+		appIDs = append([]string{}, assigned...)
+		sort.Strings(appIDs)
+	} else {
+		// Only track the applications this resource assigned, so other assignments are not reported as drift
+		appIDs = attachedApplications(expandStringSet(ctx, data.AppIDs, &resp.Diagnostics), assigned)
+	}
+	if len(appIDs) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	values, diags := types.SetValueFrom(ctx, types.StringType, appIDs)
+	resp.Diagnostics.Append(diags...)
+	data.AppIDs = values
+	data.ID = data.TenantID
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TenantApplicationVisibilityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data, state TenantApplicationVisibilityResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned := expandStringSet(ctx, data.AppIDs, &resp.Diagnostics)
+	current := expandStringSet(ctx, state.AppIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tenantID := data.TenantID.ValueString()
+	if removed := subtractStrings(current, planned); len(removed) > 0 {
+		if err := r.client.UnassignTenantApplications(ctx, tenantID, removed); err != nil {
+			addClientError(&resp.Diagnostics, "Unable to unassign applications from tenant", err)
+			return
+		}
+	}
+
+	if err := r.client.AssignTenantApplications(ctx, tenantID, planned); err != nil {
+		addClientError(&resp.Diagnostics, "Unable to assign applications to tenant", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TenantApplicationVisibilityResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data TenantApplicationVisibilityResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appIDs := expandStringSet(ctx, data.AppIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UnassignTenantApplications(ctx, data.TenantID.ValueString(), appIDs)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to unassign applications from tenant", err)
+		return
+	}
+}
+
+func (r *TenantApplicationVisibilityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant_id"), req.ID)...)
+}
+
+func (r *TenantApplicationVisibilityResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
+
+func (r *TenantApplicationVisibilityResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/clienttest"
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestTenantApplicationVisibilityResourceHasExpectedSchema(t *testing.T) {
+	r := NewTenantApplicationVisibilityResource()
+
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	for _, attr := range []string{"id", "tenant_id", "app_ids"} {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in schema", attr)
+		}
+	}
+}
+
+func TestTenantApplicationVisibilityResourceMetadata(t *testing.T) {
+	r := NewTenantApplicationVisibilityResource()
+
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_tenant_application_visibility"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func TestTenantApplicationVisibilityLeavesOtherApplications(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	server.Put(clienttest.TenantApplications, clienttest.Object{"id": "tenant-1", "appIds": []interface{}{"app-1"}})
+
+	r := &TenantApplicationVisibilityResource{client: client.NewClient(server.URL, clienttest.DefaultClientID, clienttest.DefaultSecret)}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := plan.Set(ctx, &TenantApplicationVisibilityResourceModel{
+		ID:       types.StringUnknown(),
+		TenantID: types.StringValue("tenant-1"),
+		AppIDs:   types.SetValueMust(types.StringType, []attr.Value{types.StringValue("app-2")}),
+	})
+	if diags.HasError() {
+		t.Fatalf("failed to build plan: %v", diags)
+	}
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: plan.Raw}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+	}
+
+	if appIDs := server.Get(clienttest.TenantApplications, "tenant-1")["appIds"]; !reflect.DeepEqual(appIDs, []interface{}{"app-1", "app-2"}) {
+		t.Errorf("expected app-2 to be assigned next to app-1, got %v", appIDs)
+	}
+
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+	}
+	var data TenantApplicationVisibilityResourceModel
+	readResp.State.Get(ctx, &data)
+	if appIDs := expandStringSet(ctx, data.AppIDs, &readResp.Diagnostics); !reflect.DeepEqual(appIDs, []string{"app-2"}) {
+		t.Errorf("expected only app-2 to be tracked, got %v", appIDs)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", deleteResp.Diagnostics)
+	}
+
+	if appIDs := server.Get(clienttest.TenantApplications, "tenant-1")["appIds"]; !reflect.DeepEqual(appIDs, []interface{}{"app-1"}) {
+		t.Errorf("expected only app-2 to be unassigned, got %v", appIDs)
+	}
+}
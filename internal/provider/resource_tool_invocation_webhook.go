@@ -0,0 +1,295 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// toolHookFailMethods are the ways a tool call can be handled when its hook fails
+var toolHookFailMethods = []string{"OPEN", "CLOSE"}
+
+// maxToolHookCodeLength is the maximum length of hook code accepted by the API
+const maxToolHookCodeLength = 102400
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ToolInvocationWebhookResource{}
+var _ resource.ResourceWithImportState = &ToolInvocationWebhookResource{}
+var _ resource.ResourceWithValidateConfig = &ToolInvocationWebhookResource{}
+var _ resource.ResourceWithMoveState = &ToolInvocationWebhookResource{}
+var _ resource.ResourceWithUpgradeState = &ToolInvocationWebhookResource{}
+
+func NewToolInvocationWebhookResource() resource.Resource {
+	return &ToolInvocationWebhookResource{}
+}
+
+// ToolInvocationWebhookResource defines the resource implementation.
+type ToolInvocationWebhookResource struct {
+	client *client.Client
+}
+
+// ToolInvocationWebhookResourceModel describes the resource data model.
+type ToolInvocationWebhookResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	ApplicationID   types.String `tfsdk:"application_id"`
+	Code            types.String `tfsdk:"code"`
+	Runtime         types.String `tfsdk:"runtime"`
+	FailMethod      types.String `tfsdk:"fail_method"`
+	Timeout         types.Int64  `tfsdk:"timeout"`
+	InternalToolIDs types.Set    `tfsdk:"internal_tool_ids"`
+	Enabled         types.Bool   `tfsdk:"enabled"`
+}
+
+func (r *ToolInvocationWebhookResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tool_invocation_webhook"
+}
+
+func (r *ToolInvocationWebhookResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the hook Frontegg runs when agents call the tools of an application, e.g. to notify an external system of agent actions in real time. An application has at most one tool call hook.",
+		Version:     0,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The hook ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"application_id": schema.StringAttribute{
+				Description: "The application whose tool calls run the hook. Changing this forces a new resource to be created.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"code": schema.StringAttribute{
+				Description: "The hook code, at most 102400 characters.",
+				Required:    true,
+			},
+			"runtime": schema.StringAttribute{
+				Description: "The runtime the hook code runs in. Valid values: NODE_20. Defaults to NODE_20. Changing this forces a new resource to be created.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("NODE_20"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"fail_method": schema.StringAttribute{
+				Description: "How tool calls are handled when the hook fails. Valid values: " + strings.Join(toolHookFailMethods, ", ") + ".",
+				Required:    true,
+			},
+			"timeout": schema.Int64Attribute{
+				Description: "The hook timeout, from 5 to 10. The API default is used when not set.",
+				Optional:    true,
+			},
+			"internal_tool_ids": schema.SetAttribute{
+				Description: "The internal tool IDs whose calls run the hook. When unset, calls of all tools of the application run the hook.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the hook runs. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+func (r *ToolInvocationWebhookResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ToolInvocationWebhookResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Code.IsUnknown() && len(data.Code.ValueString()) > maxToolHookCodeLength {
+		resp.Diagnostics.AddAttributeError(path.Root("code"), "Invalid Hook Code", "The hook code must be at most 102400 characters long.")
+	}
+
+	if !data.FailMethod.IsUnknown() && !data.FailMethod.IsNull() && !slices.Contains(toolHookFailMethods, data.FailMethod.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("fail_method"),
+			"Invalid Fail Method",
+			"Unsupported fail method "+data.FailMethod.ValueString()+". Valid values: "+strings.Join(toolHookFailMethods, ", ")+".",
+		)
+	}
+
+	if !data.Timeout.IsUnknown() && !data.Timeout.IsNull() && (data.Timeout.ValueInt64() < 5 || data.Timeout.ValueInt64() > 10) {
+		resp.Diagnostics.AddAttributeError(path.Root("timeout"), "Invalid Hook Timeout", "The hook timeout must be between 5 and 10.")
+	}
+}
+
+func (r *ToolInvocationWebhookResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ToolInvocationWebhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data ToolInvocationWebhookResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hook, err := r.client.CreateInternalToolHook(ctx, client.CreateInternalToolHookRequest{
+		AppID:           data.ApplicationID.ValueString(),
+		IsActive:        data.Enabled.ValueBool(),
+		HookType:        client.ToolHookTypeCallTool,
+		Code:            data.Code.ValueString(),
+		Runtime:         data.Runtime.ValueString(),
+		FailMethod:      data.FailMethod.ValueString(),
+		Timeout:         int(data.Timeout.ValueInt64()),
+		InternalToolIDs: expandStringSet(ctx, data.InternalToolIDs, &resp.Diagnostics),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to create tool invocation hook", err)
+		return
+	}
+
+	flattenToolInvocationWebhook(ctx, hook, &data, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ToolInvocationWebhookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data ToolInvocationWebhookResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hook, err := r.client.GetInternalToolHook(ctx, data.ApplicationID.ValueString(), client.ToolHookTypeCallTool)
+	if errors.Is(err, client.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read tool invocation hook", err)
+		return
+	}
+
+	flattenToolInvocationWebhook(ctx, hook, &data, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ToolInvocationWebhookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data ToolInvocationWebhookResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hook, err := r.client.UpdateInternalToolHook(ctx, client.UpdateInternalToolHookRequest{
+		AppID:           data.ApplicationID.ValueString(),
+		HookType:        client.ToolHookTypeCallTool,
+		IsActive:        data.Enabled.ValueBool(),
+		Code:            data.Code.ValueString(),
+		FailMethod:      data.FailMethod.ValueString(),
+		Timeout:         int(data.Timeout.ValueInt64()),
+		InternalToolIDs: nonNilStrings(expandStringSet(ctx, data.InternalToolIDs, &resp.Diagnostics)),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to update tool invocation hook", err)
+		return
+	}
+
+	flattenToolInvocationWebhook(ctx, hook, &data, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ToolInvocationWebhookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data ToolInvocationWebhookResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteInternalToolHook(ctx, data.ApplicationID.ValueString(), client.ToolHookTypeCallTool)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to delete tool invocation hook", err)
+		return
+	}
+}
+
+// ImportState imports the tool call hook of an application by application ID
+func (r *ToolInvocationWebhookResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("application_id"), req, resp)
+}
+
+func (r *ToolInvocationWebhookResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
+
+func (r *ToolInvocationWebhookResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+// flattenToolInvocationWebhook maps a hook response onto the resource model. The hook settings are
+// read from the prehook details when the API returns them; otherwise the configured values are kept.
+func flattenToolInvocationWebhook(ctx context.Context, hook *client.InternalToolHook, data *ToolInvocationWebhookResourceModel, diags *diag.Diagnostics) {
+	data.ID = types.StringValue(hook.ID)
+	data.InternalToolIDs = flattenOptionalStringSet(ctx, hook.InternalToolIDs, data.InternalToolIDs, diags)
+
+	if code, ok := hook.Prehook["code"].(string); ok {
+		data.Code = types.StringValue(code)
+	}
+	if runtime, ok := hook.Prehook["runtime"].(string); ok {
+		data.Runtime = types.StringValue(runtime)
+	}
+	if failMethod, ok := hook.Prehook["failMethod"].(string); ok {
+		data.FailMethod = types.StringValue(failMethod)
+	}
+	if timeout, ok := hook.Prehook["timeout"].(float64); ok {
+		data.Timeout = flattenOptionalInt64(int(timeout), data.Timeout)
+	}
+	if isActive, ok := hook.Prehook["isActive"].(bool); ok {
+		data.Enabled = types.BoolValue(isActive)
+	}
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestToolInvocationWebhookResourceHasExpectedSchema(t *testing.T) {
+	r := NewToolInvocationWebhookResource()
+
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	for _, attr := range []string{"id", "application_id", "code", "runtime", "fail_method", "timeout", "internal_tool_ids", "enabled"} {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in schema", attr)
+		}
+	}
+}
+
+func TestToolInvocationWebhookResourceMetadata(t *testing.T) {
+	r := NewToolInvocationWebhookResource()
+
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_tool_invocation_webhook"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func TestToolInvocationWebhookValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := &ToolInvocationWebhookResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	validate := func(code, failMethod string, timeout types.Int64) diag.Diagnostics {
+		config := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		diags := config.Set(ctx, &ToolInvocationWebhookResourceModel{
+			ID:              types.StringNull(),
+			ApplicationID:   types.StringValue("app-1"),
+			Code:            types.StringValue(code),
+			Runtime:         types.StringNull(),
+			FailMethod:      types.StringValue(failMethod),
+			Timeout:         timeout,
+			InternalToolIDs: types.SetNull(types.StringType),
+			Enabled:         types.BoolNull(),
+		})
+		if diags.HasError() {
+			t.Fatalf("failed to build config: %v", diags)
+		}
+
+		resp := &resource.ValidateConfigResponse{}
+		r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, resp)
+		return resp.Diagnostics
+	}
+
+	code := "export default async (event) => ({ allow: true })"
+	if diags := validate(code, "OPEN", types.Int64Value(5)); diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+	if diags := validate(code, "IGNORE", types.Int64Null()); len(diags) != 1 {
+		t.Errorf("expected one error for an unsupported fail method, got %v", diags)
+	}
+	if diags := validate(code, "CLOSE", types.Int64Value(30)); len(diags) != 1 {
+		t.Errorf("expected one error for a timeout out of range, got %v", diags)
+	}
+	if diags := validate(strings.Repeat("x", maxToolHookCodeLength+1), "CLOSE", types.Int64Null()); len(diags) != 1 {
+		t.Errorf("expected one error for code that is too long, got %v", diags)
+	}
+}
+
+func TestFlattenToolInvocationWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	var diags diag.Diagnostics
+	data := ToolInvocationWebhookResourceModel{
+		Code:            types.StringValue("configured"),
+		FailMethod:      types.StringValue("OPEN"),
+		Timeout:         types.Int64Null(),
+		InternalToolIDs: types.SetNull(types.StringType),
+		Enabled:         types.BoolValue(true),
+	}
+	flattenToolInvocationWebhook(ctx, &client.InternalToolHook{
+		ID:       "hook-1",
+		HookType: client.ToolHookTypeCallTool,
+		Prehook:  map[string]interface{}{"failMethod": "CLOSE", "isActive": false},
+	}, &data, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if data.ID.ValueString() != "hook-1" || data.FailMethod.ValueString() != "CLOSE" || data.Enabled.ValueBool() {
+		t.Errorf("expected the returned hook settings, got %+v", data)
+	}
+	if data.Code.ValueString() != "configured" || !data.Timeout.IsNull() {
+		t.Errorf("expected settings missing from the response to be kept, got %+v", data)
+	}
+	if !data.InternalToolIDs.IsNull() {
+		t.Errorf("expected internal_tool_ids to stay unset, got %s", data.InternalToolIDs)
+	}
+}
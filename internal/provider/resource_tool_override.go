@@ -0,0 +1,432 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ToolOverrideResource{}
+var _ resource.ResourceWithMoveState = &ToolOverrideResource{}
+var _ resource.ResourceWithUpgradeState = &ToolOverrideResource{}
+
+func NewToolOverrideResource() resource.Resource {
+	return &ToolOverrideResource{}
+}
+
+// ToolOverrideResource defines the resource implementation.
+type ToolOverrideResource struct {
+	client *client.Client
+}
+
+// ToolOverrideResourceModel describes the resource data model.
+type ToolOverrideResourceModel struct {
+	ID            types.String                          `tfsdk:"id"`
+	ApplicationID types.String                          `tfsdk:"application_id"`
+	SourceID      types.String                          `tfsdk:"source_id"`
+	ToolName      types.String                          `tfsdk:"tool_name"`
+	Description   types.String                          `tfsdk:"description"`
+	Parameters    map[string]ToolParameterOverrideModel `tfsdk:"parameters"`
+	Redacted      types.Set                             `tfsdk:"redacted_parameters"`
+	ToolID        types.String                          `tfsdk:"tool_id"`
+}
+
+// ToolParameterOverrideModel describes the overrides of a single tool parameter.
+type ToolParameterOverrideModel struct {
+	Description types.String `tfsdk:"description"`
+	Default     types.String `tfsdk:"default"`
+	Required    types.Bool   `tfsdk:"required"`
+}
+
+func (r *ToolOverrideResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tool_override"
+}
+
+func (r *ToolOverrideResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Overrides parts of the input schema of a single imported tool, such as parameter descriptions, defaults and required flags. Overrides reset by a later import of the source are detected and applied again.",
+		Version:     0,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier in the format app_id:source_id:tool_name.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"application_id": schema.StringAttribute{
+				Description: "The ID of the application.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_id": schema.StringAttribute{
+				Description: "The ID of the source the tool was imported into.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tool_name": schema.StringAttribute{
+				Description: "The name of the tool to override.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Replaces the description of the tool.",
+				Optional:    true,
+			},
+			"parameters": schema.MapNestedAttribute{
+				Description: "Overrides of the tool's input parameters, keyed by parameter name. Unset attributes keep the imported values.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"description": schema.StringAttribute{
+							Description: "Replaces the description of the parameter.",
+							Optional:    true,
+						},
+						"default": schema.StringAttribute{
+							Description: "The default value of the parameter as JSON, e.g. jsonencode(25) or jsonencode(\"asc\").",
+							Optional:    true,
+						},
+						"required": schema.BoolAttribute{
+							Description: "Whether the parameter is required.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"redacted_parameters": schema.SetAttribute{
+				Description: "Names of input parameters whose values are redacted in invocation logs and audits, e.g. card numbers or access tokens passed by agents. Complements masking policies, which redact responses. Replaces the redacted parameters of the tool when set.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"tool_id": schema.StringAttribute{
+				Description: "The ID of the overridden tool.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *ToolOverrideResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ToolOverrideResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data ToolOverrideResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ToolOverrideResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data ToolOverrideResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tools, err := r.client.GetToolsBySource(ctx, data.ApplicationID.ValueString(), data.SourceID.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read tools", err)
+		return
+	}
+
+	tool := findToolByName(tools, data.ToolName.ValueString())
+	if tool == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Values reset by a re-import show up as drift, so the next apply overrides them again
+	flattenToolOverride(*tool, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ToolOverrideResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data ToolOverrideResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ToolOverrideResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data ToolOverrideResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The imported values are not kept, so the tool is restored by the next import of the source
+	tflog.Info(ctx, "Leaving tool override in place on destroy; re-import the source to restore the tool", map[string]interface{}{
+		"tool_name": data.ToolName.ValueString(),
+	})
+}
+
+func (r *ToolOverrideResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
+
+func (r *ToolOverrideResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+// apply upserts the tool with the overrides of data applied and sets the computed attributes
+func (r *ToolOverrideResource) apply(ctx context.Context, data *ToolOverrideResourceModel, diags *diag.Diagnostics) {
+	appID := data.ApplicationID.ValueString()
+	sourceID := data.SourceID.ValueString()
+	toolName := data.ToolName.ValueString()
+
+	source, err := r.client.GetSourceByID(ctx, appID, sourceID)
+	if errors.Is(err, client.ErrNotFound) {
+		diags.AddAttributeError(path.Root("source_id"), "Source Not Found", "No source with ID "+sourceID+" exists in application "+appID)
+		return
+	}
+	if err != nil {
+		addClientError(diags, "Unable to read source", err)
+		return
+	}
+
+	tools, err := r.client.GetToolsBySource(ctx, appID, sourceID)
+	if err != nil {
+		addClientError(diags, "Unable to read tools", err)
+		return
+	}
+
+	tool := findToolByName(tools, toolName)
+	if tool == nil {
+		diags.AddAttributeError(path.Root("tool_name"), "Tool Not Found", fmt.Sprintf("No tool named %q exists in source %s. Import the source before overriding its tools.", toolName, sourceID))
+		return
+	}
+
+	if err := applyToolOverride(tool, data); err != nil {
+		diags.AddError("Invalid Tool Override", err.Error())
+		return
+	}
+
+	_, err = r.client.UpsertTools(ctx, client.UpsertToolsRequest{
+		AppID:    appID,
+		ToolType: source.Type,
+		Tools:    []client.InternalTool{*tool},
+	})
+	if err != nil {
+		addClientError(diags, "Unable to update tool", err)
+		return
+	}
+
+	data.ID = types.StringValue(appID + ":" + sourceID + ":" + toolName)
+	data.ToolID = types.StringValue(tool.ID)
+}
+
+// findToolByName returns the tool with the given name, or nil if there is none
+func findToolByName(tools []client.InternalTool, name string) *client.InternalTool {
+	for i := range tools {
+		if tools[i].Name == name {
+			return &tools[i]
+		}
+	}
+	return nil
+}
+
+// applyToolOverride sets the overridden description and parameters on a tool's input schema
+func applyToolOverride(tool *client.InternalTool, data *ToolOverrideResourceModel) error {
+	if !data.Description.IsNull() && !data.Description.IsUnknown() {
+		tool.Description = data.Description.ValueString()
+	}
+
+	properties, _ := tool.Schema["properties"].(map[string]interface{})
+
+	if !data.Redacted.IsNull() && !data.Redacted.IsUnknown() {
+		redacted := []string{}
+		for _, value := range data.Redacted.Elements() {
+			name := value.(types.String).ValueString()
+			if _, ok := properties[name]; !ok {
+				return fmt.Errorf("tool %q has no parameter %q to redact", tool.Name, name)
+			}
+			redacted = append(redacted, name)
+		}
+		sort.Strings(redacted)
+		tool.RedactedParameters = redacted
+	}
+
+	if len(data.Parameters) == 0 {
+		return nil
+	}
+
+	required := schemaRequiredNames(tool.Schema)
+	requiredChanged := false
+
+	names := make([]string, 0, len(data.Parameters))
+	for name := range data.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		override := data.Parameters[name]
+		property, ok := properties[name].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("tool %q has no parameter %q", tool.Name, name)
+		}
+
+		if !override.Description.IsNull() && !override.Description.IsUnknown() {
+			property["description"] = override.Description.ValueString()
+		}
+		if !override.Default.IsNull() && !override.Default.IsUnknown() {
+			var value interface{}
+			if err := json.Unmarshal([]byte(override.Default.ValueString()), &value); err != nil {
+				return fmt.Errorf("default of parameter %q is not valid JSON: %w", name, err)
+			}
+			property["default"] = value
+		}
+		if !override.Required.IsNull() && !override.Required.IsUnknown() {
+			required[name] = override.Required.ValueBool()
+			requiredChanged = true
+		}
+	}
+	if !requiredChanged {
+		return nil
+	}
+
+	names = names[:0]
+	for name, isRequired := range required {
+		if isRequired {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	requiredList := make([]interface{}, len(names))
+	for i, name := range names {
+		requiredList[i] = name
+	}
+	tool.Schema["required"] = requiredList
+
+	return nil
+}
+
+// flattenToolOverride replaces the overridden values in data with the tool's current values.
+// Values that are not overridden stay null, and defaults equal to the configured JSON keep
+// their configured formatting.
+func flattenToolOverride(tool client.InternalTool, data *ToolOverrideResourceModel) {
+	data.ToolID = types.StringValue(tool.ID)
+	if !data.Description.IsNull() {
+		data.Description = types.StringValue(tool.Description)
+	}
+	if !data.Redacted.IsNull() {
+		redacted := make([]attr.Value, 0, len(tool.RedactedParameters))
+		for _, name := range tool.RedactedParameters {
+			redacted = append(redacted, types.StringValue(name))
+		}
+		data.Redacted = types.SetValueMust(types.StringType, redacted)
+	}
+
+	properties, _ := tool.Schema["properties"].(map[string]interface{})
+	required := schemaRequiredNames(tool.Schema)
+
+	for name, override := range data.Parameters {
+		property, _ := properties[name].(map[string]interface{})
+
+		if !override.Description.IsNull() {
+			if description, ok := property["description"].(string); ok {
+				override.Description = types.StringValue(description)
+			} else {
+				override.Description = types.StringNull()
+			}
+		}
+		if !override.Default.IsNull() {
+			value, ok := property["default"]
+			var configured interface{}
+			_ = json.Unmarshal([]byte(override.Default.ValueString()), &configured)
+			switch {
+			case !ok:
+				override.Default = types.StringNull()
+			case !reflect.DeepEqual(value, configured):
+				encoded, _ := json.Marshal(value)
+				override.Default = types.StringValue(string(encoded))
+			}
+		}
+		if !override.Required.IsNull() {
+			override.Required = types.BoolValue(required[name])
+		}
+
+		data.Parameters[name] = override
+	}
+}
+
+// schemaRequiredNames returns the required property names of an input schema as a set
+func schemaRequiredNames(inputSchema map[string]interface{}) map[string]bool {
+	required := map[string]bool{}
+	names, _ := inputSchema["required"].([]interface{})
+	for _, name := range names {
+		if s, ok := name.(string); ok {
+			required[s] = true
+		}
+	}
+	return required
+}
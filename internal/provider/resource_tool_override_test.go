@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestToolOverrideResourceHasExpectedSchema(t *testing.T) {
+	r := NewToolOverrideResource()
+
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	for _, attr := range []string{"id", "application_id", "source_id", "tool_name", "description", "parameters", "redacted_parameters", "tool_id"} {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected attribute '%s' in schema", attr)
+		}
+	}
+}
+
+func TestToolOverrideResourceMetadata(t *testing.T) {
+	r := NewToolOverrideResource()
+
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "agentlink"}, resp)
+
+	expected := "agentlink_tool_override"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func testOverrideTool() client.InternalTool {
+	return client.InternalTool{
+		ID:          "tool-1",
+		Name:        "listOrders",
+		Description: "List orders",
+		Schema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"limit":  map[string]interface{}{"type": "integer"},
+				"status": map[string]interface{}{"type": "string", "description": "status"},
+			},
+			"required": []interface{}{"status"},
+		},
+	}
+}
+
+func TestApplyToolOverride(t *testing.T) {
+	tool := testOverrideTool()
+	data := &ToolOverrideResourceModel{
+		Description: types.StringValue("List the orders of the current customer"),
+		Parameters: map[string]ToolParameterOverrideModel{
+			"limit": {
+				Description: types.StringValue("Maximum number of orders"),
+				Default:     types.StringValue("25"),
+				Required:    types.BoolValue(true),
+			},
+			"status": {
+				Description: types.StringNull(),
+				Default:     types.StringNull(),
+				Required:    types.BoolValue(false),
+			},
+		},
+	}
+
+	if err := applyToolOverride(&tool, data); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if tool.Description != "List the orders of the current customer" {
+		t.Errorf("expected the tool description to be replaced, got %q", tool.Description)
+	}
+	limit := tool.Schema["properties"].(map[string]interface{})["limit"].(map[string]interface{})
+	if limit["description"] != "Maximum number of orders" || limit["default"] != float64(25) {
+		t.Errorf("expected limit description and default, got %v", limit)
+	}
+	status := tool.Schema["properties"].(map[string]interface{})["status"].(map[string]interface{})
+	if status["description"] != "status" {
+		t.Errorf("expected status description to be kept, got %v", status)
+	}
+	if !reflect.DeepEqual(tool.Schema["required"], []interface{}{"limit"}) {
+		t.Errorf("expected only limit to be required, got %v", tool.Schema["required"])
+	}
+
+	data.Parameters = map[string]ToolParameterOverrideModel{
+		"missing": {Description: types.StringValue("x"), Default: types.StringNull(), Required: types.BoolNull()},
+	}
+	if err := applyToolOverride(&tool, data); err == nil {
+		t.Error("expected an error for an unknown parameter")
+	}
+
+	data.Parameters = map[string]ToolParameterOverrideModel{
+		"limit": {Description: types.StringNull(), Default: types.StringValue("not json"), Required: types.BoolNull()},
+	}
+	if err := applyToolOverride(&tool, data); err == nil {
+		t.Error("expected an error for an invalid default")
+	}
+}
+
+func TestFlattenToolOverrideDetectsReimport(t *testing.T) {
+	data := &ToolOverrideResourceModel{
+		Description: types.StringNull(),
+		Parameters: map[string]ToolParameterOverrideModel{
+			"limit": {
+				Description: types.StringValue("Maximum number of orders"),
+				Default:     types.StringValue("{ \"value\": 25 }"),
+				Required:    types.BoolValue(true),
+			},
+		},
+	}
+
+	applied := testOverrideTool()
+	if err := applyToolOverride(&applied, data); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// An applied override reads back unchanged, keeping the configured JSON formatting
+	flattenToolOverride(applied, data)
+	limit := data.Parameters["limit"]
+	if limit.Description.ValueString() != "Maximum number of orders" || limit.Default.ValueString() != "{ \"value\": 25 }" || !limit.Required.ValueBool() {
+		t.Errorf("expected the override to read back unchanged, got %+v", limit)
+	}
+	if !data.Description.IsNull() {
+		t.Errorf("expected the tool description to stay null, got %v", data.Description)
+	}
+
+	// A re-import resets the tool, which shows up as drift
+	flattenToolOverride(testOverrideTool(), data)
+	limit = data.Parameters["limit"]
+	if !limit.Description.IsNull() || !limit.Default.IsNull() || limit.Required.ValueBool() {
+		t.Errorf("expected the imported values, got %+v", limit)
+	}
+	if data.ToolID.ValueString() != "tool-1" {
+		t.Errorf("expected tool_id tool-1, got %v", data.ToolID)
+	}
+}
+
+func TestToolOverrideRedactedParameters(t *testing.T) {
+	data := &ToolOverrideResourceModel{
+		Description: types.StringNull(),
+		Redacted:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("status"), types.StringValue("limit")}),
+	}
+
+	applied := testOverrideTool()
+	if err := applyToolOverride(&applied, data); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(applied.RedactedParameters, []string{"limit", "status"}) {
+		t.Errorf("expected limit and status to be redacted, got %v", applied.RedactedParameters)
+	}
+
+	flattenToolOverride(applied, data)
+	if len(data.Redacted.Elements()) != 2 {
+		t.Errorf("expected the redacted parameters to read back unchanged, got %s", data.Redacted)
+	}
+
+	// A re-import resets the redacted parameters, which shows up as drift
+	flattenToolOverride(testOverrideTool(), data)
+	if data.Redacted.IsNull() || len(data.Redacted.Elements()) != 0 {
+		t.Errorf("expected no redacted parameters after a re-import, got %s", data.Redacted)
+	}
+
+	data.Redacted = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("cardNumber")})
+	if err := applyToolOverride(&applied, data); err == nil {
+		t.Error("expected an error for redacting an unknown parameter")
+	}
+}
@@ -4,19 +4,30 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ToolsImportResource{}
+var _ resource.ResourceWithValidateConfig = &ToolsImportResource{}
+var _ resource.ResourceWithMoveState = &ToolsImportResource{}
+var _ resource.ResourceWithUpgradeState = &ToolsImportResource{}
 
 func NewToolsImportResource() resource.Resource {
 	return &ToolsImportResource{}
@@ -29,13 +40,26 @@ type ToolsImportResource struct {
 
 // ToolsImportResourceModel describes the resource data model.
 type ToolsImportResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	ApplicationID types.String `tfsdk:"application_id"`
-	SourceID      types.String `tfsdk:"source_id"`
-	SchemaFile    types.String `tfsdk:"schema_file"`
-	SchemaType    types.String `tfsdk:"schema_type"`
-	SchemaHash    types.String `tfsdk:"schema_hash"`
-	ToolsCount    types.Int64  `tfsdk:"tools_count"`
+	ID              types.String `tfsdk:"id"`
+	ApplicationID   types.String `tfsdk:"application_id"`
+	SourceID        types.String `tfsdk:"source_id"`
+	SchemaFile      types.String `tfsdk:"schema_file"`
+	SchemaType      types.String `tfsdk:"schema_type"`
+	SchemaFragments types.List   `tfsdk:"schema_fragments"`
+	SchemaHash      types.String `tfsdk:"schema_hash"`
+	CanonicalHash   types.Bool   `tfsdk:"canonical_hash"`
+	ToolsCount      types.Int64  `tfsdk:"tools_count"`
+
+	NamingStrategy types.String `tfsdk:"naming_strategy"`
+	NamePrefix     types.String `tfsdk:"name_prefix"`
+	NameSuffix     types.String `tfsdk:"name_suffix"`
+
+	IncludeSummary       types.Bool  `tfsdk:"include_summary"`
+	IncludeDescription   types.Bool  `tfsdk:"include_description"`
+	IncludeParameterDocs types.Bool  `tfsdk:"include_parameter_docs"`
+	MaxDescriptionLength types.Int64 `tfsdk:"max_description_length"`
+
+	Tags types.Set `tfsdk:"tags"`
 }
 
 func (r *ToolsImportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -45,6 +69,7 @@ func (r *ToolsImportResource) Metadata(ctx context.Context, req resource.Metadat
 func (r *ToolsImportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Imports tools from an OpenAPI or GraphQL schema file.",
+		Version:     0,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The import ID (composite of app_id and source_id).",
@@ -68,8 +93,13 @@ func (r *ToolsImportResource) Schema(ctx context.Context, req resource.SchemaReq
 				},
 			},
 			"schema_file": schema.StringAttribute{
-				Description: "Path to the OpenAPI (JSON/YAML) or GraphQL schema file.",
-				Required:    true,
+				Description: "Path to the OpenAPI (JSON/YAML) or GraphQL schema file. Exactly one of schema_file and schema_fragments must be set.",
+				Optional:    true,
+			},
+			"schema_fragments": schema.ListAttribute{
+				Description: "GraphQL SDL fragments, e.g. the subgraph schemas of a federated graph, merged into a single schema before import. Types and their extensions are merged field by field; conflicting definitions are an error. Only valid with schema_type graphql.",
+				Optional:    true,
+				ElementType: types.StringType,
 			},
 			"schema_type": schema.StringAttribute{
 				Description: "The schema type. Valid values: openapi, graphql.",
@@ -78,18 +108,104 @@ func (r *ToolsImportResource) Schema(ctx context.Context, req resource.SchemaReq
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"naming_strategy": schema.StringAttribute{
+				Description: "How tool names are derived. Valid values: operation_id (default), method_path, summary_slug. Changing this re-creates the tools under their new names.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(client.NamingStrategyOperationID),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name_prefix": schema.StringAttribute{
+				Description: "Prefix added to every imported tool name. Useful to avoid collisions between sources. Changing this re-creates the tools under their new names.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name_suffix": schema.StringAttribute{
+				Description: "Suffix added to every imported tool name. Changing this re-creates the tools under their new names.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"include_summary": schema.BoolAttribute{
+				Description: "Whether the OpenAPI operation summary is included in tool descriptions. Defaults to true when another include flag is set. When none is set, the descriptions generated by the import endpoint are kept.",
+				Optional:    true,
+			},
+			"include_description": schema.BoolAttribute{
+				Description: "Whether the OpenAPI operation description is included in tool descriptions. Defaults to true when another include flag is set. When none is set, the descriptions generated by the import endpoint are kept.",
+				Optional:    true,
+			},
+			"include_parameter_docs": schema.BoolAttribute{
+				Description: "Whether OpenAPI parameter documentation is appended to tool descriptions. Defaults to false. When no include flag is set, the descriptions generated by the import endpoint are kept.",
+				Optional:    true,
+			},
+			"max_description_length": schema.Int64Attribute{
+				Description: "Maximum number of characters kept in tool descriptions. Longer descriptions are truncated. Unlimited when not set.",
+				Optional:    true,
+			},
+			"tags": schema.SetAttribute{
+				Description: "Tags set on every imported tool. Policies with matching tool_tags apply to the tools, including tools added by later imports.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"canonical_hash": schema.BoolAttribute{
+				Description: "Whether schema_hash is computed over a canonical form of OpenAPI documents, so whitespace, formatting and key-ordering changes do not trigger a re-import. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 			"schema_hash": schema.StringAttribute{
 				Description: "SHA256 hash of the schema file contents (used to detect changes).",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					schemaHashPlanModifier{},
+				},
 			},
 			"tools_count": schema.Int64Attribute{
 				Description: "Number of tools imported from the schema.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
 }
 
+func (r *ToolsImportResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ToolsImportResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.SchemaFile.IsUnknown() || data.SchemaFragments.IsUnknown() {
+		return
+	}
+
+	if data.SchemaFile.IsNull() == data.SchemaFragments.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("schema_file"),
+			"Invalid Schema Configuration",
+			"Exactly one of schema_file and schema_fragments must be set.",
+		)
+		return
+	}
+
+	if !data.SchemaFragments.IsNull() && !data.SchemaType.IsUnknown() && data.SchemaType.ValueString() != "graphql" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("schema_fragments"),
+			"Invalid Schema Configuration",
+			"schema_fragments can only be used with schema_type 'graphql'.",
+		)
+	}
+}
+
 func (r *ToolsImportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -108,6 +224,9 @@ func (r *ToolsImportResource) Configure(ctx context.Context, req resource.Config
 }
 
 func (r *ToolsImportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data ToolsImportResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -115,16 +234,14 @@ func (r *ToolsImportResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	// Read schema file
-	schemaContent, err := os.ReadFile(data.SchemaFile.ValueString())
+	schemaContent, filename, err := toolsImportSchema(ctx, data)
 	if err != nil {
-		resp.Diagnostics.AddError("File Error", "Unable to read schema file: "+err.Error())
+		resp.Diagnostics.AddError("Schema Error", "Unable to read schema: "+err.Error())
 		return
 	}
 
 	// Calculate hash
-	hash := sha256.Sum256(schemaContent)
-	hashStr := hex.EncodeToString(hash[:])
+	hashStr := schemaHash(schemaContent, data.SchemaType.ValueString(), data.CanonicalHash.ValueBool())
 
 	// Determine source type based on schema type
 	var sourceType string
@@ -138,8 +255,12 @@ func (r *ToolsImportResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	opts := toolsImportOptions(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Import and upsert schema
-	filename := filepath.Base(data.SchemaFile.ValueString())
 	err = r.client.ImportAndUpsertSchema(
 		ctx,
 		data.ApplicationID.ValueString(),
@@ -147,9 +268,10 @@ func (r *ToolsImportResource) Create(ctx context.Context, req resource.CreateReq
 		sourceType,
 		schemaContent,
 		filename,
+		opts,
 	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to import schema: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to import schema", err)
 		return
 	}
 
@@ -163,6 +285,9 @@ func (r *ToolsImportResource) Create(ctx context.Context, req resource.CreateReq
 }
 
 func (r *ToolsImportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data ToolsImportResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -170,22 +295,15 @@ func (r *ToolsImportResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	// Check if schema file still exists and calculate current hash
-	schemaContent, err := os.ReadFile(data.SchemaFile.ValueString())
-	if err != nil {
-		// File doesn't exist anymore, but that's OK - keep state as is
-		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-		return
-	}
-
-	hash := sha256.Sum256(schemaContent)
-	hashStr := hex.EncodeToString(hash[:])
-	data.SchemaHash = types.StringValue(hashStr)
-
+	// The schema file is compared against schema_hash during plan, so changes show up as an
+	// update instead of being absorbed into state here
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ToolsImportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data ToolsImportResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -193,16 +311,14 @@ func (r *ToolsImportResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	// Read schema file
-	schemaContent, err := os.ReadFile(data.SchemaFile.ValueString())
+	schemaContent, filename, err := toolsImportSchema(ctx, data)
 	if err != nil {
-		resp.Diagnostics.AddError("File Error", "Unable to read schema file: "+err.Error())
+		resp.Diagnostics.AddError("Schema Error", "Unable to read schema: "+err.Error())
 		return
 	}
 
 	// Calculate hash
-	hash := sha256.Sum256(schemaContent)
-	hashStr := hex.EncodeToString(hash[:])
+	hashStr := schemaHash(schemaContent, data.SchemaType.ValueString(), data.CanonicalHash.ValueBool())
 
 	// Determine source type based on schema type
 	var sourceType string
@@ -216,8 +332,12 @@ func (r *ToolsImportResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	opts := toolsImportOptions(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Re-import and upsert schema
-	filename := filepath.Base(data.SchemaFile.ValueString())
 	err = r.client.ImportAndUpsertSchema(
 		ctx,
 		data.ApplicationID.ValueString(),
@@ -225,9 +345,10 @@ func (r *ToolsImportResource) Update(ctx context.Context, req resource.UpdateReq
 		sourceType,
 		schemaContent,
 		filename,
+		opts,
 	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to import schema: "+err.Error())
+		addClientError(&resp.Diagnostics, "Unable to import schema", err)
 		return
 	}
 
@@ -238,6 +359,9 @@ func (r *ToolsImportResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *ToolsImportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
 	var data ToolsImportResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -252,3 +376,148 @@ func (r *ToolsImportResource) Delete(ctx context.Context, req resource.DeleteReq
 		resp.Diagnostics.AddWarning("Cleanup Warning", "Unable to delete tools: "+err.Error())
 	}
 }
+
+func (r *ToolsImportResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
+
+func (r *ToolsImportResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+// toolsImportOptions builds the client import options from the resource model
+func toolsImportOptions(ctx context.Context, data ToolsImportResourceModel, diags *diag.Diagnostics) client.ImportOptions {
+	opts := client.ImportOptions{
+		NamingStrategy: data.NamingStrategy.ValueString(),
+		NamePrefix:     data.NamePrefix.ValueString(),
+		NameSuffix:     data.NameSuffix.ValueString(),
+		Tags:           expandStringSet(ctx, data.Tags, diags),
+	}
+
+	// Description controls only apply to OpenAPI schemas. Without any of them, the descriptions
+	// generated by the import endpoint are kept as they were before the controls existed.
+	if data.SchemaType.ValueString() == "openapi" && (!data.IncludeSummary.IsNull() || !data.IncludeDescription.IsNull() || !data.IncludeParameterDocs.IsNull()) {
+		opts.Description = &client.DescriptionOptions{
+			IncludeSummary:       data.IncludeSummary.IsNull() || data.IncludeSummary.ValueBool(),
+			IncludeDescription:   data.IncludeDescription.IsNull() || data.IncludeDescription.ValueBool(),
+			IncludeParameterDocs: data.IncludeParameterDocs.ValueBool(),
+		}
+	}
+
+	if !data.MaxDescriptionLength.IsNull() {
+		if data.MaxDescriptionLength.ValueInt64() < 1 {
+			diags.AddAttributeError(
+				path.Root("max_description_length"),
+				"Invalid Description Length",
+				"max_description_length must be at least 1",
+			)
+		}
+		opts.MaxDescriptionLength = int(data.MaxDescriptionLength.ValueInt64())
+	}
+
+	switch opts.NamingStrategy {
+	case "":
+		opts.NamingStrategy = client.NamingStrategyOperationID
+	case client.NamingStrategyOperationID, client.NamingStrategyMethodPath, client.NamingStrategySummarySlug:
+	default:
+		diags.AddAttributeError(
+			path.Root("naming_strategy"),
+			"Invalid Naming Strategy",
+			"naming_strategy must be 'operation_id', 'method_path' or 'summary_slug'",
+		)
+	}
+
+	return opts
+}
+
+// schemaHashPlanModifier plans schema_hash as unknown when the schema file no longer matches
+// the hash in state, so editing the file shows up as an update in terraform plan.
+type schemaHashPlanModifier struct{}
+
+func (m schemaHashPlanModifier) Description(ctx context.Context) string {
+	return "Marks the hash as changing when the schema file contents change."
+}
+
+func (m schemaHashPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m schemaHashPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to compare against on create or destroy
+	if req.StateValue.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data ToolsImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.SchemaFile.IsUnknown() || data.SchemaFragments.IsUnknown() || data.SchemaType.IsUnknown() || data.CanonicalHash.IsUnknown() {
+		return
+	}
+
+	schemaContent, _, err := toolsImportSchema(ctx, data)
+	if err != nil {
+		// Keep the hash in state; apply reports the missing file or invalid fragments if an
+		// update is needed
+		resp.PlanValue = req.StateValue
+		return
+	}
+
+	if schemaHash(schemaContent, data.SchemaType.ValueString(), data.CanonicalHash.ValueBool()) == req.StateValue.ValueString() {
+		resp.PlanValue = req.StateValue
+		return
+	}
+
+	resp.PlanValue = types.StringUnknown()
+}
+
+// toolsImportSchema returns the schema to import and its upload file name, read from schema_file
+// or merged from schema_fragments
+func toolsImportSchema(ctx context.Context, data ToolsImportResourceModel) ([]byte, string, error) {
+	if data.SchemaFragments.IsNull() {
+		content, err := os.ReadFile(data.SchemaFile.ValueString())
+		if err != nil {
+			return nil, "", err
+		}
+		return content, filepath.Base(data.SchemaFile.ValueString()), nil
+	}
+
+	var fragments []string
+	if diags := data.SchemaFragments.ElementsAs(ctx, &fragments, false); diags.HasError() {
+		return nil, "", fmt.Errorf("invalid schema_fragments")
+	}
+	merged, err := mergeGraphQLFragments(fragments)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to merge schema_fragments: %w", err)
+	}
+	return []byte(merged), "schema.graphql", nil
+}
+
+// schemaHash returns the SHA256 hash of a schema file. With canonical set, OpenAPI documents
+// are hashed in a canonical JSON form so only semantic changes alter the hash; content that
+// cannot be parsed is hashed as is.
+func schemaHash(content []byte, schemaType string, canonical bool) string {
+	if canonical && schemaType == "openapi" {
+		if normalized, err := canonicalizeOpenAPI(content); err == nil {
+			content = normalized
+		}
+	}
+
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+// canonicalizeOpenAPI re-encodes a JSON or YAML document as compact JSON with sorted keys
+func canonicalizeOpenAPI(content []byte) ([]byte, error) {
+	// YAML is a superset of JSON, so both formats decode the same way
+	var document interface{}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+
+	// encoding/json writes map keys in sorted order
+	return json.Marshal(document)
+}
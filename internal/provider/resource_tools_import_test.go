@@ -2,9 +2,19 @@ package provider
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestToolsImportResourceHasExpectedSchema(t *testing.T) {
@@ -16,13 +26,24 @@ func TestToolsImportResourceHasExpectedSchema(t *testing.T) {
 	r.Schema(context.Background(), req, resp)
 
 	// Check required attributes
-	requiredAttrs := []string{"application_id", "source_id", "schema_file", "schema_type"}
+	requiredAttrs := []string{"application_id", "source_id", "schema_type"}
 	for _, attr := range requiredAttrs {
 		if _, ok := resp.Schema.Attributes[attr]; !ok {
 			t.Errorf("expected attribute '%s' in schema", attr)
 		}
 	}
 
+	// Check optional attributes
+	optionalAttrs := []string{
+		"schema_file", "schema_fragments", "naming_strategy", "name_prefix", "name_suffix",
+		"include_summary", "include_description", "include_parameter_docs", "max_description_length", "tags", "canonical_hash",
+	}
+	for _, attr := range optionalAttrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected optional attribute '%s' in schema", attr)
+		}
+	}
+
 	// Check computed attributes
 	computedAttrs := []string{"id", "schema_hash", "tools_count"}
 	for _, attr := range computedAttrs {
@@ -32,6 +53,115 @@ func TestToolsImportResourceHasExpectedSchema(t *testing.T) {
 	}
 }
 
+func TestToolsImportOptions(t *testing.T) {
+	var diags diag.Diagnostics
+	opts := toolsImportOptions(context.Background(), ToolsImportResourceModel{
+		NamingStrategy: types.StringValue("method_path"),
+		NamePrefix:     types.StringValue("crm_"),
+		Tags:           types.SetValueMust(types.StringType, []attr.Value{types.StringValue("crm")}),
+	}, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if opts.NamingStrategy != client.NamingStrategyMethodPath || opts.NamePrefix != "crm_" || opts.NameSuffix != "" {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+
+	if len(opts.Tags) != 1 || opts.Tags[0] != "crm" {
+		t.Errorf("unexpected tags: %v", opts.Tags)
+	}
+
+	if opts.Description != nil {
+		t.Error("expected no description options without schema_type openapi")
+	}
+
+	diags = diag.Diagnostics{}
+	toolsImportOptions(context.Background(), ToolsImportResourceModel{NamingStrategy: types.StringValue("random")}, &diags)
+	if !diags.HasError() {
+		t.Error("expected an error for an unknown naming strategy")
+	}
+
+	diags = diag.Diagnostics{}
+	opts = toolsImportOptions(context.Background(), ToolsImportResourceModel{
+		SchemaType:           types.StringValue("openapi"),
+		IncludeSummary:       types.BoolValue(true),
+		IncludeDescription:   types.BoolValue(false),
+		IncludeParameterDocs: types.BoolValue(true),
+		MaxDescriptionLength: types.Int64Value(200),
+	}, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if opts.Description == nil || !opts.Description.IncludeSummary || opts.Description.IncludeDescription || !opts.Description.IncludeParameterDocs {
+		t.Errorf("unexpected description options: %+v", opts.Description)
+	}
+	if opts.MaxDescriptionLength != 200 {
+		t.Errorf("expected max description length 200, got %d", opts.MaxDescriptionLength)
+	}
+
+	// Without include flags the import endpoint's descriptions are kept, as before the flags existed
+	opts = toolsImportOptions(context.Background(), ToolsImportResourceModel{SchemaType: types.StringValue("openapi")}, &diags)
+	if opts.Description != nil {
+		t.Errorf("expected no description options without include flags, got %+v", opts.Description)
+	}
+
+	opts = toolsImportOptions(context.Background(), ToolsImportResourceModel{
+		SchemaType:         types.StringValue("openapi"),
+		IncludeDescription: types.BoolValue(false),
+	}, &diags)
+	if opts.Description == nil || !opts.Description.IncludeSummary || opts.Description.IncludeDescription || opts.Description.IncludeParameterDocs {
+		t.Errorf("expected the summary only when include_description is false, got %+v", opts.Description)
+	}
+
+	diags = diag.Diagnostics{}
+	toolsImportOptions(context.Background(), ToolsImportResourceModel{MaxDescriptionLength: types.Int64Value(0)}, &diags)
+	if !diags.HasError() {
+		t.Error("expected an error for a zero max_description_length")
+	}
+}
+
+func TestToolsImportNamingChangesRequireReplace(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	NewToolsImportResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := ToolsImportResourceModel{
+		ID:              types.StringValue("source-1"),
+		ApplicationID:   types.StringValue("app-1"),
+		SourceID:        types.StringValue("source-1"),
+		SchemaType:      types.StringValue("openapi"),
+		SchemaFragments: types.ListNull(types.StringType),
+		NamingStrategy:  types.StringValue(client.NamingStrategyOperationID),
+		Tags:            types.SetNull(types.StringType),
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := state.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if diags := plan.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// Renamed tools would otherwise be imported next to the tools under the old names
+	for _, name := range []string{"naming_strategy", "name_prefix", "name_suffix"} {
+		attribute, ok := schemaResp.Schema.Attributes[name].(schema.StringAttribute)
+		if !ok {
+			t.Fatalf("expected %s to be a string attribute", name)
+		}
+
+		req := planmodifier.StringRequest{Plan: plan, State: state, StateValue: types.StringValue("old"), PlanValue: types.StringValue("new")}
+		resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+		for _, modifier := range attribute.PlanModifiers {
+			modifier.PlanModifyString(ctx, req, resp)
+		}
+		if !resp.RequiresReplace {
+			t.Errorf("expected changing %s to require replacement", name)
+		}
+	}
+}
+
 func TestToolsImportResourceMetadata(t *testing.T) {
 	r := NewToolsImportResource()
 
@@ -52,3 +182,175 @@ func TestToolsImportResourceImplementsResource(t *testing.T) {
 	var _ = r
 	// Note: ToolsImportResource does not implement ImportState
 }
+
+func TestSchemaHash(t *testing.T) {
+	original := []byte(`{"openapi":"3.0.0","info":{"title":"Users","version":"1.0"}}`)
+	reformatted := []byte("{\n  \"info\": {\n    \"version\": \"1.0\",\n    \"title\": \"Users\"\n  },\n  \"openapi\": \"3.0.0\"\n}\n")
+	asYAML := []byte("openapi: 3.0.0\ninfo:\n  title: Users\n  version: \"1.0\"\n")
+	changed := []byte(`{"openapi":"3.0.0","info":{"title":"Users","version":"1.1"}}`)
+
+	if schemaHash(original, "openapi", false) == schemaHash(reformatted, "openapi", false) {
+		t.Error("expected raw hashes to differ for reformatted documents")
+	}
+	if schemaHash(original, "openapi", true) != schemaHash(reformatted, "openapi", true) {
+		t.Error("expected canonical hashes to ignore whitespace and key order")
+	}
+	if schemaHash(original, "openapi", true) != schemaHash(asYAML, "openapi", true) {
+		t.Error("expected canonical hashes to match between JSON and YAML")
+	}
+	if schemaHash(original, "openapi", true) == schemaHash(changed, "openapi", true) {
+		t.Error("expected canonical hashes to change with the document")
+	}
+
+	graphql := []byte("type Query {\n  users: [User]\n}\n")
+	if schemaHash(graphql, "graphql", true) != schemaHash(graphql, "graphql", false) {
+		t.Error("expected GraphQL schemas to be hashed as is")
+	}
+
+	invalid := []byte("{not: valid: yaml")
+	if schemaHash(invalid, "openapi", true) != schemaHash(invalid, "openapi", false) {
+		t.Error("expected unparseable documents to be hashed as is")
+	}
+}
+
+func TestSchemaHashPlanModifier(t *testing.T) {
+	ctx := context.Background()
+	schemaFile := filepath.Join(t.TempDir(), "openapi.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"openapi":"3.0.0"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	stateHash := schemaHash([]byte(`{"openapi":"3.0.0"}`), "openapi", false)
+
+	schemaResp := &resource.SchemaResponse{}
+	NewToolsImportResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := plan.Set(ctx, &ToolsImportResourceModel{
+		ID:                   types.StringValue("app-1:src-1"),
+		ApplicationID:        types.StringValue("app-1"),
+		SourceID:             types.StringValue("src-1"),
+		SchemaFile:           types.StringValue(schemaFile),
+		SchemaType:           types.StringValue("openapi"),
+		SchemaFragments:      types.ListNull(types.StringType),
+		SchemaHash:           types.StringUnknown(),
+		CanonicalHash:        types.BoolValue(false),
+		ToolsCount:           types.Int64Value(-1),
+		NamingStrategy:       types.StringValue(client.NamingStrategyOperationID),
+		NamePrefix:           types.StringNull(),
+		NameSuffix:           types.StringNull(),
+		IncludeSummary:       types.BoolValue(true),
+		IncludeDescription:   types.BoolValue(true),
+		IncludeParameterDocs: types.BoolValue(false),
+		MaxDescriptionLength: types.Int64Null(),
+		Tags:                 types.SetNull(types.StringType),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	modify := func(stateValue types.String) types.String {
+		req := planmodifier.StringRequest{Plan: plan, StateValue: stateValue, PlanValue: types.StringUnknown()}
+		resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+		schemaHashPlanModifier{}.PlanModifyString(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		return resp.PlanValue
+	}
+
+	if planned := modify(types.StringValue(stateHash)); planned.ValueString() != stateHash {
+		t.Errorf("expected the state hash to be kept for an unchanged file, got %s", planned)
+	}
+
+	if err := os.WriteFile(schemaFile, []byte(`{"openapi":"3.1.0"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if planned := modify(types.StringValue(stateHash)); !planned.IsUnknown() {
+		t.Errorf("expected an unknown hash for a changed file, got %s", planned)
+	}
+
+	if planned := modify(types.StringNull()); !planned.IsUnknown() {
+		t.Errorf("expected the hash to stay unknown on create, got %s", planned)
+	}
+}
+
+func TestToolsImportSchemaFromFragments(t *testing.T) {
+	ctx := context.Background()
+
+	content, filename, err := toolsImportSchema(ctx, ToolsImportResourceModel{
+		SchemaFile: types.StringNull(),
+		SchemaFragments: types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("type Query { orders: [String] }"),
+			types.StringValue("extend type Query { users: [String] }"),
+		}),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if filename != "schema.graphql" || string(content) != "type Query {\n  orders: [String]\n  users: [String]\n}\n" {
+		t.Errorf("unexpected merged schema %s: %s", filename, content)
+	}
+
+	_, _, err = toolsImportSchema(ctx, ToolsImportResourceModel{
+		SchemaFile: types.StringNull(),
+		SchemaFragments: types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("type Query { orders: [String] }"),
+			types.StringValue("type Query { orders: Int }"),
+		}),
+	})
+	if err == nil {
+		t.Error("expected conflicting fragments to be rejected")
+	}
+}
+
+func TestToolsImportValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := &ToolsImportResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	validate := func(schemaFile string, fragments []string, schemaType string) int {
+		values := map[string]tftypes.Value{}
+		for name, attrType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+		values["schema_type"] = tftypes.NewValue(tftypes.String, schemaType)
+		if schemaFile != "" {
+			values["schema_file"] = tftypes.NewValue(tftypes.String, schemaFile)
+		}
+		if fragments != nil {
+			elements := []tftypes.Value{}
+			for _, fragment := range fragments {
+				elements = append(elements, tftypes.NewValue(tftypes.String, fragment))
+			}
+			values["schema_fragments"] = tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elements)
+		}
+
+		resp := &resource.ValidateConfigResponse{}
+		r.ValidateConfig(ctx, resource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+		}, resp)
+		return resp.Diagnostics.ErrorsCount()
+	}
+
+	if errs := validate("schema.graphql", nil, "graphql"); errs != 0 {
+		t.Errorf("expected schema_file alone to be valid, got %d errors", errs)
+	}
+	if errs := validate("", []string{"type Query { a: String }"}, "graphql"); errs != 0 {
+		t.Errorf("expected schema_fragments alone to be valid, got %d errors", errs)
+	}
+	if errs := validate("", nil, "graphql"); errs != 1 {
+		t.Errorf("expected an error without a schema, got %d", errs)
+	}
+	if errs := validate("schema.graphql", []string{"type Query { a: String }"}, "graphql"); errs != 1 {
+		t.Errorf("expected an error with both schema_file and schema_fragments, got %d", errs)
+	}
+	if errs := validate("", []string{"type Query { a: String }"}, "openapi"); errs != 1 {
+		t.Errorf("expected an error for OpenAPI fragments, got %d", errs)
+	}
+}
@@ -0,0 +1,359 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frontegg/terraform-provider-agentlink/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &VendorSettingsResource{}
+var _ resource.ResourceWithImportState = &VendorSettingsResource{}
+var _ resource.ResourceWithMoveState = &VendorSettingsResource{}
+var _ resource.ResourceWithUpgradeState = &VendorSettingsResource{}
+
+func NewVendorSettingsResource() resource.Resource {
+	return &VendorSettingsResource{}
+}
+
+// VendorSettingsResource defines the resource implementation.
+type VendorSettingsResource struct {
+	client *client.Client
+}
+
+// VendorSettingsResourceModel describes the resource data model.
+type VendorSettingsResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	SupportEmail      types.String `tfsdk:"support_email"`
+	Logo              types.String `tfsdk:"logo"`
+	Icon              types.String `tfsdk:"icon"`
+	AppURL            types.String `tfsdk:"app_url"`
+	LoginURL          types.String `tfsdk:"login_url"`
+	Country           types.String `tfsdk:"country"`
+	Timezone          types.String `tfsdk:"timezone"`
+	DateFormat        types.String `tfsdk:"date_format"`
+	TimeFormat        types.String `tfsdk:"time_format"`
+	StartWeekDay      types.String `tfsdk:"start_week_day"`
+	Currency          types.String `tfsdk:"currency"`
+	OpenSaaSInstalled types.Bool   `tfsdk:"open_saas_installed"`
+}
+
+func (r *VendorSettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vendor_settings"
+}
+
+func (r *VendorSettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages vendor-level settings such as the vendor name, support email and localization defaults. This is a singleton resource; allowed origins are managed by agentlink_allowed_origins.",
+		Version:     0,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The vendor ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The vendor name.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"support_email": schema.StringAttribute{
+				Description: "The support email shown to end users.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"logo": schema.StringAttribute{
+				Description: "URL of the vendor logo.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"icon": schema.StringAttribute{
+				Description: "URL of the vendor icon.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"app_url": schema.StringAttribute{
+				Description: "The vendor application URL.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"login_url": schema.StringAttribute{
+				Description: "The vendor login URL.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"country": schema.StringAttribute{
+				Description: "The vendor country.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"timezone": schema.StringAttribute{
+				Description: "The default timezone, e.g. UTC.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"date_format": schema.StringAttribute{
+				Description: "The default date format.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"time_format": schema.StringAttribute{
+				Description: "The default time format.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"start_week_day": schema.StringAttribute{
+				Description: "The first day of the week.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"currency": schema.StringAttribute{
+				Description: "The default currency.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"open_saas_installed": schema.BoolAttribute{
+				Description: "Whether the OpenSaaS integration is installed.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *VendorSettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *client.Client, got something else.",
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *VendorSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data VendorSettingsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The vendor always exists, so creating the resource adopts it and applies the configured settings
+	config, err := r.client.UpdateVendorSettings(ctx, expandVendorSettings(data))
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to create vendor settings", err)
+		return
+	}
+
+	flattenVendorSettings(config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VendorSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data VendorSettingsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetVendorConfig(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read vendor settings", err)
+		return
+	}
+
+	flattenVendorSettings(config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VendorSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	var data VendorSettingsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.UpdateVendorSettings(ctx, expandVendorSettings(data))
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to update vendor settings", err)
+		return
+	}
+
+	flattenVendorSettings(config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VendorSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, maintenance := watchMaintenance(ctx)
+	defer maintenance(&resp.Diagnostics)
+
+	// The vendor cannot be deleted through this resource, it's a singleton.
+	// On destroy, we simply remove it from state. The settings remain on the
+	// server with their current values.
+}
+
+func (r *VendorSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Only the vendor the provider is authenticated as can be imported
+	config, err := r.client.GetVendorConfig(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read vendor settings", err)
+		return
+	}
+
+	if req.ID != config.ID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected the ID of the vendor the provider is authenticated as (%s), got %q.", config.ID, req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *VendorSettingsResource) MoveState(ctx context.Context) []resource.StateMover {
+	return legacyStateMovers(ctx, r)
+}
+
+func (r *VendorSettingsResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+// expandVendorSettings builds the vendor settings request from the resource model.
+// Unset attributes are omitted so the server keeps its current values.
+func expandVendorSettings(data VendorSettingsResourceModel) client.UpdateVendorSettingsRequest {
+	var req client.UpdateVendorSettingsRequest
+
+	if !data.Name.IsNull() && !data.Name.IsUnknown() {
+		req.Name = data.Name.ValueString()
+	}
+	if !data.SupportEmail.IsNull() && !data.SupportEmail.IsUnknown() {
+		req.SupportEmail = data.SupportEmail.ValueString()
+	}
+	if !data.Logo.IsNull() && !data.Logo.IsUnknown() {
+		req.Logo = data.Logo.ValueString()
+	}
+	if !data.Icon.IsNull() && !data.Icon.IsUnknown() {
+		req.Icon = data.Icon.ValueString()
+	}
+	if !data.AppURL.IsNull() && !data.AppURL.IsUnknown() {
+		req.AppURL = data.AppURL.ValueString()
+	}
+	if !data.LoginURL.IsNull() && !data.LoginURL.IsUnknown() {
+		req.LoginURL = data.LoginURL.ValueString()
+	}
+	if !data.Country.IsNull() && !data.Country.IsUnknown() {
+		req.Country = data.Country.ValueString()
+	}
+	if !data.Timezone.IsNull() && !data.Timezone.IsUnknown() {
+		req.Timezone = data.Timezone.ValueString()
+	}
+	if !data.DateFormat.IsNull() && !data.DateFormat.IsUnknown() {
+		req.DateFormat = data.DateFormat.ValueString()
+	}
+	if !data.TimeFormat.IsNull() && !data.TimeFormat.IsUnknown() {
+		req.TimeFormat = data.TimeFormat.ValueString()
+	}
+	if !data.StartWeekDay.IsNull() && !data.StartWeekDay.IsUnknown() {
+		req.StartWeekDay = data.StartWeekDay.ValueString()
+	}
+	if !data.Currency.IsNull() && !data.Currency.IsUnknown() {
+		req.Currency = data.Currency.ValueString()
+	}
+	if !data.OpenSaaSInstalled.IsNull() && !data.OpenSaaSInstalled.IsUnknown() {
+		openSaaSInstalled := data.OpenSaaSInstalled.ValueBool()
+		req.OpenSaaSInstalled = &openSaaSInstalled
+	}
+
+	return req
+}
+
+// flattenVendorSettings maps a vendor config response onto the resource model
+func flattenVendorSettings(config *client.VendorConfig, data *VendorSettingsResourceModel) {
+	data.ID = types.StringValue(config.ID)
+	data.Name = types.StringValue(config.Name)
+	data.SupportEmail = types.StringValue(config.SupportEmail)
+	data.Logo = types.StringValue(config.Logo)
+	data.Icon = types.StringValue(config.Icon)
+	data.AppURL = types.StringValue(config.AppURL)
+	data.LoginURL = types.StringValue(config.LoginURL)
+	data.Country = types.StringValue(config.Country)
+	data.Timezone = types.StringValue(config.Timezone)
+	data.DateFormat = types.StringValue(config.DateFormat)
+	data.TimeFormat = types.StringValue(config.TimeFormat)
+	data.StartWeekDay = types.StringValue(config.StartWeekDay)
+	data.Currency = types.StringValue(config.Currency)
+	data.OpenSaaSInstalled = types.BoolValue(config.OpenSaaSInstalled)
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestVendorSettingsResourceHasExpectedSchema(t *testing.T) {
+	r := NewVendorSettingsResource()
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.Background(), req, resp)
+
+	// Check optional attributes
+	optionalAttrs := []string{
+		"name", "support_email", "logo", "icon", "app_url", "login_url", "country",
+		"timezone", "date_format", "time_format", "start_week_day", "currency", "open_saas_installed",
+	}
+	for _, attr := range optionalAttrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected optional attribute '%s' in schema", attr)
+		}
+	}
+
+	// Check computed attribute
+	if _, ok := resp.Schema.Attributes["id"]; !ok {
+		t.Error("expected 'id' attribute in schema")
+	}
+
+	// Allowed origins are managed by agentlink_allowed_origins
+	if _, ok := resp.Schema.Attributes["allowed_origins"]; ok {
+		t.Error("expected 'allowed_origins' not to be managed by vendor settings")
+	}
+}
+
+func TestVendorSettingsResourceMetadata(t *testing.T) {
+	r := NewVendorSettingsResource()
+
+	req := resource.MetadataRequest{ProviderTypeName: "agentlink"}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	expected := "agentlink_vendor_settings"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name '%s', got '%s'", expected, resp.TypeName)
+	}
+}
+
+func TestVendorSettingsResourceImplementsResource(t *testing.T) {
+	r := NewVendorSettingsResource()
+
+	var _ = r
+	var _ resource.ResourceWithImportState = r.(*VendorSettingsResource)
+}
+
+func TestExpandVendorSettings(t *testing.T) {
+	req := expandVendorSettings(VendorSettingsResourceModel{
+		Name:              types.StringValue("Acme"),
+		SupportEmail:      types.StringValue("support@example.com"),
+		Timezone:          types.StringUnknown(),
+		Currency:          types.StringNull(),
+		OpenSaaSInstalled: types.BoolValue(false),
+	})
+
+	if req.Name != "Acme" || req.SupportEmail != "support@example.com" {
+		t.Errorf("unexpected request: %+v", req)
+	}
+	if req.Timezone != "" || req.Currency != "" {
+		t.Errorf("expected unset attributes to be omitted, got %+v", req)
+	}
+	if req.OpenSaaSInstalled == nil || *req.OpenSaaSInstalled {
+		t.Errorf("expected open_saas_installed to be false, got %v", req.OpenSaaSInstalled)
+	}
+}